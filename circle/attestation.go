@@ -66,3 +66,56 @@ func CheckAttestation(attestationURL string, logger log.Logger, irisLookupID str
 
 	return &response
 }
+
+// CheckAttestationV2 queries the iris v2 messages api for all CCTP V2 messages emitted in a source transaction.
+// Unlike V1, the lookup is done by source domain and transaction hash because the nonce is only known once
+// Circle attests to the message.
+func CheckAttestationV2(messagesURL string, logger log.Logger, txHash string, sourceDomain, destDomain types.Domain) *types.AttestationV2Response {
+	// append ending / if not present
+	if messagesURL[len(messagesURL)-1:] != "/" {
+		messagesURL += "/"
+	}
+
+	url := fmt.Sprintf("%s%d?transactionHash=%s", messagesURL, sourceDomain, txHash)
+
+	logger.Debug(fmt.Sprintf("Checking v2 attestation for %s from %d to %d", url, sourceDomain, destDomain))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		logger.Debug("error creating request: " + err.Error())
+		return nil
+	}
+
+	client := http.Client{}
+	rawResponse, err := client.Do(req)
+	if err != nil {
+		logger.Debug("error during request: " + err.Error())
+		return nil
+	}
+
+	defer rawResponse.Body.Close()
+	if rawResponse.StatusCode != http.StatusOK {
+		logger.Debug("non 200 response received from Circles v2 messages API")
+		return nil
+	}
+
+	body, err := io.ReadAll(rawResponse.Body)
+	if err != nil {
+		logger.Debug("unable to parse message body")
+		return nil
+	}
+
+	response := types.AttestationV2Response{}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		logger.Debug("unable to unmarshal response")
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Found %d v2 message(s) for %s", len(response.Messages), url))
+
+	return &response
+}
@@ -0,0 +1,32 @@
+package circle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CheckReachable returns an error if the attestation api at url can not be reached or responds with a server error.
+// Any other response, e.g. a 404 for the bare base url, means the api is up.
+func CheckReachable(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	client := http.Client{}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error during request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%d response received from Circles attestation API", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// Names of the address list filters
+const (
+	FilterDeniedAddresses   = "denied-addresses"
+	FilterUnlistedAddresses = "unlisted-addresses"
+)
+
+// defaultAddressListRefreshSeconds is how often the address list files are reloaded if refresh-interval is not set
+const defaultAddressListRefreshSeconds = 3600
+
+// screenedAddresses holds the loaded address lists
+var screenedAddresses = &addressLists{}
+
+// loadedAddressList is an address list file loaded into memory
+type loadedAddressList struct {
+	name      string
+	domains   []types.Domain
+	addresses map[relayer.Address]struct{}
+}
+
+// appliesTo returns true if the list applies to addresses of the domain
+func (l *loadedAddressList) appliesTo(domain types.Domain) bool {
+	return len(l.domains) == 0 || slices.Contains(l.domains, domain)
+}
+
+func (l *loadedAddressList) contains(domain types.Domain, address relayer.Address) bool {
+	if !l.appliesTo(domain) {
+		return false
+	}
+	_, ok := l.addresses[address]
+	return ok
+}
+
+// addressLists are the allow and deny lists transfers are screened against. The lists are replaced together when
+// the files are reloaded.
+type addressLists struct {
+	mu    sync.RWMutex
+	deny  []*loadedAddressList
+	allow []*loadedAddressList
+}
+
+// load reads the list files of settings. If any of them fails to load, the current lists are kept.
+func (s *addressLists) load(settings types.AddressListSettings) error {
+	deny, err := loadAddressLists(settings.Deny)
+	if err != nil {
+		return err
+	}
+	allow, err := loadAddressLists(settings.Allow)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.deny, s.allow = deny, allow
+	s.mu.Unlock()
+	return nil
+}
+
+func loadAddressLists(lists []types.AddressList) ([]*loadedAddressList, error) {
+	loaded := make([]*loadedAddressList, 0, len(lists))
+	for _, list := range lists {
+		addresses, err := relayer.LoadAddressList(list.File, list.Format)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load address list %s: %w", addressListName(list), err)
+		}
+		loaded = append(loaded, &loadedAddressList{
+			name:      addressListName(list),
+			domains:   list.Domains,
+			addresses: addresses,
+		})
+	}
+	return loaded, nil
+}
+
+func addressListName(list types.AddressList) string {
+	if list.Name != "" {
+		return list.Name
+	}
+	return filepath.Base(list.File)
+}
+
+// sizes returns the number of addresses of each list, by list name
+func (s *addressLists) sizes() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sizes := make(map[string]int)
+	for _, list := range append(slices.Clone(s.deny), s.allow...) {
+		sizes[list.name] = len(list.addresses)
+	}
+	return sizes
+}
+
+// screenedAddress is an address of a transfer that is matched against the address lists
+type screenedAddress struct {
+	// role of the address in the transfer
+	role    string
+	domain  types.Domain
+	address relayer.Address
+	// bech32 is the address as it is known on the chain it is forwarded to, empty if it is not a forward address
+	bech32 string
+	// allowListed addresses must be on an allow list if one applies to their domain
+	allowListed bool
+}
+
+// transferAddresses returns the addresses of a message. Burns are screened by the burn's sender and the message
+// sender (the source TokenMessenger) on the source domain, and the mint recipient on the destination domain. Forwards
+// are screened by the sender and the final recipient of their metadata, and so are the burns they are paired with in
+// tx, so a mint is not relayed towards a forward recipient that would be denied.
+func transferAddresses(tx *types.TxState, msg *types.MessageState) ([]screenedAddress, error) {
+	if msg.Type == types.Forward {
+		return forwardAddresses(msg)
+	}
+
+	message, err := new(types.Message).Parse(msg.MsgSentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse message: %w", err)
+	}
+	burn, err := new(types.BurnMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse burn message: %w", err)
+	}
+
+	var addresses []screenedAddress
+	for _, a := range []struct {
+		role        string
+		domain      types.Domain
+		bz          []byte
+		allowListed bool
+	}{
+		{"burn sender", msg.SourceDomain, burn.MessageSender, true},
+		{"message sender", msg.SourceDomain, message.Sender, false},
+		{"mint recipient", msg.DestDomain, burn.MintRecipient, true},
+	} {
+		address, err := relayer.BytesToAddress(a.bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", a.role, err)
+		}
+		addresses = append(addresses, screenedAddress{role: a.role, domain: a.domain, address: address, allowListed: a.allowListed})
+	}
+
+	if tx != nil {
+		if forward := tx.Pair(msg); forward != nil {
+			forwarded, err := forwardAddresses(forward)
+			if err != nil {
+				return nil, err
+			}
+			addresses = append(addresses, forwarded...)
+		}
+	}
+	return addresses, nil
+}
+
+// forwardAddresses returns the sender and the final recipient of a forward, bech32 encoded with the prefix of the
+// chain the forward goes to. They are screened on the forward's destination domain, the chain it leaves from.
+func forwardAddresses(msg *types.MessageState) ([]screenedAddress, error) {
+	metadata, err := new(types.MetadataMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse metadata message: %w", err)
+	}
+
+	var addresses []screenedAddress
+	for _, a := range []struct {
+		role        string
+		bz          []byte
+		allowListed bool
+	}{
+		{"forward sender", metadata.Sender, false},
+		{"forward recipient", metadata.Recipient, true},
+	} {
+		// metadata addresses are left padded to 32 bytes, cosmos account addresses are 20
+		bz := a.bz
+		if len(bz) == 32 && bytes.Count(bz[:12], []byte{0}) == 12 {
+			bz = bz[12:]
+		}
+		encoded, err := sdk.Bech32ifyAddressBytes(metadata.Prefix, bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", a.role, err)
+		}
+		address, err := relayer.NormalizeAddress(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", a.role, err)
+		}
+		addresses = append(addresses, screenedAddress{role: a.role, domain: msg.DestDomain, address: address, bech32: encoded, allowListed: a.allowListed})
+	}
+	return addresses, nil
+}
+
+// readableAddress returns the address as a noble bech32 address if the domain is noble's, and as hex otherwise.
+// Forward addresses are shown with the prefix of the chain they are forwarded to.
+func readableAddress(chains map[types.Domain]types.Chain, a screenedAddress) string {
+	if a.bech32 != "" {
+		return fmt.Sprintf("%s (%s)", a.bech32, a.address)
+	}
+	if _, ok := chains[a.domain].(*noble.Noble); ok {
+		if bech32, err := sdk.Bech32ifyAddressBytes("noble", a.address[12:]); err == nil {
+			return fmt.Sprintf("%s (%s)", bech32, a.address)
+		}
+	}
+	return a.address.String()
+}
+
+// denied returns true if an address of the transfer is on a deny list that applies to its domain. Transfers that
+// can not be parsed are denied while deny lists are loaded.
+func (s *addressLists) denied(fc *FilterContext, msg *types.MessageState) (bool, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.deny) == 0 {
+		return false, ""
+	}
+
+	addresses, err := transferAddresses(fc.Tx, msg)
+	if err != nil {
+		return true, err.Error()
+	}
+	for _, a := range addresses {
+		for _, list := range s.deny {
+			if list.contains(a.domain, a.address) {
+				return true, fmt.Sprintf("%s %s on domain %d is on deny list %s",
+					a.role, readableAddress(fc.Chains, a), a.domain, list.name)
+			}
+		}
+	}
+	return false, ""
+}
+
+// unlisted returns true if the burn sender, mint recipient or a forward address is not on any of the allow lists that apply to its
+// domain. Domains without allow lists are not restricted.
+func (s *addressLists) unlisted(fc *FilterContext, msg *types.MessageState) (bool, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.allow) == 0 {
+		return false, ""
+	}
+
+	addresses, err := transferAddresses(fc.Tx, msg)
+	if err != nil {
+		return true, err.Error()
+	}
+	for _, a := range addresses {
+		if !a.allowListed {
+			continue
+		}
+
+		restricted, allowed := false, false
+		for _, list := range s.allow {
+			restricted = restricted || list.appliesTo(a.domain)
+			allowed = allowed || list.contains(a.domain, a.address)
+		}
+		if restricted && !allowed {
+			return true, fmt.Sprintf("%s %s on domain %d is not on an allow list",
+				a.role, readableAddress(fc.Chains, a), a.domain)
+		}
+	}
+	return false, ""
+}
+
+func init() {
+	RegisterFilter(NewFilterFunc(FilterDeniedAddresses, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		filtered, detail := screenedAddresses.denied(fc, msg)
+		if filtered {
+			fc.Logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d: %s", msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, detail))
+		}
+		return filtered, detail
+	}))
+	RegisterFilter(NewFilterFunc(FilterUnlistedAddresses, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		filtered, detail := screenedAddresses.unlisted(fc, msg)
+		if filtered {
+			fc.Logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d: %s", msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, detail))
+		}
+		return filtered, detail
+	}))
+}
+
+// validateAddressLists checks that every list has a file and a supported format, and that the filters of the
+// configured lists are part of the filter chain
+func validateAddressLists(cfg *types.Config) error {
+	settings := cfg.AddressLists
+	for _, list := range append(slices.Clone(settings.Deny), settings.Allow...) {
+		if list.File == "" {
+			return fmt.Errorf("address list %q has no file", list.Name)
+		}
+		switch list.Format {
+		case "", relayer.AddressListFormatPlain, relayer.AddressListFormatOFAC:
+		default:
+			return fmt.Errorf("address list %s has unsupported format %q, must be %s or %s",
+				addressListName(list), list.Format, relayer.AddressListFormatPlain, relayer.AddressListFormatOFAC)
+		}
+	}
+
+	names := filterNames(cfg)
+	if len(settings.Deny) > 0 && !slices.Contains(names, FilterDeniedAddresses) {
+		return fmt.Errorf("filters must include %s when deny lists are configured", FilterDeniedAddresses)
+	}
+	if len(settings.Allow) > 0 && !slices.Contains(names, FilterUnlistedAddresses) {
+		return fmt.Errorf("filters must include %s when allow lists are configured", FilterUnlistedAddresses)
+	}
+	return nil
+}
+
+// refreshAddressLists reloads the address list files every refresh-interval until ctx is done. If a file fails to
+// load, the lists loaded before stay in use.
+func refreshAddressLists(ctx context.Context, a *AppState) {
+	logger := a.Logger.With("routine", "address-lists")
+	for {
+		interval := a.CurrentConfig().AddressLists.RefreshInterval
+		if interval <= 0 {
+			interval = defaultAddressListRefreshSeconds
+		}
+
+		timer := time.NewTimer(time.Duration(interval) * time.Second)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		if err := screenedAddresses.load(a.CurrentConfig().AddressLists); err != nil {
+			logger.Error("Unable to reload address lists, the previously loaded lists stay in use", "err", err)
+			continue
+		}
+		logAddressLists(logger)
+	}
+}
+
+func logAddressLists(logger log.Logger) {
+	for name, size := range screenedAddresses.sizes() {
+		logger.Debug("Loaded address list", "list", name, "addresses", size)
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// screenedTransfer returns a V1 burn from sourceDomain to destDomain with the given burn sender and mint recipient
+func screenedTransfer(sourceDomain, destDomain types.Domain, sender, recipient relayer.Address) *types.MessageState {
+	body := burnMsgBody(100)
+	copy(body[36:68], recipient[:])
+	copy(body[100:132], sender[:])
+
+	message := append(make([]byte, 116), body...)
+	// the source TokenMessenger
+	message[51] = 0xee
+
+	return &types.MessageState{
+		SourceDomain: sourceDomain,
+		DestDomain:   destDomain,
+		MsgSentBytes: message,
+		MsgBody:      body,
+	}
+}
+
+// screenedForward returns a forward from sourceDomain to noble and its mint, the forward sends to the osmo recipient
+func screenedForward(sourceDomain types.Domain, recipient relayer.Address) (*types.TxState, *types.MessageState, *types.MessageState) {
+	mint := screenedTransfer(sourceDomain, 4, relayer.Address{31: 0xa}, relayer.Address{31: 0xb})
+	mint.Type, mint.Nonce, mint.Channel = types.Mint, 10, "channel-1"
+
+	body := make([]byte, 112)
+	binary.BigEndian.PutUint64(body[0:], 10)
+	body[39] = 0xa
+	binary.BigEndian.PutUint64(body[40:], 1)
+	copy(body[48+28:80], "osmo")
+	copy(body[80:112], recipient[:])
+	forward := &types.MessageState{SourceDomain: sourceDomain, DestDomain: 4, Type: types.Forward, Nonce: 11, Channel: "channel-1", MsgBody: body}
+
+	return &types.TxState{Msgs: []*types.MessageState{mint, forward}}, mint, forward
+}
+
+func writeAddressList(t *testing.T, addresses ...string) string {
+	file := filepath.Join(t.TempDir(), "list.txt")
+	content := ""
+	for _, address := range addresses {
+		content += address + "\n"
+	}
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestAddressLists(t *testing.T) {
+	sanctioned := relayer.Address{31: 0x1}
+	customer := relayer.Address{31: 0x2}
+	other := relayer.Address{31: 0x3}
+
+	lists := &addressLists{}
+	fc := &FilterContext{Chains: map[types.Domain]types.Chain{}, Logger: log.NewNopLogger()}
+
+	// nothing is screened without lists
+	filtered, _ := lists.denied(fc, screenedTransfer(0, 4, sanctioned, sanctioned))
+	require.False(t, filtered)
+
+	require.NoError(t, lists.load(types.AddressListSettings{
+		Deny:  []types.AddressList{{Name: "sanctions", File: writeAddressList(t, sanctioned.String())}},
+		Allow: []types.AddressList{{Name: "customers", File: writeAddressList(t, customer.String()), Domains: []types.Domain{3}}},
+	}))
+
+	filtered, detail := lists.denied(fc, screenedTransfer(0, 4, other, sanctioned))
+	require.True(t, filtered)
+	require.Equal(t, "mint recipient "+sanctioned.String()+" on domain 4 is on deny list sanctions", detail)
+
+	filtered, detail = lists.denied(fc, screenedTransfer(0, 4, sanctioned, other))
+	require.True(t, filtered)
+	require.Contains(t, detail, "burn sender")
+
+	filtered, _ = lists.denied(fc, screenedTransfer(0, 4, other, other))
+	require.False(t, filtered)
+
+	// only domain 3 is restricted to the allow list
+	filtered, _ = lists.unlisted(fc, screenedTransfer(0, 4, other, other))
+	require.False(t, filtered)
+	filtered, _ = lists.unlisted(fc, screenedTransfer(0, 3, other, customer))
+	require.False(t, filtered)
+	filtered, detail = lists.unlisted(fc, screenedTransfer(0, 3, customer, other))
+	require.True(t, filtered)
+	require.Equal(t, "mint recipient "+other.String()+" on domain 3 is not on an allow list", detail)
+
+	// lists that fail to load leave the loaded lists in place
+	require.Error(t, lists.load(types.AddressListSettings{
+		Deny: []types.AddressList{{File: filepath.Join(t.TempDir(), "missing.txt")}},
+	}))
+	filtered, _ = lists.denied(fc, screenedTransfer(0, 4, sanctioned, other))
+	require.True(t, filtered)
+}
+
+func TestValidateAddressLists(t *testing.T) {
+	cfg := &types.Config{AddressLists: types.AddressListSettings{
+		Deny: []types.AddressList{{File: "sdn.csv", Format: relayer.AddressListFormatOFAC}},
+	}}
+	require.NoError(t, validateAddressLists(cfg))
+
+	cfg.AddressLists.Deny[0].Format = "xml"
+	require.ErrorContains(t, validateAddressLists(cfg), "unsupported format")
+
+	// deny lists are useless without their filter
+	cfg.AddressLists.Deny[0].Format = ""
+	cfg.Filters = []string{FilterDisabledRoutes, FilterDestinationCallers, FilterMessageVersions, FilterUnpairedForwards}
+	require.ErrorContains(t, validateAddressLists(cfg), FilterDeniedAddresses)
+}
+
+func TestAddressListsForwards(t *testing.T) {
+	sanctioned := relayer.Address{12: 0x1, 31: 0x1}
+	other := relayer.Address{12: 0x1, 31: 0x3}
+	sanctionedOsmo, err := sdk.Bech32ifyAddressBytes("osmo", sanctioned[12:])
+	require.NoError(t, err)
+
+	lists := &addressLists{}
+	require.NoError(t, lists.load(types.AddressListSettings{
+		Deny:  []types.AddressList{{Name: "sanctions", File: writeAddressList(t, sanctionedOsmo)}},
+		Allow: []types.AddressList{{Name: "customers", File: writeAddressList(t, other.String()), Domains: []types.Domain{4}}},
+	}))
+
+	// the forward recipient is screened on the forward and on its mint
+	tx, mint, forward := screenedForward(0, sanctioned)
+	fc := &FilterContext{Chains: map[types.Domain]types.Chain{}, Tx: tx, Logger: log.NewNopLogger()}
+	filtered, detail := lists.denied(fc, forward)
+	require.True(t, filtered)
+	require.Equal(t, "forward recipient "+sanctionedOsmo+" ("+sanctioned.String()+") on domain 4 is on deny list sanctions", detail)
+	filtered, detail = lists.denied(fc, mint)
+	require.True(t, filtered)
+	require.Contains(t, detail, "forward recipient "+sanctionedOsmo)
+
+	tx, mint, forward = screenedForward(0, other)
+	fc.Tx = tx
+	filtered, _ = lists.denied(fc, forward)
+	require.False(t, filtered)
+	filtered, _ = lists.denied(fc, mint)
+	require.False(t, filtered)
+
+	// forward recipients must be on the allow lists of the forward's destination domain
+	filtered, _ = lists.unlisted(fc, forward)
+	require.False(t, filtered)
+	tx, _, forward = screenedForward(0, sanctioned)
+	fc.Tx = tx
+	filtered, detail = lists.unlisted(fc, forward)
+	require.True(t, filtered)
+	require.Contains(t, detail, "forward recipient "+sanctionedOsmo)
+}
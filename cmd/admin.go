@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// envAdminToken overrides api.admin-token, so the token does not have to be stored in the config file
+const envAdminToken = "ADMIN_TOKEN"
+
+// adminToken returns the bearer token required by the admin API, the admin API is disabled if it is empty
+func adminToken(cfg *types.Config) string {
+	if token := os.Getenv(envAdminToken); token != "" {
+		return token
+	}
+	return cfg.API.AdminToken
+}
+
+// pauseRequest pauses or resumes relaying to Dest, or only from Source to Dest if Source is set
+type pauseRequest struct {
+	Source *types.Domain `json:"source,omitempty"`
+	Dest   *types.Domain `json:"dest"`
+	Reason string        `json:"reason,omitempty"`
+}
+
+// String describes what is paused or resumed
+func (r pauseRequest) String() string {
+	if r.Source == nil {
+		return fmt.Sprintf("relaying to %d", *r.Dest)
+	}
+	return fmt.Sprintf("relaying from %d to %d", *r.Source, *r.Dest)
+}
+
+// registerAdminRoutes adds the authenticated admin endpoints under /admin
+func registerAdminRoutes(router *gin.Engine, token string, health *relayerHealth, reloader *configReloader) {
+	admin := router.Group("/admin", requireAdminToken(token))
+
+	admin.GET("/pauses", func(c *gin.Context) {
+		c.JSON(http.StatusOK, pauses.status())
+	})
+	admin.POST("/pause", postPause)
+	admin.POST("/resume", postResume)
+	admin.POST("/flush/:chain", func(c *gin.Context) {
+		postFlush(c, health.registeredChains())
+	})
+	admin.POST("/config/reload", func(c *gin.Context) {
+		if err := reloader.reload(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "config reloaded"})
+	})
+}
+
+// requireAdminToken rejects requests without the admin bearer token, and all requests if no token is configured
+func requireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"message": fmt.Sprintf("admin API is disabled, set api.admin-token or %s to enable it", envAdminToken),
+			})
+			return
+		}
+
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func bindPauseRequest(c *gin.Context) (pauseRequest, bool) {
+	var req pauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "unable to parse request: " + err.Error()})
+		return req, false
+	}
+	if req.Dest == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "dest is required"})
+		return req, false
+	}
+	return req, true
+}
+
+// postPause pauses a destination domain or route, attested messages are held until it is resumed
+func postPause(c *gin.Context) {
+	req, ok := bindPauseRequest(c)
+	if !ok {
+		return
+	}
+
+	pauses.pause(req.Source, *req.Dest, req.Reason)
+	c.JSON(http.StatusOK, gin.H{"message": "paused " + req.String()})
+}
+
+// postResume resumes a destination domain or route, held messages are requeued
+func postResume(c *gin.Context) {
+	req, ok := bindPauseRequest(c)
+	if !ok {
+		return
+	}
+
+	requeued := pauses.resume(req.Source, *req.Dest)
+	c.JSON(http.StatusOK, gin.H{"message": "resumed " + req.String(), "requeued_txs": requeued})
+}
+
+// postFlush makes a chain flush right away
+func postFlush(c *gin.Context, chains []types.Chain) {
+	name := c.Param("chain")
+	for _, chain := range chains {
+		if !strings.EqualFold(chain.Name(), name) {
+			continue
+		}
+		if err := chain.RequestFlush(); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"message": "flush requested for " + chain.Name()})
+		return
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"message": "chain not found"})
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	flagAdminAPI = "api-address"
+	flagSource   = "source"
+	flagDest     = "dest"
+	flagReason   = "reason"
+)
+
+// Commands for the admin API of a running relayer
+func adminCmd(a *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Manage a running relayer through its admin API",
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			a.InitAppState()
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s admin pause --dest 0 --reason "paused message transmitter"
+$ %s admin resume --dest 0
+$ %s admin pause --source 4 --dest 3
+$ %s admin pauses
+$ %s admin flush ethereum
+$ %s admin reload`, appName, appName, appName, appName, appName, appName)),
+	}
+	cmd.PersistentFlags().String(flagAdminAPI, "http://localhost:8000", "address of the relayer's API")
+
+	cmd.AddCommand(
+		adminPauseCmd(a),
+		adminResumeCmd(a),
+		&cobra.Command{
+			Use:   "pauses",
+			Short: "Show the paused destination domains and routes",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return adminRequest(cmd, a, http.MethodGet, "/admin/pauses", nil)
+			},
+		},
+		&cobra.Command{
+			Use:   "flush [chain-name]",
+			Short: "Flush a chain right away",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return adminRequest(cmd, a, http.MethodPost, "/admin/flush/"+args[0], nil)
+			},
+		},
+		&cobra.Command{
+			Use:   "reload",
+			Short: "Reload the relayer's config file",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return adminRequest(cmd, a, http.MethodPost, "/admin/config/reload", nil)
+			},
+		},
+	)
+	return cmd
+}
+
+func adminPauseCmd(a *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause relaying to a destination domain, or from a source to a destination domain",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			req, err := pauseRequestFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			return adminRequest(cmd, a, http.MethodPost, "/admin/pause", req)
+		},
+	}
+	cmd.Flags().String(flagReason, "", "why relaying is paused")
+	return addPauseFlags(cmd)
+}
+
+func adminResumeCmd(a *AppState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume relaying to a destination domain, or from a source to a destination domain",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			req, err := pauseRequestFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			return adminRequest(cmd, a, http.MethodPost, "/admin/resume", req)
+		},
+	}
+	return addPauseFlags(cmd)
+}
+
+func addPauseFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Uint32(flagDest, 0, "destination domain")
+	cmd.Flags().Uint32(flagSource, 0, "source domain, only the route from this domain is paused or resumed if set")
+	_ = cmd.MarkFlagRequired(flagDest)
+	return cmd
+}
+
+func pauseRequestFromFlags(cmd *cobra.Command) (pauseRequest, error) {
+	var req pauseRequest
+
+	dest, err := cmd.Flags().GetUint32(flagDest)
+	if err != nil {
+		return req, err
+	}
+	d := types.Domain(dest)
+	req.Dest = &d
+
+	if cmd.Flags().Changed(flagSource) {
+		source, err := cmd.Flags().GetUint32(flagSource)
+		if err != nil {
+			return req, err
+		}
+		s := types.Domain(source)
+		req.Source = &s
+	}
+
+	if cmd.Flags().Lookup(flagReason) != nil {
+		if req.Reason, err = cmd.Flags().GetString(flagReason); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
+
+// adminRequest sends an authenticated request to the admin API and prints the response
+func adminRequest(cmd *cobra.Command, a *AppState, method, path string, body any) error {
+	address, err := cmd.Flags().GetString(flagAdminAPI)
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		bz, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("unable to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(bz)
+	}
+
+	req, err := http.NewRequestWithContext(cmd.Context(), method, strings.TrimSuffix(address, "/")+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken(a.Config))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to reach the relayer's API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("request failed with status %s", resp.Status)
+	}
+	return nil
+}
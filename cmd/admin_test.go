@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func adminStatus(router *gin.Engine, method, path, token, body string) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAdminAuth(t *testing.T) {
+	health := newRelayerHealth(&types.Config{}, relayer.NewSupervisor(log.NewNopLogger(), nil))
+
+	// disabled without a token
+	router := gin.New()
+	registerAdminRoutes(router, "", health, nil)
+	require.Equal(t, http.StatusForbidden, adminStatus(router, http.MethodGet, "/admin/pauses", "", ""))
+
+	router = gin.New()
+	registerAdminRoutes(router, "secret", health, nil)
+	require.Equal(t, http.StatusUnauthorized, adminStatus(router, http.MethodGet, "/admin/pauses", "", ""))
+	require.Equal(t, http.StatusUnauthorized, adminStatus(router, http.MethodGet, "/admin/pauses", "wrong", ""))
+	require.Equal(t, http.StatusOK, adminStatus(router, http.MethodGet, "/admin/pauses", "secret", ""))
+
+	require.Equal(t, http.StatusBadRequest, adminStatus(router, http.MethodPost, "/admin/pause", "secret", `{"source": 0}`))
+	require.Equal(t, http.StatusNotFound, adminStatus(router, http.MethodPost, "/admin/flush/unknown", "secret", ""))
+}
+
+func TestPauseHoldsAttestedMsgs(t *testing.T) {
+	router := gin.New()
+	registerAdminRoutes(router, "secret", newRelayerHealth(&types.Config{}, relayer.NewSupervisor(log.NewNopLogger(), nil)), nil)
+
+	processingQueue := make(chan *types.TxState, 10)
+	toEth := withStatus(&types.MessageState{SourceDomain: 4, DestDomain: 0}, types.Attested)
+	toArb := withStatus(&types.MessageState{SourceDomain: 4, DestDomain: 3}, types.Attested)
+	tx := &types.TxState{TxHash: "0xpaused", Msgs: []*types.MessageState{toEth, toArb}}
+	broadcastMsgs := map[types.Domain][]*types.MessageState{0: {toEth}, 3: {toArb}}
+
+	// pause a destination and a route
+	require.Equal(t, http.StatusOK, adminStatus(router, http.MethodPost, "/admin/pause", "secret", `{"dest": 0, "reason": "incident"}`))
+	require.Equal(t, http.StatusOK, adminStatus(router, http.MethodPost, "/admin/pause", "secret", `{"source": 4, "dest": 3}`))
+	status := pauses.status()
+	require.Len(t, status.Destinations, 1)
+	require.Equal(t, "incident", status.Destinations[0].Reason)
+	require.Len(t, status.Routes, 1)
+
+	unpaused := holdPausedMsgs(context.Background(), log.NewNopLogger(), tx, broadcastMsgs, processingQueue)
+	require.Empty(t, unpaused)
+	require.Equal(t, 2, pauses.status().HeldMsgs)
+	require.False(t, pauses.release(toEth))
+
+	// resuming the destination requeues the tx, the route stays paused
+	require.Equal(t, http.StatusOK, adminStatus(router, http.MethodPost, "/admin/resume", "secret", `{"dest": 0}`))
+	select {
+	case requeued := <-processingQueue:
+		require.Equal(t, tx, requeued)
+	case <-time.After(time.Second):
+		t.Fatal("tx was not requeued")
+	}
+	require.True(t, pauses.release(toEth))
+	require.False(t, pauses.release(toArb))
+	require.Equal(t, types.Attested, toArb.Status())
+
+	require.Equal(t, http.StatusOK, adminStatus(router, http.MethodPost, "/admin/resume", "secret", `{"source": 4, "dest": 3}`))
+	<-processingQueue
+	require.True(t, pauses.release(toArb))
+	require.Zero(t, pauses.status().HeldMsgs)
+}
+
+func TestPausePrunesFinishedMsgs(t *testing.T) {
+	pauses = newPauseRegistry()
+	t.Cleanup(func() { pauses = newPauseRegistry() })
+
+	pauses.pause(nil, 0, "incident")
+	ctx, cancel := context.WithCancel(context.Background())
+	msg := withStatus(&types.MessageState{SourceDomain: 4, DestDomain: 0}, types.Attested)
+	filtered := withStatus(&types.MessageState{SourceDomain: 4, DestDomain: 0}, types.Attested)
+	tx := &types.TxState{TxHash: "0xpruned", Msgs: []*types.MessageState{msg, filtered}}
+	require.True(t, pauses.holdIfPaused(ctx, tx, msg, make(chan *types.TxState)))
+	require.True(t, pauses.holdIfPaused(ctx, tx, filtered, make(chan *types.TxState)))
+
+	// messages filtered after a reload are no longer held
+	require.NoError(t, filtered.SetStatus(types.Filtered))
+	require.Equal(t, 1, pauses.status().HeldMsgs)
+
+	// after shutdown nothing reads the queue, the requeue gives up
+	cancel()
+	require.Equal(t, 1, pauses.resume(nil, 0))
+	require.True(t, pauses.release(msg))
+}
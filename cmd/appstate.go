@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 
@@ -26,6 +27,9 @@ type AppState struct {
 	LogLevel string
 
 	Logger log.Logger
+
+	// live is the config applied to the running relayer, it is replaced atomically when the config is reloaded
+	live atomic.Pointer[types.Config]
 }
 
 func NewAppState() *AppState {
@@ -62,6 +66,15 @@ func (a *AppState) InitLogger() {
 	}
 }
 
+// CurrentConfig returns the config applied to the running relayer. It starts out as Config and changes when the
+// config file is reloaded.
+func (a *AppState) CurrentConfig() *types.Config {
+	if cfg := a.live.Load(); cfg != nil {
+		return cfg
+	}
+	return a.Config
+}
+
 // loadConfigFile loads a configuration into the AppState. It uses the AppState ConfigPath
 // to determine file path to config.
 func (a *AppState) loadConfigFile() {
@@ -97,6 +110,7 @@ func (a *AppState) validateConfig() error {
 				"",
 				cc.RPC,
 				"",
+				false,
 				cc.BroadcastRetries,
 				cc.BroadcastRetryInterval,
 				cc.MinMintAmount,
@@ -113,6 +127,7 @@ func (a *AppState) validateConfig() error {
 				fmt.Sprintf("%d", cc.Domain),
 				cc.RPC,
 				cc.WS,
+				cc.Polling,
 				cc.BroadcastRetries,
 				cc.BroadcastRetryInterval,
 				cc.MinMintAmount,
@@ -134,6 +149,22 @@ func (a *AppState) validateConfig() error {
 		return err
 	}
 
+	if err := validateRoutePolicies(a.Config); err != nil {
+		return err
+	}
+	if err := validateFilters(a.Config); err != nil {
+		return err
+	}
+	if err := validateAddressLists(a.Config); err != nil {
+		return err
+	}
+	if err := validateRelayCost(a.Config); err != nil {
+		return err
+	}
+	if err := validateBroadcastPriority(a.Config); err != nil {
+		return err
+	}
+
 	// validate processor worker count
 	if a.Config.ProcessorWorkerCount == 0 {
 		return fmt.Errorf("ProcessorWorkerCount must be greater than zero in the config")
@@ -149,6 +180,7 @@ func (a *AppState) validateChain(
 	domain string,
 	rpcURL string,
 	wsURL string,
+	polling bool,
 	broadcastRetries int,
 	broadcastRetryInterval int,
 	minMintAmount uint64,
@@ -170,9 +202,9 @@ func (a *AppState) validateChain(
 		return fmt.Errorf("rpcURL must be set in the config (chain: %s) (rpcURL: %s)", name, rpcURL)
 	}
 
-	// we do not use a websocket for noble
-	if wsURL == "" && name != nobleChainName {
-		return fmt.Errorf("wsURL must be set in the config (chain: %s) (wsURL: %s)", name, wsURL)
+	// we do not use a websocket for noble, or for chains that poll for logs
+	if wsURL == "" && name != nobleChainName && !polling {
+		return fmt.Errorf("wsURL must be set in the config unless polling is enabled (chain: %s) (wsURL: %s)", name, wsURL)
 	}
 
 	if broadcastRetries <= 0 {
@@ -201,5 +233,13 @@ func (a *AppState) validateCircleConfig() error {
 		return fmt.Errorf("FetchRetryInterval must be greater than zero in the config")
 	}
 
+	// v2 messages are looked up with a different api
+	for name, cfg := range a.Config.Chains {
+		cc, ok := cfg.(*ethereum.ChainConfig)
+		if ok && cc.MessageTransmitterV2 != "" && a.Config.Circle.AttestationV2BaseURL == "" {
+			return fmt.Errorf("AttestationV2BaseUrl is required in the config when a v2 message transmitter is set (chain: %s)", name)
+		}
+	}
+
 	return nil
 }
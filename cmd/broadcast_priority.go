@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// Names of the broadcast priority rules
+const (
+	PriorityDestinationCaller = "destination-caller"
+	PriorityRoute             = "route"
+	PriorityAmount            = "amount"
+	PriorityAge               = "age"
+)
+
+// defaultPriorityRules are the rules used when broadcast-priority rules is empty
+var defaultPriorityRules = []string{PriorityDestinationCaller, PriorityRoute, PriorityAmount, PriorityAge}
+
+// defaultPriorityMaxWaitSeconds is how long a batch waits before it goes first if max-wait is not set
+const defaultPriorityMaxWaitSeconds = 300
+
+// broadcasts holds the attested batches waiting for a broadcaster worker of their destination. Workers take the
+// highest priority batch of their destination next.
+var broadcasts = newBroadcastScheduler()
+
+// broadcastBatch is a batch of messages of a tx waiting to be broadcast to a destination
+type broadcastBatch struct {
+	tx     *types.TxState
+	msgs   []*types.MessageState
+	queued time.Time
+	// seq keeps batches that are equal by every rule in the order they were queued
+	seq uint64
+}
+
+type broadcastScheduler struct {
+	mu      sync.Mutex
+	waiting map[types.Domain][]*broadcastBatch
+	// signals wake up a broadcaster worker of the domain when a batch is queued
+	signals map[types.Domain]chan struct{}
+	seq     uint64
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func newBroadcastScheduler() *broadcastScheduler {
+	return &broadcastScheduler{
+		waiting: make(map[types.Domain][]*broadcastBatch),
+		signals: make(map[types.Domain]chan struct{}),
+		now:     time.Now,
+	}
+}
+
+// enqueue queues msgs of tx for the broadcaster workers of domain and returns the number of batches waiting
+func (s *broadcastScheduler) enqueue(domain types.Domain, tx *types.TxState, msgs []*types.MessageState) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	s.waiting[domain] = append(s.waiting[domain], &broadcastBatch{tx: tx, msgs: msgs, queued: s.now(), seq: s.seq})
+	s.notify(domain)
+	return len(s.waiting[domain])
+}
+
+// next waits until a batch is queued for domain and returns the highest priority one, with the number of batches
+// still waiting. It returns an error if ctx is done first.
+func (s *broadcastScheduler) next(ctx context.Context, settings types.BroadcastPrioritySettings, domain types.Domain) (*broadcastBatch, int, error) {
+	for {
+		s.mu.Lock()
+		if waiting := s.waiting[domain]; len(waiting) > 0 {
+			now := s.now()
+			next := slices.MinFunc(waiting, func(a, b *broadcastBatch) int {
+				return comparePriority(settings, now, a, b)
+			})
+			s.waiting[domain] = slices.DeleteFunc(waiting, func(b *broadcastBatch) bool { return b == next })
+			remaining := len(s.waiting[domain])
+			// other workers of the domain may be waiting for the remaining batches
+			if remaining > 0 {
+				s.notify(domain)
+			}
+			s.mu.Unlock()
+			return next, remaining, nil
+		}
+		signal := s.signal(domain)
+		s.mu.Unlock()
+
+		select {
+		case <-signal:
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
+}
+
+// signal returns the channel waking up the workers of domain. The caller must hold mu.
+func (s *broadcastScheduler) signal(domain types.Domain) chan struct{} {
+	signal, ok := s.signals[domain]
+	if !ok {
+		signal = make(chan struct{}, 1)
+		s.signals[domain] = signal
+	}
+	return signal
+}
+
+// notify wakes up a worker of domain, if none is awake already. The caller must hold mu.
+func (s *broadcastScheduler) notify(domain types.Domain) {
+	select {
+	case s.signal(domain) <- struct{}{}:
+	default:
+	}
+}
+
+// comparePriority returns a negative number if a goes before b. Batches waiting longer than max-wait go first,
+// the others are compared by the rules in order.
+func comparePriority(settings types.BroadcastPrioritySettings, now time.Time, a, b *broadcastBatch) int {
+	maxWait := time.Duration(settings.MaxWait) * time.Second
+	if settings.MaxWait <= 0 {
+		maxWait = defaultPriorityMaxWaitSeconds * time.Second
+	}
+	aStarved, bStarved := now.Sub(a.queued) >= maxWait, now.Sub(b.queued) >= maxWait
+	switch {
+	case aStarved && bStarved:
+		return compareSeq(a, b)
+	case aStarved:
+		return -1
+	case bStarved:
+		return 1
+	}
+
+	for _, rule := range priorityRules(settings) {
+		var c int
+		switch rule {
+		case PriorityDestinationCaller:
+			c = compareBool(batchHasDestinationCaller(b.msgs), batchHasDestinationCaller(a.msgs))
+		case PriorityRoute:
+			c = routeWeight(settings, b.msgs) - routeWeight(settings, a.msgs)
+		case PriorityAmount:
+			c = batchAmount(b.msgs).Cmp(batchAmount(a.msgs))
+		case PriorityAge:
+			c = a.queued.Compare(b.queued)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareSeq(a, b)
+}
+
+func priorityRules(settings types.BroadcastPrioritySettings) []string {
+	if len(settings.Rules) == 0 {
+		return defaultPriorityRules
+	}
+	return settings.Rules
+}
+
+func compareSeq(a, b *broadcastBatch) int {
+	switch {
+	case a.seq < b.seq:
+		return -1
+	case a.seq > b.seq:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// batchHasDestinationCaller returns true if any message of the batch is for us only. Messages with a destination
+// caller other than ours are filtered before they are broadcast.
+func batchHasDestinationCaller(msgs []*types.MessageState) bool {
+	return slices.ContainsFunc(msgs, func(msg *types.MessageState) bool { return !permissionless(msg) })
+}
+
+// routeWeight returns the highest weight of the routes of the batch
+func routeWeight(settings types.BroadcastPrioritySettings, msgs []*types.MessageState) int {
+	var weight int
+	for i, msg := range msgs {
+		msgWeight := 0
+		for _, r := range settings.Routes {
+			if r.Source == msg.SourceDomain && r.Dest == msg.DestDomain {
+				msgWeight = r.Weight
+			}
+		}
+		if i == 0 || msgWeight > weight {
+			weight = msgWeight
+		}
+	}
+	return weight
+}
+
+// batchAmount returns the total amount the burns of the batch transfer
+func batchAmount(msgs []*types.MessageState) *big.Int {
+	total := new(big.Int)
+	for _, msg := range msgs {
+		if msg.Type == types.Forward {
+			continue
+		}
+		if bm, err := new(types.BurnMessage).Parse(msg.MsgBody); err == nil {
+			total.Add(total, bm.Amount)
+		}
+	}
+	return total
+}
+
+// validateBroadcastPriority checks that the broadcast priority rules are known and listed once
+func validateBroadcastPriority(cfg *types.Config) error {
+	rules := cfg.BroadcastPriority.Rules
+	for i, rule := range rules {
+		if !slices.Contains(defaultPriorityRules, rule) {
+			return fmt.Errorf("unknown broadcast-priority rule %q, must be one of %v", rule, defaultPriorityRules)
+		}
+		if slices.Contains(rules[:i], rule) {
+			return fmt.Errorf("broadcast-priority rule %q is listed more than once", rule)
+		}
+	}
+	if cfg.BroadcastPriority.MaxWait < 0 {
+		return fmt.Errorf("broadcast-priority max-wait must not be negative")
+	}
+	return nil
+}
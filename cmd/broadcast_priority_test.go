@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestComparePriority(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ourCaller := make([]byte, 32)
+	ourCaller[31] = 0x1
+
+	ticket := func(seq uint64, waited time.Duration, msgs ...*types.MessageState) *broadcastBatch {
+		return &broadcastBatch{msgs: msgs, queued: now.Add(-waited), seq: seq}
+	}
+	dust := ticket(1, 10*time.Second, &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(1), DestinationCaller: make([]byte, 32)})
+	large := ticket(2, 5*time.Second, &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(1_000_000), DestinationCaller: make([]byte, 32)})
+	ours := ticket(3, time.Second, &types.MessageState{SourceDomain: 3, DestDomain: 4, MsgBody: burnMsgBody(1), DestinationCaller: ourCaller})
+	weighted := ticket(4, time.Second, &types.MessageState{SourceDomain: 1, DestDomain: 4, MsgBody: burnMsgBody(1), DestinationCaller: make([]byte, 32)})
+
+	order := func(settings types.BroadcastPrioritySettings, tickets ...*broadcastBatch) []*broadcastBatch {
+		sorted := append([]*broadcastBatch(nil), tickets...)
+		for i := range sorted {
+			for j := i + 1; j < len(sorted); j++ {
+				if comparePriority(settings, now, sorted[j], sorted[i]) < 0 {
+					sorted[i], sorted[j] = sorted[j], sorted[i]
+				}
+			}
+		}
+		return sorted
+	}
+
+	settings := types.BroadcastPrioritySettings{Routes: []types.RouteWeight{{Source: 1, Dest: 4, Weight: 10}}}
+	require.Equal(t, []*broadcastBatch{ours, weighted, large, dust}, order(settings, dust, large, ours, weighted))
+
+	// rules are compared in the configured order
+	settings.Rules = []string{PriorityAge}
+	require.Equal(t, []*broadcastBatch{dust, large, ours, weighted}, order(settings, weighted, ours, large, dust))
+	settings.Rules = []string{PriorityAmount, PriorityDestinationCaller}
+	require.Equal(t, []*broadcastBatch{large, ours, dust, weighted}, order(settings, dust, large, ours, weighted))
+
+	// batches waiting longer than max-wait go first
+	settings = types.BroadcastPrioritySettings{MaxWait: 8}
+	require.Equal(t, []*broadcastBatch{dust, ours, large, weighted}, order(settings, weighted, large, ours, dust))
+}
+
+func TestBroadcastScheduler(t *testing.T) {
+	s := newBroadcastScheduler()
+	settings := types.BroadcastPrioritySettings{}
+	ctx := context.Background()
+
+	msg := func(amount int64) []*types.MessageState {
+		return []*types.MessageState{{DestDomain: 4, MsgBody: burnMsgBody(amount), DestinationCaller: make([]byte, 32)}}
+	}
+	tx := &types.TxState{TxHash: "0x1"}
+
+	require.Equal(t, 1, s.enqueue(4, tx, msg(10)))
+	require.Equal(t, 2, s.enqueue(4, tx, msg(1000)))
+	require.Equal(t, 1, s.enqueue(0, tx, msg(1_000_000)))
+
+	// the larger transfer goes first, other destinations are independent
+	batch, waiting, err := s.next(ctx, settings, 4)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), batchAmount(batch.msgs).Int64())
+	require.Equal(t, 1, waiting)
+	batch, waiting, err = s.next(ctx, settings, 4)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), batchAmount(batch.msgs).Int64())
+	require.Equal(t, 0, waiting)
+
+	// workers wait for the next batch of their destination
+	next := make(chan *broadcastBatch)
+	go func() {
+		batch, _, err := s.next(ctx, settings, 4)
+		if err == nil {
+			next <- batch
+		}
+	}()
+	select {
+	case <-next:
+		t.Fatal("no batch is queued for the destination")
+	case <-time.After(50 * time.Millisecond):
+	}
+	s.enqueue(4, tx, msg(1))
+	select {
+	case batch := <-next:
+		require.Equal(t, int64(1), batchAmount(batch.msgs).Int64())
+	case <-time.After(time.Second):
+		t.Fatal("worker was not woken up")
+	}
+
+	// waiting workers return once their ctx is done
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = s.next(cancelled, settings, 4)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Len(t, s.waiting[0], 1)
+	require.Empty(t, s.waiting[4])
+}
+
+func TestValidateBroadcastPriority(t *testing.T) {
+	cfg := &types.Config{}
+	require.NoError(t, validateBroadcastPriority(cfg))
+
+	cfg.BroadcastPriority.Rules = []string{PriorityAmount, PriorityAge}
+	require.NoError(t, validateBroadcastPriority(cfg))
+
+	cfg.BroadcastPriority.Rules = []string{PriorityAmount, "fee"}
+	require.ErrorContains(t, validateBroadcastPriority(cfg), "unknown broadcast-priority rule")
+
+	cfg.BroadcastPriority.Rules = []string{PriorityAmount, PriorityAmount}
+	require.ErrorContains(t, validateBroadcastPriority(cfg), "more than once")
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// defaultBroadcasterWorkerCount is the number of broadcaster workers of a destination if none is configured
+	defaultBroadcasterWorkerCount = 1
+	// relayedByOtherRecheckDelay is how long messages relayed by another relayer wait for its tx before they are
+	// broadcast again. It matches how long the mempool watchers remember other relayers' pending txs.
+	relayedByOtherRecheckDelay = 2 * time.Minute
+)
+
+// broadcasterPools runs the broadcaster workers of each destination domain. Each destination has its own queue and
+// workers, so a slow or retrying chain only holds up the broadcasts to itself.
+type broadcasterPools struct {
+	ctx   context.Context
+	start func(ctx context.Context, chain types.Chain)
+
+	mu    sync.Mutex
+	pools map[types.Domain]*processorPool
+}
+
+func newBroadcasterPools(ctx context.Context, start func(ctx context.Context, chain types.Chain)) *broadcasterPools {
+	return &broadcasterPools{ctx: ctx, start: start, pools: make(map[types.Domain]*processorPool)}
+}
+
+// add creates the pool of chain, its workers are started by resize
+func (b *broadcasterPools) add(chain types.Chain) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pools[chain.Domain()] = newProcessorPool(b.ctx, func(ctx context.Context) {
+		b.start(ctx, chain)
+	})
+}
+
+// resize starts or stops the workers of every destination until the configured number are running
+func (b *broadcasterPools) resize(cfg *types.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for domain, pool := range b.pools {
+		pool.resize(broadcasterWorkerCount(cfg, domain))
+	}
+}
+
+// sizes returns the number of running workers by destination domain
+func (b *broadcasterPools) sizes() map[types.Domain]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sizes := make(map[types.Domain]int, len(b.pools))
+	for domain, pool := range b.pools {
+		sizes[domain] = pool.size()
+	}
+	return sizes
+}
+
+// wait blocks until every worker returned
+func (b *broadcasterPools) wait() {
+	b.mu.Lock()
+	pools := make([]*processorPool, 0, len(b.pools))
+	for _, pool := range b.pools {
+		pools = append(pools, pool)
+	}
+	b.mu.Unlock()
+
+	for _, pool := range pools {
+		pool.workers.Wait()
+	}
+}
+
+// broadcasterWorkerCount returns the number of broadcaster workers of domain
+func broadcasterWorkerCount(cfg *types.Config, domain types.Domain) int {
+	if count, ok := cfg.BroadcasterWorkers[domain]; ok && count > 0 {
+		return int(count)
+	}
+	if cfg.BroadcasterWorkerCount > 0 {
+		return int(cfg.BroadcasterWorkerCount)
+	}
+	return defaultBroadcasterWorkerCount
+}
+
+// StartBroadcaster broadcasts the batches queued for chain by the processor workers, highest priority first. It
+// returns once ctx is done and the current batch is broadcast, broadcasts already in flight are not cancelled.
+// Messages that fail to broadcast are marked failed by the chain and are not retried.
+//
+// Batches may wait in the queue while their messages are filtered by a reload or their route is paused, so messages
+// are checked again when they are dequeued: those no longer attested are dropped and paused ones are held until they
+// are resumed, when their tx is requeued to processingQueue.
+func StartBroadcaster(
+	ctx context.Context,
+	a *AppState,
+	chain types.Chain,
+	processingQueue chan *types.TxState,
+	sequenceMap *types.SequenceMap,
+	metrics *relayer.PromMetrics,
+) {
+	logger := a.Logger.With("routine", "broadcaster", "name", chain.Name(), "domain", chain.Domain())
+	domain := chain.Domain()
+
+	// in-flight broadcasts are bounded by the shutdown grace period instead of being cancelled mid-signing, their
+	// retries stop once it has passed
+	broadcastCtx := withGracePeriod(ctx, shutdownGracePeriod(a.CurrentConfig()))
+
+	for {
+		batch, waiting, err := broadcasts.next(ctx, a.CurrentConfig().BroadcastPriority, domain)
+		if err != nil {
+			return
+		}
+		if metrics != nil {
+			metrics.SetBroadcastQueueSize(chain.Name(), fmt.Sprint(domain), waiting)
+		}
+
+		// the mint cost is estimated right before broadcasting, a slow rpc only holds up its own destination
+		msgs := dequeuedMsgs(ctx, logger, batch, processingQueue)
+		msgs = checkRelayCosts(broadcastCtx, logger, a.CurrentConfig(), chain, batch.tx, msgs, processingQueue, metrics)
+		if len(msgs) == 0 {
+			continue
+		}
+
+		// chains move the messages to their new status, private mints stay attested until they are mined
+		if err := chain.Broadcast(broadcastCtx, logger, msgs, sequenceMap, metrics); err != nil {
+			logger.Error("Unable to mint one or more transfers", "error(s)", err, "total_transfers", len(msgs), "src-tx", batch.tx.TxHash)
+		}
+
+		var relayedByOther []*types.MessageState
+		for _, msg := range msgs {
+			if msg.Status() == types.RelayedByOther {
+				relayedByOther = append(relayedByOther, msg)
+			}
+		}
+		if len(relayedByOther) > 0 {
+			go recheckRelayedByOther(ctx, logger, batch.tx, relayedByOther, relayedByOtherRecheckDelay)
+		}
+	}
+}
+
+// recheckRelayedByOther queues msgs for broadcasting again once delay passed, unless the other relayer's tx
+// completed them by then. The other relayer's tx may have been dropped or reverted, broadcasting checks whether the
+// nonce was used before minting, so msgs are only minted if it was not. Messages still received by a pending tx of
+// another relayer are marked relayed-by-other again.
+func recheckRelayedByOther(ctx context.Context, logger log.Logger, tx *types.TxState, msgs []*types.MessageState, delay time.Duration) {
+	timer := time.NewTimer(delay)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+		return
+	}
+
+	var requeue []*types.MessageState
+	State.Mu.Lock()
+	for _, msg := range msgs {
+		if msg.Status() != types.RelayedByOther || msg.SetStatus(types.Attested) != nil {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Msg from %d with tx hash %s was not received by tx %s of another relayer within %v, broadcasting it again",
+			msg.SourceDomain, msg.SourceTxHash, msg.DestTxHash, delay))
+		requeue = append(requeue, msg)
+	}
+	State.Mu.Unlock()
+
+	if len(requeue) > 0 {
+		broadcasts.enqueue(requeue[0].DestDomain, tx, requeue)
+	}
+}
+
+// dequeuedMsgs returns the messages of batch that are still to be broadcast. Messages that are no longer attested,
+// e.g. filtered after a reload or relayed in the meantime, are dropped and messages whose route is paused are held.
+func dequeuedMsgs(ctx context.Context, logger log.Logger, batch *broadcastBatch, processingQueue chan *types.TxState) []*types.MessageState {
+	msgs := make([]*types.MessageState, 0, len(batch.msgs))
+	for _, msg := range batch.msgs {
+		if status := msg.Status(); status != types.Attested {
+			logger.Info(fmt.Sprintf("Dropping msg in tx %s from %d to %d, it is %s since it was queued",
+				msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, status))
+			continue
+		}
+		if pauses.holdIfPaused(ctx, batch.tx, msg, processingQueue) {
+			logger.Info(fmt.Sprintf("Holding msg in tx %s from %d to %d until relaying to %d is resumed",
+				msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, msg.DestDomain))
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
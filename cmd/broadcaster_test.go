@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// blockingChain broadcasts once unblock is closed, marking the messages complete
+type blockingChain struct {
+	fakeChain
+	unblock chan struct{}
+
+	mu          sync.Mutex
+	broadcasted []*types.MessageState
+}
+
+func (c *blockingChain) Broadcast(ctx context.Context, logger log.Logger, msgs []*types.MessageState, _ *types.SequenceMap, _ *relayer.PromMetrics) error {
+	select {
+	case <-c.unblock:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	c.mu.Lock()
+	c.broadcasted = append(c.broadcasted, msgs...)
+	c.mu.Unlock()
+	for _, msg := range msgs {
+		msg.SetBroadcastStatus(logger, types.Complete, 1, nil)
+	}
+	return nil
+}
+
+func (c *blockingChain) received() []*types.MessageState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*types.MessageState(nil), c.broadcasted...)
+}
+
+func TestStartBroadcaster(t *testing.T) {
+	broadcasts = newBroadcastScheduler()
+	t.Cleanup(func() { broadcasts = newBroadcastScheduler() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := &AppState{Config: &types.Config{}, Logger: log.NewNopLogger()}
+	stuck := &blockingChain{fakeChain: fakeChain{name: "stuck", domain: 0}, unblock: make(chan struct{})}
+	healthy := &blockingChain{fakeChain: fakeChain{name: "healthy", domain: 4}, unblock: make(chan struct{})}
+	close(healthy.unblock)
+
+	pools := newBroadcasterPools(ctx, func(ctx context.Context, chain types.Chain) {
+		StartBroadcaster(ctx, a, chain, make(chan *types.TxState, 1), types.NewSequenceMap(), nil)
+	})
+	pools.add(stuck)
+	pools.add(healthy)
+	pools.resize(a.Config)
+	require.Equal(t, map[types.Domain]int{0: 1, 4: 1}, pools.sizes())
+
+	toStuck := withStatus(&types.MessageState{DestDomain: 0}, types.Attested)
+	toHealthy := withStatus(&types.MessageState{DestDomain: 4}, types.Attested)
+	broadcasts.enqueue(0, &types.TxState{TxHash: "0x1"}, []*types.MessageState{toStuck})
+	broadcasts.enqueue(4, &types.TxState{TxHash: "0x2"}, []*types.MessageState{toHealthy})
+
+	// a stuck destination does not hold up the others
+	require.Eventually(t, func() bool { return toHealthy.Status() == types.Complete }, time.Second, time.Millisecond)
+	require.Equal(t, types.Attested, toStuck.Status())
+
+	close(stuck.unblock)
+	require.Eventually(t, func() bool { return toStuck.Status() == types.Complete }, time.Second, time.Millisecond)
+
+	// workers of each destination can be resized on their own
+	pools.resize(&types.Config{BroadcasterWorkerCount: 2, BroadcasterWorkers: map[types.Domain]uint32{4: 3}})
+	require.Equal(t, map[types.Domain]int{0: 2, 4: 3}, pools.sizes())
+
+	cancel()
+	pools.wait()
+}
+
+func TestStartBroadcasterRechecksDequeuedMsgs(t *testing.T) {
+	broadcasts = newBroadcastScheduler()
+	pauses = newPauseRegistry()
+	t.Cleanup(func() {
+		broadcasts = newBroadcastScheduler()
+		pauses = newPauseRegistry()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := &AppState{Config: &types.Config{}, Logger: log.NewNopLogger()}
+	chain := &blockingChain{fakeChain: fakeChain{name: "noble", domain: 4}, unblock: make(chan struct{})}
+	close(chain.unblock)
+	processingQueue := make(chan *types.TxState, 1)
+
+	// filtered while queued
+	filtered := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4}, types.Attested)
+	require.NoError(t, filtered.SetStatus(types.Filtered))
+	// paused while queued
+	paused := withStatus(&types.MessageState{SourceDomain: 1, DestDomain: 4}, types.Attested)
+	source := types.Domain(1)
+	pauses.pause(&source, 4, "maintenance")
+	pausedTx := &types.TxState{TxHash: "0x2", Msgs: []*types.MessageState{paused}}
+	relayed := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4}, types.Attested)
+
+	broadcasts.enqueue(4, &types.TxState{TxHash: "0x1"}, []*types.MessageState{filtered})
+	broadcasts.enqueue(4, pausedTx, []*types.MessageState{paused})
+	broadcasts.enqueue(4, &types.TxState{TxHash: "0x3"}, []*types.MessageState{relayed})
+
+	done := make(chan struct{})
+	go func() {
+		StartBroadcaster(ctx, a, chain, processingQueue, types.NewSequenceMap(), nil)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return relayed.Status() == types.Complete }, time.Second, time.Millisecond)
+	require.Equal(t, []*types.MessageState{relayed}, chain.received())
+	require.Equal(t, types.Filtered, filtered.Status())
+	require.Equal(t, types.Attested, paused.Status())
+
+	// held messages are requeued to the processor once resumed
+	require.Equal(t, 1, pauses.resume(&source, 4))
+	select {
+	case tx := <-processingQueue:
+		require.Equal(t, pausedTx, tx)
+	case <-time.After(time.Second):
+		t.Fatal("paused tx was not requeued")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestRecheckRelayedByOther(t *testing.T) {
+	broadcasts = newBroadcastScheduler()
+	t.Cleanup(func() { broadcasts = newBroadcastScheduler() })
+
+	dropped := withStatus(&types.MessageState{DestDomain: 4}, types.Attested)
+	require.NoError(t, dropped.SetStatus(types.RelayedByOther))
+	landed := withStatus(&types.MessageState{DestDomain: 4}, types.Attested)
+	require.NoError(t, landed.SetStatus(types.RelayedByOther))
+	require.NoError(t, landed.SetStatus(types.Complete))
+	tx := &types.TxState{TxHash: "0x1", Msgs: []*types.MessageState{dropped, landed}}
+
+	recheckRelayedByOther(context.Background(), log.NewNopLogger(), tx, tx.Msgs, time.Millisecond)
+
+	// only the message whose other relayer's tx did not land is broadcast again
+	require.Equal(t, types.Attested, dropped.Status())
+	require.Equal(t, types.Complete, landed.Status())
+	batch, _, err := broadcasts.next(context.Background(), types.BroadcastPrioritySettings{}, 4)
+	require.NoError(t, err)
+	require.Equal(t, []*types.MessageState{dropped}, batch.msgs)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -65,12 +66,27 @@ func ParseConfig(file string) (*types.Config, error) {
 		return nil, fmt.Errorf("error unmarshalling config: %w", err)
 	}
 
+	enabledRoutes, routePolicies, err := splitEnabledRoutes(cfg.EnabledRoutes)
+	if err != nil {
+		return nil, err
+	}
+
 	c := types.Config{
-		EnabledRoutes:        cfg.EnabledRoutes,
-		Circle:               cfg.Circle,
-		ProcessorWorkerCount: cfg.ProcessorWorkerCount,
-		API:                  cfg.API,
-		Chains:               make(map[string]types.ChainConfig),
+		EnabledRoutes:          enabledRoutes,
+		RoutePolicies:          routePolicies,
+		Circle:                 cfg.Circle,
+		FastTransfer:           cfg.FastTransfer,
+		Filters:                cfg.Filters,
+		AddressLists:           cfg.AddressLists,
+		RelayCost:              cfg.RelayCost,
+		BroadcastPriority:      cfg.BroadcastPriority,
+		Health:                 cfg.Health,
+		Shutdown:               cfg.Shutdown,
+		ProcessorWorkerCount:   cfg.ProcessorWorkerCount,
+		BroadcasterWorkerCount: cfg.BroadcasterWorkerCount,
+		BroadcasterWorkers:     cfg.BroadcasterWorkers,
+		API:                    cfg.API,
+		Chains:                 make(map[string]types.ChainConfig),
 	}
 
 	for name, chain := range cfg.Chains {
@@ -96,3 +112,34 @@ func ParseConfig(file string) (*types.Config, error) {
 	}
 	return &c, err
 }
+
+// splitEnabledRoutes returns the destination domains of each source domain in enabled-routes, and the policies set
+// on them
+func splitEnabledRoutes(
+	routes map[types.Domain][]types.EnabledRoute,
+) (map[types.Domain][]types.Domain, map[types.Domain]map[types.Domain]types.RoutePolicy, error) {
+	if routes == nil {
+		return nil, nil, nil
+	}
+
+	enabled := make(map[types.Domain][]types.Domain)
+	policies := make(map[types.Domain]map[types.Domain]types.RoutePolicy)
+	for source, dests := range routes {
+		enabled[source] = make([]types.Domain, 0, len(dests))
+		for _, route := range dests {
+			if slices.Contains(enabled[source], route.Dest) {
+				return nil, nil, fmt.Errorf("route %d -> %d is listed more than once in enabled-routes", source, route.Dest)
+			}
+			enabled[source] = append(enabled[source], route.Dest)
+
+			if route.RoutePolicy == (types.RoutePolicy{}) {
+				continue
+			}
+			if policies[source] == nil {
+				policies[source] = make(map[types.Domain]types.RoutePolicy)
+			}
+			policies[source][route.Dest] = route.RoutePolicy
+		}
+	}
+	return enabled, policies, nil
+}
@@ -1,6 +1,8 @@
 package cmd_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +10,7 @@ import (
 	"github.com/strangelove-ventures/noble-cctp-relayer/cmd"
 	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
 	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 func TestConfig(t *testing.T) {
@@ -38,3 +41,34 @@ func TestBlockQueueChannelSize(t *testing.T) {
 
 	require.Equal(t, expected, n.BlockQueueChannelSize)
 }
+
+func TestEnabledRoutePolicies(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+enabled-routes:
+  0:
+    - domain: 4
+      min-amount: 50000000
+      volume-cap: 1000000000000
+  3: [4]
+  4:
+    - 0
+    - domain: 3
+      max-tx-per-minute: 10
+`), 0o600))
+
+	cfg, err := cmd.ParseConfig(file)
+	require.NoError(t, err)
+
+	require.Equal(t, map[types.Domain][]types.Domain{0: {4}, 3: {4}, 4: {0, 3}}, cfg.EnabledRoutes)
+	require.Equal(t, types.RoutePolicy{MinAmount: 50000000, VolumeCap: 1000000000000}, cfg.RoutePolicy(0, 4))
+	require.Equal(t, types.RoutePolicy{MaxTxPerMinute: 10}, cfg.RoutePolicy(4, 3))
+	require.Equal(t, types.RoutePolicy{}, cfg.RoutePolicy(3, 4))
+
+	require.NoError(t, os.WriteFile(file, []byte(`
+enabled-routes:
+  0: [4, {domain: 4, max-amount: 10}]
+`), 0o600))
+	_, err = cmd.ParseConfig(file)
+	require.ErrorContains(t, err, "more than once")
+}
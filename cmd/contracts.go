@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// contractCheckInterval is how often the CCTP contracts of each chain are queried
+const contractCheckInterval = time.Minute
+
+// monitorContracts halts broadcasting to the chain while its CCTP contracts are paused, report another domain or an
+// unexpected version, and resumes it once they recover. Each message version is halted with its own transmitter. It
+// runs until ctx is done.
+func monitorContracts(ctx context.Context, logger log.Logger, chain types.Chain, m *relayer.PromMetrics) error {
+	var maxMessageBodySizes map[string]uint64
+	for {
+		maxMessageBodySizes = checkContracts(ctx, logger, chain, m, maxMessageBodySizes)
+
+		timer := time.NewTimer(contractCheckInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+// checkContracts queries the chain's contracts once and halts or resumes broadcasting to it. Changes to the max
+// message body size since the previous check are logged, it returns the current sizes by contract.
+func checkContracts(
+	ctx context.Context,
+	logger log.Logger,
+	chain types.Chain,
+	m *relayer.PromMetrics,
+	maxMessageBodySizes map[string]uint64,
+) map[string]uint64 {
+	states, err := chain.ContractStates(ctx)
+	if err != nil {
+		// unreachable rpcs are reported by the health endpoints, they do not halt broadcasting
+		logger.Error("Unable to query CCTP contract state", "err", err)
+		return maxMessageBodySizes
+	}
+
+	// the contract of each message version only halts the messages it mints
+	reasons := make(map[uint32][]string)
+	sizes := make(map[string]uint64)
+	for _, state := range states {
+		if reason := state.HaltReason(chain.Domain()); reason != "" {
+			reasons[state.ExpectedVersion] = append(reasons[state.ExpectedVersion], reason)
+		}
+
+		sizes[state.Contract] = state.MaxMessageBodySize
+		if previous, ok := maxMessageBodySizes[state.Contract]; ok && previous != state.MaxMessageBodySize {
+			logger.Error(fmt.Sprintf("%s max message body size changed from %d to %d, the contract may have been upgraded",
+				state.Contract, previous, state.MaxMessageBodySize))
+		}
+	}
+
+	if m != nil {
+		m.SetContractHalted(chain.Name(), fmt.Sprint(chain.Domain()), len(reasons) > 0)
+	}
+
+	for _, version := range []uint32{types.MessageVersionV1, types.MessageVersionV2} {
+		if len(reasons[version]) > 0 {
+			reason := strings.Join(reasons[version], ", ")
+			if pauses.halt(chain.Domain(), version, reason) {
+				logger.Error("Halting broadcasts, attested messages are held until the CCTP contracts recover",
+					"message_version", version, "reason", reason)
+			}
+			continue
+		}
+
+		if halted, requeued := pauses.unhalt(chain.Domain(), version); halted {
+			logger.Info("CCTP contracts recovered, resuming broadcasts", "message_version", version, "requeued_txs", requeued)
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// contractsChain reports fixed contract states
+type contractsChain struct {
+	fakeChain
+
+	states []types.ContractState
+	err    error
+}
+
+func (c *contractsChain) ContractStates(context.Context) ([]types.ContractState, error) {
+	return c.states, c.err
+}
+
+func TestCheckContracts(t *testing.T) {
+	logger := log.NewNopLogger()
+	chain := &contractsChain{
+		fakeChain: fakeChain{name: "arbitrum", domain: 3},
+		states: []types.ContractState{{
+			Contract:           "MessageTransmitter",
+			LocalDomain:        3,
+			MaxMessageBodySize: 8192,
+		}},
+	}
+
+	sizes := checkContracts(context.Background(), logger, chain, nil, nil)
+	require.Equal(t, uint64(8192), sizes["MessageTransmitter"])
+	require.Empty(t, pauses.halted(3))
+
+	// a paused contract halts broadcasts, attested messages are held
+	chain.states[0].Paused = true
+	sizes = checkContracts(context.Background(), logger, chain, nil, sizes)
+	require.Equal(t, "MessageTransmitter is paused", pauses.halted(3))
+
+	processingQueue := make(chan *types.TxState, 1)
+	msg := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 3}, types.Attested)
+	tx := &types.TxState{TxHash: "0xhalted", Msgs: []*types.MessageState{msg}}
+	require.Empty(t, holdPausedMsgs(context.Background(), logger, tx, map[types.Domain][]*types.MessageState{3: {msg}}, processingQueue))
+
+	// query errors do not change the halt
+	chain.err = errors.New("rpc unreachable")
+	sizes = checkContracts(context.Background(), logger, chain, nil, sizes)
+	require.NotEmpty(t, pauses.halted(3))
+	chain.err = nil
+
+	// neither do admin resumes
+	pauses.resume(nil, 3)
+	require.False(t, pauses.release(msg))
+
+	// an upgrade to an unexpected version changes the reason
+	chain.states[0].Paused = false
+	chain.states[0].Version = 1
+	sizes = checkContracts(context.Background(), logger, chain, nil, sizes)
+	require.Equal(t, "MessageTransmitter reports version 1 instead of 0", pauses.halted(3))
+
+	// once the contract recovers, held txs are requeued
+	chain.states[0].Version = 0
+	checkContracts(context.Background(), logger, chain, nil, sizes)
+	require.Empty(t, pauses.halted(3))
+	require.Equal(t, tx, <-processingQueue)
+	require.True(t, pauses.release(msg))
+}
+
+func TestCheckContractsHaltsByVersion(t *testing.T) {
+	pauses = newPauseRegistry()
+	t.Cleanup(func() { pauses = newPauseRegistry() })
+
+	logger := log.NewNopLogger()
+	chain := &contractsChain{
+		fakeChain: fakeChain{name: "arbitrum", domain: 3},
+		states: []types.ContractState{
+			{Contract: "MessageTransmitter", LocalDomain: 3, ExpectedVersion: types.MessageVersionV1},
+			{Contract: "MessageTransmitterV2", LocalDomain: 3, Version: types.MessageVersionV2, ExpectedVersion: types.MessageVersionV2, Paused: true},
+		},
+	}
+	checkContracts(context.Background(), logger, chain, nil, nil)
+	require.Equal(t, "MessageTransmitterV2 is paused", pauses.halted(3))
+
+	// only the messages minted by the paused transmitter are held
+	processingQueue := make(chan *types.TxState, 1)
+	v1 := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 3, Version: types.MessageVersionV1}, types.Attested)
+	v2 := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 3, Version: types.MessageVersionV2}, types.Attested)
+	tx := &types.TxState{TxHash: "0xversions", Msgs: []*types.MessageState{v1, v2}}
+	broadcastMsgs := holdPausedMsgs(context.Background(), logger, tx, map[types.Domain][]*types.MessageState{3: {v1, v2}}, processingQueue)
+	require.Equal(t, []*types.MessageState{v1}, broadcastMsgs[3])
+
+	status := pauses.status()
+	require.Len(t, status.Halted, 1)
+	require.Equal(t, types.MessageVersionV2, status.Halted[0].Version)
+
+	chain.states[1].Paused = false
+	checkContracts(context.Background(), logger, chain, nil, nil)
+	require.Empty(t, pauses.halted(3))
+	require.Equal(t, tx, <-processingQueue)
+	require.True(t, pauses.release(v2))
+}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// Names of the built-in filters, as used in the filters config and recorded as a message's FilterReason
+const (
+	FilterDisabledRoutes     = "disabled-routes"
+	FilterDestinationCallers = "invalid-destination-callers"
+	FilterMessageVersions    = "unsupported-message-versions"
+	FilterLowTransfers       = "low-transfers"
+	FilterFastTransfers      = "fast-transfers"
+	FilterUnpairedForwards   = "unpaired-forwards"
+)
+
+// FilterContext is what filters can decide on besides the message itself
+type FilterContext struct {
+	Config *types.Config
+	// Chains are the registered chains by domain
+	Chains map[types.Domain]types.Chain
+	// Tx is the tx the message was emitted in
+	Tx     *types.TxState
+	Logger log.Logger
+}
+
+// Filter decides whether a message is relayed. Filters run in the order of the filters config, the first filter
+// that matches marks the message as filtered and is recorded as its FilterReason.
+type Filter interface {
+	// Name identifies the filter in the filters config, the API and the filtered messages metric
+	Name() string
+	// Filter returns true if msg should not be relayed, and optionally a detail recorded as the msg's FilterDetail
+	Filter(fc *FilterContext, msg *types.MessageState) (filtered bool, detail string)
+}
+
+// FilterFunc adapts a function to the Filter interface
+type FilterFunc struct {
+	name string
+	fn   func(fc *FilterContext, msg *types.MessageState) (bool, string)
+}
+
+// NewFilterFunc returns a Filter named name that calls fn
+func NewFilterFunc(name string, fn func(fc *FilterContext, msg *types.MessageState) (bool, string)) FilterFunc {
+	return FilterFunc{name: name, fn: fn}
+}
+
+func (f FilterFunc) Name() string { return f.name }
+
+func (f FilterFunc) Filter(fc *FilterContext, msg *types.MessageState) (bool, string) {
+	return f.fn(fc, msg)
+}
+
+// filters are the filters that can be enabled in the filters config, by name
+var filters = map[string]Filter{}
+
+// defaultFilters is the filter chain used when the filters config is empty
+var defaultFilters = []string{
+	FilterDisabledRoutes,
+	FilterDeniedAddresses,
+	FilterUnlistedAddresses,
+	FilterDestinationCallers,
+	FilterMessageVersions,
+	FilterLowTransfers,
+	FilterFastTransfers,
+	FilterUnpairedForwards,
+}
+
+// requiredFilters must be part of every filter chain, messages they filter can not or must not be broadcast
+var requiredFilters = []string{
+	FilterDisabledRoutes,
+	FilterDestinationCallers,
+	FilterMessageVersions,
+	FilterUnpairedForwards,
+}
+
+func init() {
+	RegisterFilter(NewFilterFunc(FilterDisabledRoutes, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		return FilterDisabledCCTPRoutes(fc.Config, fc.Logger, msg), ""
+	}))
+	RegisterFilter(NewFilterFunc(FilterDestinationCallers, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		return filterInvalidDestinationCallers(fc.Chains, fc.Logger, msg), ""
+	}))
+	RegisterFilter(NewFilterFunc(FilterMessageVersions, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		return filterUnsupportedMessageVersions(fc.Config, fc.Logger, msg), ""
+	}))
+	RegisterFilter(NewFilterFunc(FilterLowTransfers, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		return filterLowTransfers(fc.Config, fc.Logger, msg), ""
+	}))
+	RegisterFilter(NewFilterFunc(FilterFastTransfers, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		return filterFastTransfers(fc.Config, fc.Logger, msg), ""
+	}))
+	RegisterFilter(NewFilterFunc(FilterUnpairedForwards, func(fc *FilterContext, msg *types.MessageState) (bool, string) {
+		return filterUnpairedForwards(fc.Tx, fc.Logger, msg), ""
+	}))
+}
+
+// RegisterFilter makes a filter available to the filters config. It panics if a filter with the same name is
+// already registered, filters are expected to be registered from init functions.
+func RegisterFilter(f Filter) {
+	if _, ok := filters[f.Name()]; ok {
+		panic(fmt.Sprintf("filter %s is already registered", f.Name()))
+	}
+	filters[f.Name()] = f
+}
+
+// filterNames returns the names of the configured filter chain, in order
+func filterNames(cfg *types.Config) []string {
+	if len(cfg.Filters) == 0 {
+		return defaultFilters
+	}
+	return cfg.Filters
+}
+
+// validateFilters checks that the filters config only names registered filters, once each, and includes the
+// required filters
+func validateFilters(cfg *types.Config) error {
+	names := filterNames(cfg)
+	for i, name := range names {
+		if _, ok := filters[name]; !ok {
+			return fmt.Errorf("unknown filter %q in filters", name)
+		}
+		if slices.Contains(names[:i], name) {
+			return fmt.Errorf("filter %q is listed more than once in filters", name)
+		}
+	}
+	for _, name := range requiredFilters {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("filters must include %s", name)
+		}
+	}
+	return nil
+}
+
+// filterChain returns the configured filters in order, unknown names are skipped (see validateFilters)
+func filterChain(cfg *types.Config) []Filter {
+	var chain []Filter
+	for _, name := range filterNames(cfg) {
+		if f, ok := filters[name]; ok {
+			chain = append(chain, f)
+		}
+	}
+	return chain
+}
+
+// runFilters returns the name of the first filter of the chain that filters msg and its detail, or an empty reason
+func runFilters(fc *FilterContext, chain []Filter, msg *types.MessageState) (reason, detail string) {
+	for _, f := range chain {
+		if filtered, detail := f.Filter(fc, msg); filtered {
+			return f.Name(), detail
+		}
+	}
+	return "", ""
+}
+
+// runFilter runs the filter named name on msg, if it is part of the chain
+func runFilter(fc *FilterContext, chain []Filter, name string, msg *types.MessageState) (filtered bool, detail string) {
+	for _, f := range chain {
+		if f.Name() == name {
+			return f.Filter(fc, msg)
+		}
+	}
+	return false, ""
+}
+
+// markFiltered marks msg as filtered for reason and detail. Messages keep the reason they were first filtered for and are
+// only counted once, messages that can not move to filtered are left alone. The caller must hold State.Mu.
+func markFiltered(msg *types.MessageState, reason, detail string, m *relayer.PromMetrics) {
+	// complete and failed messages are done with, they are not filtered after the fact
+	if msg.Status() == types.Filtered || msg.SetStatus(types.Filtered) != nil {
+		return
+	}
+	msg.FilterReason = reason
+	msg.FilterDetail = detail
+	if m != nil {
+		m.IncFilteredMessages(reason, fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+	}
+}
+
+// filterDisabledCCTPRoutes returns true if we haven't enabled relaying from a source domain to a destination domain
+func FilterDisabledCCTPRoutes(cfg *types.Config, logger log.Logger, msg *types.MessageState) bool {
+	val, ok := cfg.EnabledRoutes[msg.SourceDomain]
+	if !ok {
+		logger.Info(fmt.Sprintf("Filtered tx %s because relaying from %d to %d is not enabled",
+			msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
+		return !ok
+	}
+	for _, dd := range val {
+		if dd == msg.DestDomain {
+			return false
+		}
+	}
+	logger.Info(fmt.Sprintf("Filtered tx %s because relaying from %d to %d is not enabled",
+		msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
+	return true
+}
+
+// filterInvalidDestinationCallers returns true if the minter is not the destination caller for the specified domain
+func filterInvalidDestinationCallers(registeredDomains map[types.Domain]types.Chain, logger log.Logger, msg *types.MessageState) bool {
+	chain, ok := registeredDomains[msg.DestDomain]
+	if !ok {
+		logger.Error("No chain registered for domain", "domain", msg.DestDomain)
+		return true
+	}
+	validCaller, address := chain.IsDestinationCaller(msg.DestinationCaller)
+
+	if validCaller {
+		// we do not want to filter this message if valid caller
+		return false
+	}
+
+	logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d due to destination caller: %s)",
+		msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, address))
+	return true
+}
+
+// filterLowTransfers returns true if the amount being transferred to the destination chain is lower than the min-mint-amount configured,
+// or than the min-amount of the route's policy if it sets one
+func filterLowTransfers(cfg *types.Config, logger log.Logger, msg *types.MessageState) bool {
+	// forwards carry no amount, they follow their mint (see filterUnpairedForwards)
+	if msg.Type == types.Forward {
+		return false
+	}
+
+	bm, err := new(types.BurnMessage).Parse(msg.MsgBody)
+	if err != nil {
+		logger.Info("This is not a burn message", "err", err)
+		return true
+	}
+
+	minBurnAmount, ok := minMintAmount(cfg, msg.DestDomain)
+	if !ok {
+		logger.Info(fmt.Sprintf("No chain with domain %d found in config, filtering transaction", msg.DestDomain))
+		return true
+	}
+	if policy := cfg.RoutePolicy(msg.SourceDomain, msg.DestDomain); policy.MinAmount > 0 {
+		minBurnAmount = policy.MinAmount
+	}
+
+	if bm.Amount.Cmp(new(big.Int).SetUint64(minBurnAmount)) < 0 {
+		logger.Info(
+			"Filtered tx because the transfer amount is less than the minimum allowed amount",
+			"dest domain", msg.DestDomain,
+			"source_domain", msg.SourceDomain,
+			"source_tx", msg.SourceTxHash,
+			"amount", bm.Amount,
+			"min_amount", minBurnAmount,
+		)
+		return true
+	}
+
+	return false
+}
+
+// minMintAmount returns the min-mint-amount of the configured chain with the domain
+func minMintAmount(cfg *types.Config, domain types.Domain) (uint64, bool) {
+	for _, chain := range cfg.Chains {
+		switch c := chain.(type) {
+		case *noble.ChainConfig:
+			if c.CCTPDomain() == domain {
+				return c.MinMintAmount, true
+			}
+		case *ethereum.ChainConfig:
+			if c.Domain == domain {
+				return c.MinMintAmount, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// filterUnsupportedMessageVersions returns true if the message is a CCTP V2 message and the destination chain
+// has no V2 MessageTransmitter configured. Noble does not support CCTP V2.
+func filterUnsupportedMessageVersions(cfg *types.Config, logger log.Logger, msg *types.MessageState) bool {
+	if !msg.IsV2() {
+		return false
+	}
+
+	for _, chain := range cfg.Chains {
+		c, ok := chain.(*ethereum.ChainConfig)
+		if !ok {
+			continue
+		}
+		if c.Domain == msg.DestDomain && c.MessageTransmitterV2 != "" {
+			return false
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d because the destination does not support CCTP V2",
+		msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
+	return true
+}
+
+// filterFastTransfers returns true if the message is a CCTP V2 fast transfer that does not satisfy the
+// fast-transfer config. Before attestation the requested threshold and max fee are checked, after attestation
+// the executed threshold and fee are checked.
+func filterFastTransfers(cfg *types.Config, logger log.Logger, msg *types.MessageState) bool {
+	if !msg.IsFastTransfer() {
+		return false
+	}
+
+	settings := cfg.FastTransfer
+	if !settings.Enabled {
+		logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d because fast transfers are not enabled",
+			msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
+		return true
+	}
+
+	threshold, fee := msg.MinFinalityThreshold, msg.MaxFee
+	if msg.FinalityThresholdExecuted != 0 {
+		threshold, fee = msg.FinalityThresholdExecuted, msg.FeeExecuted
+	}
+
+	if threshold < settings.MinFinalityThreshold {
+		logger.Info(
+			"Filtered fast transfer because the finality threshold is below the minimum allowed threshold",
+			"source_domain", msg.SourceDomain,
+			"dest_domain", msg.DestDomain,
+			"source_tx", msg.SourceTxHash,
+			"threshold", threshold,
+			"min_threshold", settings.MinFinalityThreshold,
+		)
+		return true
+	}
+
+	if fee == nil || fee.Cmp(new(big.Int).SetUint64(settings.MinFee)) < 0 {
+		logger.Info(
+			"Filtered fast transfer because the fee is less than the minimum allowed fee",
+			"source_domain", msg.SourceDomain,
+			"dest_domain", msg.DestDomain,
+			"source_tx", msg.SourceTxHash,
+			"fee", fee,
+			"min_fee", settings.MinFee,
+		)
+		return true
+	}
+
+	return false
+}
+
+// filterUnpairedForwards returns true if the message is a forward whose mint is missing or was filtered.
+// The mint is emitted before the forward, so it has already been through the filters.
+func filterUnpairedForwards(tx *types.TxState, logger log.Logger, msg *types.MessageState) bool {
+	if msg.Type != types.Forward {
+		return false
+	}
+
+	mint := tx.Pair(msg)
+	if mint != nil && mint.Status() != types.Filtered {
+		return false
+	}
+
+	logger.Info(fmt.Sprintf("Filtered forward in tx %s from %d to %d because its mint was filtered",
+		msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
+	return true
+}
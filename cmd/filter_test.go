@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// burnMsgBody returns a V1 burn message body transferring amount
+func burnMsgBody(amount int64) []byte {
+	bz := make([]byte, 132)
+	big.NewInt(amount).FillBytes(bz[68:100])
+	return bz
+}
+
+// withStatus moves msg to status through the statuses a relayed message goes through
+func withStatus(msg *types.MessageState, status string) *types.MessageState {
+	path := map[string][]string{
+		types.Created:  {types.Created},
+		types.Attested: {types.Created, types.Attested},
+		types.Complete: {types.Created, types.Attested, types.Complete},
+	}[status]
+	for _, s := range path {
+		if err := msg.SetStatus(s); err != nil {
+			panic(err)
+		}
+	}
+	return msg
+}
+
+// anyCallerChain accepts every destination caller
+type anyCallerChain struct {
+	fakeChain
+}
+
+func (c *anyCallerChain) IsDestinationCaller([]byte) (bool, string) { return true, "" }
+
+func TestValidateFilters(t *testing.T) {
+	require.NoError(t, validateFilters(&types.Config{}))
+
+	cfg := &types.Config{Filters: []string{
+		FilterDisabledRoutes, FilterDestinationCallers, FilterMessageVersions, FilterUnpairedForwards, FilterLowTransfers,
+	}}
+	require.NoError(t, validateFilters(cfg))
+
+	cfg.Filters = append(cfg.Filters, "unknown")
+	require.ErrorContains(t, validateFilters(cfg), "unknown filter")
+
+	cfg.Filters = []string{
+		FilterDisabledRoutes, FilterDestinationCallers, FilterMessageVersions, FilterUnpairedForwards, FilterLowTransfers, FilterLowTransfers,
+	}
+	require.ErrorContains(t, validateFilters(cfg), "more than once")
+
+	// relaying disabled routes is never allowed
+	cfg.Filters = []string{FilterDestinationCallers, FilterMessageVersions, FilterUnpairedForwards}
+	require.ErrorContains(t, validateFilters(cfg), FilterDisabledRoutes)
+}
+
+func TestRunFilters(t *testing.T) {
+	cfg := &types.Config{
+		EnabledRoutes: map[types.Domain][]types.Domain{0: {4}},
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{MinMintAmount: 100},
+			"ethereum": &ethereum.ChainConfig{Domain: 0},
+		},
+	}
+	fc := &FilterContext{
+		Config: cfg,
+		Chains: map[types.Domain]types.Chain{4: &anyCallerChain{fakeChain{name: "noble", domain: 4}}},
+		Tx:     &types.TxState{},
+		Logger: log.NewNopLogger(),
+	}
+	filters := filterChain(cfg)
+	reason := func(filters []Filter, msg *types.MessageState) string {
+		reason, _ := runFilters(fc, filters, msg)
+		return reason
+	}
+
+	// the first filter that matches is the reason
+	msg := &types.MessageState{SourceDomain: 4, DestDomain: 0, MsgBody: burnMsgBody(10)}
+	require.Equal(t, FilterDisabledRoutes, reason(filters, msg))
+
+	msg = &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(10)}
+	require.Equal(t, FilterLowTransfers, reason(filters, msg))
+
+	msg = &types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(100)}
+	require.Empty(t, reason(filters, msg))
+
+	// filters left out of the config do not run
+	cfg.Filters = []string{FilterDisabledRoutes, FilterDestinationCallers, FilterMessageVersions, FilterUnpairedForwards}
+	msg = withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(10)}, types.Created)
+	require.Empty(t, reason(filterChain(cfg), msg))
+
+	// the first reason is kept
+	markFiltered(msg, FilterLowTransfers, "", nil)
+	markFiltered(msg, FilterFastTransfers, "", nil)
+	require.Equal(t, types.Filtered, msg.Status())
+	require.Equal(t, FilterLowTransfers, msg.FilterReason)
+
+	// messages that are done with are not filtered after the fact
+	msg = withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(10)}, types.Complete)
+	markFiltered(msg, FilterLowTransfers, "", nil)
+	require.Equal(t, types.Complete, msg.Status())
+	require.Empty(t, msg.FilterReason)
+}
+
+func TestFilterLowTransfersNobleDomain(t *testing.T) {
+	logger := log.NewNopLogger()
+	cfg := &types.Config{Chains: map[string]types.ChainConfig{
+		"noble":    &noble.ChainConfig{Domain: 9, MinMintAmount: 100},
+		"ethereum": &ethereum.ChainConfig{Domain: 0, MinMintAmount: 1},
+	}}
+
+	require.True(t, filterLowTransfers(cfg, logger, &types.MessageState{DestDomain: 9, MsgBody: burnMsgBody(10)}))
+	require.False(t, filterLowTransfers(cfg, logger, &types.MessageState{DestDomain: 0, MsgBody: burnMsgBody(10)}))
+	// the route's min-amount replaces the destination's min-mint-amount
+	cfg.RoutePolicies = map[types.Domain]map[types.Domain]types.RoutePolicy{3: {9: {MinAmount: 5}}}
+	require.False(t, filterLowTransfers(cfg, logger, &types.MessageState{SourceDomain: 3, DestDomain: 9, MsgBody: burnMsgBody(10)}))
+	require.True(t, filterLowTransfers(cfg, logger, &types.MessageState{SourceDomain: 3, DestDomain: 0, MsgBody: burnMsgBody(0)}))
+	// no chain is configured with domain 4
+	require.True(t, filterLowTransfers(cfg, logger, &types.MessageState{DestDomain: 4, MsgBody: burnMsgBody(1000)}))
+}
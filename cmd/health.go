@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// irisCheckInterval is how often Circle's attestation api is checked for reachability
+	irisCheckInterval = 15 * time.Second
+	// defaultStaleHeightAfter is how long a chain's latest height may stay the same before it is degraded
+	defaultStaleHeightAfter = 2 * time.Minute
+)
+
+// relayerHealth tracks the health of every chain and of Circle's attestation api for the /healthz and /readyz
+// endpoints. Chains are registered once their broadcaster is initialized.
+type relayerHealth struct {
+	cfg        *types.Config
+	supervisor *relayer.Supervisor
+
+	mu      sync.Mutex
+	chains  map[string]*chainHealth
+	started bool
+	irisErr error
+}
+
+type chainHealth struct {
+	chain         types.Chain
+	height        uint64
+	heightUpdated time.Time
+}
+
+// chainHealthReport is the health of a single chain.
+// A chain is live unless its routines are failing or its height stopped advancing, restarting the relayer may help.
+// A chain is ready once it is live, initialized, connected and funded.
+type chainHealthReport struct {
+	Name     string       `json:"name"`
+	Domain   types.Domain `json:"domain"`
+	Critical bool         `json:"critical"`
+	Live     bool         `json:"live"`
+	Ready    bool         `json:"ready"`
+	Problems []string     `json:"problems,omitempty"`
+
+	BroadcasterInitialized bool      `json:"broadcaster_initialized"`
+	LatestBlock            uint64    `json:"latest_block"`
+	HeightUpdated          time.Time `json:"height_updated"`
+	types.ChainStatus
+
+	Routines []relayer.RoutineHealth `json:"routines,omitempty"`
+}
+
+type healthReport struct {
+	Live           bool                `json:"live"`
+	Ready          bool                `json:"ready"`
+	Started        bool                `json:"started"`
+	IrisReachable  bool                `json:"iris_reachable"`
+	IrisError      string              `json:"iris_error,omitempty"`
+	Chains         []chainHealthReport `json:"chains"`
+	CriticalChains []string            `json:"critical_chains"`
+}
+
+func newRelayerHealth(cfg *types.Config, supervisor *relayer.Supervisor) *relayerHealth {
+	return &relayerHealth{
+		cfg:        cfg,
+		supervisor: supervisor,
+		chains:     make(map[string]*chainHealth),
+		irisErr:    errors.New("not checked yet"),
+	}
+}
+
+// register adds a chain whose clients and broadcaster are initialized
+func (h *relayerHealth) register(c types.Chain) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.chains[c.Name()] = &chainHealth{chain: c, height: c.LatestBlock(), heightUpdated: time.Now()}
+}
+
+// setStarted marks that every chain is registered and the processor is running
+func (h *relayerHealth) setStarted() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.started = true
+}
+
+// registeredChains returns the registered chains
+func (h *relayerHealth) registeredChains() []types.Chain {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	chains := make([]types.Chain, 0, len(h.chains))
+	for _, c := range h.chains {
+		chains = append(chains, c.chain)
+	}
+	return chains
+}
+
+// monitorIris checks whether Circle's attestation apis are reachable every irisCheckInterval until ctx is done
+func (h *relayerHealth) monitorIris(ctx context.Context) {
+	for {
+		var err error
+		for _, url := range []string{h.cfg.Circle.AttestationBaseURL, h.cfg.Circle.AttestationV2BaseURL} {
+			if url == "" {
+				continue
+			}
+			if checkErr := circle.CheckReachable(ctx, url); checkErr != nil {
+				err = errors.Join(err, checkErr)
+			}
+		}
+
+		h.mu.Lock()
+		h.irisErr = err
+		h.mu.Unlock()
+
+		timer := time.NewTimer(irisCheckInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// isCritical returns whether the chain's health decides the relayer's health. All chains are critical unless
+// critical chains are configured.
+func (h *relayerHealth) isCritical(name string) bool {
+	critical := h.cfg.Health.CriticalChains
+	return len(critical) == 0 || slices.Contains(critical, name)
+}
+
+func (h *relayerHealth) staleHeightAfter() time.Duration {
+	if h.cfg.Health.StaleHeightAfter > 0 {
+		return time.Duration(h.cfg.Health.StaleHeightAfter) * time.Second
+	}
+	return defaultStaleHeightAfter
+}
+
+func (h *relayerHealth) report() healthReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r := healthReport{
+		Started:       h.started,
+		IrisReachable: h.irisErr == nil,
+		Live:          true,
+		Ready:         h.started && h.irisErr == nil,
+	}
+	if h.irisErr != nil {
+		r.IrisError = h.irisErr.Error()
+	}
+
+	for name := range h.cfg.Chains {
+		c := h.chainReport(name)
+		if c.Critical {
+			r.CriticalChains = append(r.CriticalChains, name)
+			r.Live = r.Live && c.Live
+			r.Ready = r.Ready && c.Ready
+		}
+		r.Chains = append(r.Chains, c)
+	}
+	sort.Strings(r.CriticalChains)
+	sort.Slice(r.Chains, func(i, j int) bool { return r.Chains[i].Name < r.Chains[j].Name })
+
+	return r
+}
+
+// chainReport returns the health of a configured chain. h.mu must be held.
+func (h *relayerHealth) chainReport(name string) chainHealthReport {
+	r := chainHealthReport{Name: name, Critical: h.isCritical(name), Live: true}
+
+	c, ok := h.chains[name]
+	if !ok {
+		// still starting up
+		r.Problems = append(r.Problems, "broadcaster not initialized")
+		return r
+	}
+
+	// the height is sampled on every report, it is stale if it has not changed for a while
+	if latest := c.chain.LatestBlock(); latest != c.height {
+		c.height = latest
+		c.heightUpdated = time.Now()
+	}
+
+	r.Domain = c.chain.Domain()
+	r.BroadcasterInitialized = true
+	r.LatestBlock = c.height
+	r.HeightUpdated = c.heightUpdated
+	r.ChainStatus = c.chain.Status()
+	for _, routine := range h.supervisor.Health() {
+		if routine.Chain == name {
+			r.Routines = append(r.Routines, routine)
+		}
+	}
+
+	var liveness, readiness []string
+	for _, routine := range r.Routines {
+		if routine.State == relayer.Restarting {
+			liveness = append(liveness, fmt.Sprintf("%s routine failed: %s", routine.Routine, routine.LastError))
+		}
+	}
+	if since := time.Since(c.heightUpdated); since > h.staleHeightAfter() {
+		liveness = append(liveness, fmt.Sprintf("height has not advanced for %v", since.Round(time.Second)))
+	}
+	if !r.RPCReachable {
+		readiness = append(readiness, "no rpc endpoint reachable")
+	}
+	if !r.ListenerConnected {
+		readiness = append(readiness, "listener not connected")
+	}
+	if r.WalletBalance != nil && *r.WalletBalance < r.MinWalletBalance {
+		readiness = append(readiness, fmt.Sprintf("wallet balance %v below %v", *r.WalletBalance, r.MinWalletBalance))
+	}
+	if reason := pauses.halted(r.Domain); reason != "" {
+		readiness = append(readiness, "broadcasting halted: "+reason)
+	}
+
+	r.Live = len(liveness) == 0
+	r.Ready = r.Live && len(readiness) == 0
+	r.Problems = append(liveness, readiness...)
+	return r
+}
+
+// getHealthz is the liveness probe, it fails when a critical chain's routines keep failing or its height is stuck
+func getHealthz(c *gin.Context, h *relayerHealth) {
+	r := h.report()
+	status := http.StatusOK
+	if !r.Live {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, r)
+}
+
+// getReadyz is the readiness probe, it fails until every chain is initialized and while Circle's attestation api or
+// a critical chain is degraded
+func getReadyz(c *gin.Context, h *relayerHealth) {
+	r := h.report()
+	status := http.StatusOK
+	if !r.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, r)
+}
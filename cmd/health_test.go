@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// fakeChain reports a fixed height and status, other Chain methods are not used by the health endpoints
+type fakeChain struct {
+	types.Chain
+
+	name   string
+	domain types.Domain
+	height uint64
+	status types.ChainStatus
+}
+
+func (c *fakeChain) Name() string              { return c.name }
+func (c *fakeChain) Domain() types.Domain      { return c.domain }
+func (c *fakeChain) LatestBlock() uint64       { return c.height }
+func (c *fakeChain) Status() types.ChainStatus { return c.status }
+
+func readyzStatus(h *relayerHealth) int {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	getReadyz(c, h)
+	return w.Code
+}
+
+func healthzStatus(h *relayerHealth) int {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	getHealthz(c, h)
+	return w.Code
+}
+
+func TestHealth(t *testing.T) {
+	cfg := &types.Config{
+		Chains: map[string]types.ChainConfig{"noble": nil, "ethereum": nil},
+		Health: types.HealthSettings{CriticalChains: []string{"noble"}},
+	}
+	h := newRelayerHealth(cfg, relayer.NewSupervisor(log.NewNopLogger(), nil))
+
+	// not ready while starting up
+	require.Equal(t, http.StatusOK, healthzStatus(h))
+	require.Equal(t, http.StatusServiceUnavailable, readyzStatus(h))
+
+	balance := 0.1
+	nobleChain := &fakeChain{name: "noble", domain: 4, height: 100, status: types.ChainStatus{RPCReachable: true, ListenerConnected: true}}
+	ethChain := &fakeChain{name: "ethereum", domain: 0, height: 100, status: types.ChainStatus{
+		RPCReachable:      true,
+		ListenerConnected: true,
+		WalletBalance:     &balance,
+		MinWalletBalance:  1,
+	}}
+	h.register(nobleChain)
+	h.register(ethChain)
+	h.setStarted()
+	h.irisErr = nil
+
+	// a degraded chain that is not critical does not fail the probes
+	require.Equal(t, http.StatusOK, readyzStatus(h))
+	r := h.report()
+	require.Equal(t, []string{"noble"}, r.CriticalChains)
+	require.False(t, r.Chains[0].Ready)
+	require.Contains(t, r.Chains[0].Problems, "wallet balance 0.1 below 1")
+
+	// a disconnected critical chain is not ready, but still live
+	nobleChain.status.ListenerConnected = false
+	require.Equal(t, http.StatusOK, healthzStatus(h))
+	require.Equal(t, http.StatusServiceUnavailable, readyzStatus(h))
+	nobleChain.status.ListenerConnected = true
+
+	// a critical chain whose height stopped advancing is not live
+	h.chains["noble"].heightUpdated = time.Now().Add(-2 * defaultStaleHeightAfter)
+	require.Equal(t, http.StatusServiceUnavailable, healthzStatus(h))
+
+	// until its height advances again
+	nobleChain.height++
+	require.Equal(t, http.StatusOK, healthzStatus(h))
+	require.Equal(t, http.StatusOK, readyzStatus(h))
+}
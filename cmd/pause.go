@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// pauses is the pause state changed through the admin API, and by the contract monitor when a destination's CCTP
+// contracts can not be minted on. Attested messages for a paused destination or route are held instead of broadcast,
+// and go back to the processing queue once they are resumed.
+var pauses = newPauseRegistry()
+
+// route is a source -> destination domain pair
+type route struct {
+	Source types.Domain
+	Dest   types.Domain
+}
+
+// Pause describes why and since when a destination domain or route is paused
+type Pause struct {
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since"`
+}
+
+// DestinationPause is a paused destination domain
+type DestinationPause struct {
+	Dest types.Domain `json:"dest"`
+	Pause
+}
+
+// HaltedTransmitter is a destination domain's transmitter of one message version the contract monitor halted
+type HaltedTransmitter struct {
+	Dest types.Domain `json:"dest"`
+	// Version is the message version of the halted messages, 0 for CCTP V1 and 1 for V2
+	Version uint32 `json:"version"`
+	Pause
+}
+
+// RoutePause is a paused source -> destination route
+type RoutePause struct {
+	Source types.Domain `json:"source"`
+	Dest   types.Domain `json:"dest"`
+	Pause
+}
+
+// PauseStatus is the pause state returned by the admin API
+type PauseStatus struct {
+	Destinations []DestinationPause `json:"destinations"`
+	Routes       []RoutePause       `json:"routes"`
+	// Halted are the destination transmitters the contract monitor paused, they are resumed once their contracts
+	// recover
+	Halted []HaltedTransmitter `json:"halted"`
+	// HeldMsgs is the number of attested messages waiting for their destination or route to be resumed
+	HeldMsgs int `json:"held_msgs"`
+}
+
+// heldMsg is an attested message waiting to be resumed, with the tx and queue to requeue it to until ctx is done
+type heldMsg struct {
+	ctx   context.Context
+	tx    *types.TxState
+	queue chan *types.TxState
+}
+
+// transmitter is the contract of a destination domain that mints messages of one version
+type transmitter struct {
+	Dest    types.Domain
+	Version uint32
+}
+
+type pauseRegistry struct {
+	mu           sync.Mutex
+	destinations map[types.Domain]Pause
+	routes       map[route]Pause
+	halts        map[transmitter]Pause
+	held         map[*types.MessageState]heldMsg
+}
+
+func newPauseRegistry() *pauseRegistry {
+	return &pauseRegistry{
+		destinations: make(map[types.Domain]Pause),
+		routes:       make(map[route]Pause),
+		halts:        make(map[transmitter]Pause),
+		held:         make(map[*types.MessageState]heldMsg),
+	}
+}
+
+// pause pauses relaying to dest, or only from source to dest if source is set
+func (p *pauseRegistry) pause(source *types.Domain, dest types.Domain, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pause := Pause{Reason: reason, Since: time.Now()}
+	if source == nil {
+		p.destinations[dest] = pause
+		return
+	}
+	p.routes[route{Source: *source, Dest: dest}] = pause
+}
+
+// resume lifts a pause set with the same source and dest. Held messages that are no longer paused are requeued,
+// it returns how many txs were requeued.
+func (p *pauseRegistry) resume(source *types.Domain, dest types.Domain) int {
+	p.mu.Lock()
+	if source == nil {
+		delete(p.destinations, dest)
+	} else {
+		delete(p.routes, route{Source: *source, Dest: dest})
+	}
+
+	return p.requeueUnpausedLocked()
+}
+
+// halt pauses relaying messages of version to dest until unhalt is called, independent of the pauses set through
+// the admin API. Messages of the other version are still relayed through their own transmitter. It returns true if
+// the transmitter was not halted for the same reason yet.
+func (p *pauseRegistry) halt(dest types.Domain, version uint32, reason string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := transmitter{Dest: dest, Version: version}
+	if halt, ok := p.halts[t]; ok && halt.Reason == reason {
+		return false
+	}
+	p.halts[t] = Pause{Reason: reason, Since: time.Now()}
+	return true
+}
+
+// unhalt lifts a halt set with halt. It returns whether the transmitter was halted, and how many txs were requeued.
+func (p *pauseRegistry) unhalt(dest types.Domain, version uint32) (bool, int) {
+	p.mu.Lock()
+	t := transmitter{Dest: dest, Version: version}
+	if _, ok := p.halts[t]; !ok {
+		p.mu.Unlock()
+		return false, 0
+	}
+	delete(p.halts, t)
+	return true, p.requeueUnpausedLocked()
+}
+
+// requeueUnpausedLocked requeues the txs of the held messages that are no longer paused and unlocks mu. It returns
+// how many txs were requeued.
+func (p *pauseRegistry) requeueUnpausedLocked() int {
+	p.pruneLocked()
+	requeue := make(map[*types.TxState]heldMsg)
+	for msg, held := range p.held {
+		if !p.pausedLocked(msg) {
+			requeue[held.tx] = held
+		}
+	}
+	p.mu.Unlock()
+
+	// the queue may be full, do not block the caller. Txs not requeued before shutdown are dumped from State.
+	for _, held := range requeue {
+		go func(held heldMsg) {
+			select {
+			case held.queue <- held.tx:
+			case <-held.ctx.Done():
+			}
+		}(held)
+	}
+	return len(requeue)
+}
+
+// pruneLocked forgets the held messages that are no longer attested, e.g. filtered after a reload while they were
+// held. The caller must hold mu.
+func (p *pauseRegistry) pruneLocked() {
+	for msg := range p.held {
+		if msg.Status() != types.Attested {
+			delete(p.held, msg)
+		}
+	}
+}
+
+// halted returns why relaying to dest is halted for any message version, or an empty string
+func (p *pauseRegistry) halted(dest types.Domain) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var reasons []string
+	for _, version := range []uint32{types.MessageVersionV1, types.MessageVersionV2} {
+		if halt, ok := p.halts[transmitter{Dest: dest, Version: version}]; ok {
+			reasons = append(reasons, halt.Reason)
+		}
+	}
+	return strings.Join(reasons, ", ")
+}
+
+func (p *pauseRegistry) pausedLocked(msg *types.MessageState) bool {
+	if _, ok := p.destinations[msg.DestDomain]; ok {
+		return true
+	}
+	version := types.MessageVersionV1
+	if msg.IsV2() {
+		version = types.MessageVersionV2
+	}
+	if _, ok := p.halts[transmitter{Dest: msg.DestDomain, Version: version}]; ok {
+		return true
+	}
+	_, ok := p.routes[route{Source: msg.SourceDomain, Dest: msg.DestDomain}]
+	return ok
+}
+
+// holdIfPaused returns true if relaying msg is paused. msg is then held until its destination and route are
+// resumed, and tx is requeued to queue unless ctx is done by then.
+func (p *pauseRegistry) holdIfPaused(ctx context.Context, tx *types.TxState, msg *types.MessageState, queue chan *types.TxState) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.pausedLocked(msg) {
+		return false
+	}
+	p.pruneLocked()
+	p.held[msg] = heldMsg{ctx: ctx, tx: tx, queue: queue}
+	return true
+}
+
+// release returns true if msg was held and is no longer paused, it is then forgotten
+func (p *pauseRegistry) release(msg *types.MessageState) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.held[msg]; !ok || p.pausedLocked(msg) {
+		return false
+	}
+	delete(p.held, msg)
+	return true
+}
+
+// status returns the paused destinations and routes
+func (p *pauseRegistry) status() PauseStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pruneLocked()
+	status := PauseStatus{
+		Destinations: []DestinationPause{},
+		Routes:       []RoutePause{},
+		Halted:       []HaltedTransmitter{},
+		HeldMsgs:     len(p.held),
+	}
+	for dest, pause := range p.destinations {
+		status.Destinations = append(status.Destinations, DestinationPause{Dest: dest, Pause: pause})
+	}
+	for r, pause := range p.routes {
+		status.Routes = append(status.Routes, RoutePause{Source: r.Source, Dest: r.Dest, Pause: pause})
+	}
+	for t, halt := range p.halts {
+		status.Halted = append(status.Halted, HaltedTransmitter{Dest: t.Dest, Version: t.Version, Pause: halt})
+	}
+	slices.SortFunc(status.Destinations, func(a, b DestinationPause) int {
+		return int(a.Dest) - int(b.Dest)
+	})
+	slices.SortFunc(status.Halted, func(a, b HaltedTransmitter) int {
+		if a.Dest != b.Dest {
+			return int(a.Dest) - int(b.Dest)
+		}
+		return int(a.Version) - int(b.Version)
+	})
+	slices.SortFunc(status.Routes, func(a, b RoutePause) int {
+		if a.Source != b.Source {
+			return int(a.Source) - int(b.Source)
+		}
+		return int(a.Dest) - int(b.Dest)
+	})
+	return status
+}
@@ -5,18 +5,17 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
-	cctptypes "github.com/circlefin/noble-cctp/x/cctp/types"
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
 
 	"cosmossdk.io/log"
-	"cosmossdk.io/math"
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/circle"
-	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
 	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
 	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
@@ -60,9 +59,6 @@ func Start(a *AppState) *cobra.Command {
 				}
 			}
 
-			// start API on normal relayer only
-			go startAPI(a)
-
 			// messageState processing queue
 			var processingQueue = make(chan *types.TxState, 10000)
 
@@ -80,19 +76,48 @@ func Start(a *AppState) *cobra.Command {
 
 			metrics := relayer.InitPromMetrics(address, port)
 
+			// transfers are not relayed until the address lists are loaded
+			if err := screenedAddresses.load(cfg.AddressLists); err != nil {
+				return err
+			}
+			logAddressLists(logger)
+			go refreshAddressLists(cmd.Context(), a)
+			go refreshPrices(cmd.Context(), a)
+
+			// the supervisor restarts failed chain routines, so one unhealthy chain does not stop the others
+			supervisor := relayer.NewSupervisor(logger, metrics)
+
+			health := newRelayerHealth(cfg, supervisor)
+			go health.monitorIris(cmd.Context())
+
+			// processor workers are started once every chain is registered
+			pool := newProcessorPool(cmd.Context(), func(ctx context.Context) {
+				StartProcessor(ctx, a, registeredDomains, processingQueue, metrics)
+			})
+			// each destination has its own broadcaster workers, started with the processor workers
+			broadcasters := newBroadcasterPools(cmd.Context(), func(ctx context.Context, chain types.Chain) {
+				StartBroadcaster(ctx, a, chain, processingQueue, sequenceMap, metrics)
+			})
+			reloader := newConfigReloader(a, pool, broadcasters, health.registeredChains)
+
+			// start API on normal relayer only
+			go startAPI(a, health, reloader)
+
 			for name, cfg := range cfg.Chains {
 				c, err := cfg.Chain(name)
 				if err != nil {
 					return fmt.Errorf("error creating chain error=%w", err)
 				}
 
-				logger = logger.With("name", c.Name(), "domain", c.Domain())
+				logger := logger.With("name", c.Name(), "domain", c.Domain())
 
 				if err := c.InitializeClients(cmd.Context(), logger); err != nil {
 					return fmt.Errorf("error initializing client error=%w", err)
 				}
 
-				go c.TrackLatestBlockHeight(cmd.Context(), logger, metrics)
+				supervisor.Go(cmd.Context(), c.Name(), "height", func(ctx context.Context) error {
+					return c.TrackLatestBlockHeight(ctx, logger, metrics)
+				})
 
 				// wait until height is available
 				maxRetries := 45
@@ -111,25 +136,49 @@ func Start(a *AppState) *cobra.Command {
 					return fmt.Errorf("error initializing broadcaster error=%w", err)
 				}
 
-				go c.StartListener(cmd.Context(), logger, processingQueue, flushOnly, flushInterval)
+				supervisor.Go(cmd.Context(), c.Name(), "listener", func(ctx context.Context) error {
+					return c.StartListener(ctx, logger, processingQueue, flushOnly, flushInterval, metrics)
+				})
+
+				supervisor.Go(cmd.Context(), c.Name(), "wallet-balance", func(ctx context.Context) error {
+					return c.WalletBalanceMetric(ctx, a.Logger, metrics)
+				})
 
-				go c.WalletBalanceMetric(cmd.Context(), a.Logger, metrics)
+				supervisor.Go(cmd.Context(), c.Name(), "contracts", func(ctx context.Context) error {
+					return monitorContracts(ctx, logger, c, metrics)
+				})
 
 				if _, ok := registeredDomains[c.Domain()]; ok {
 					return fmt.Errorf("duplicate domain found domain=%d name=%s", c.Domain(), c.Name())
 				}
 
 				registeredDomains[c.Domain()] = c
+				health.register(c)
+				broadcasters.add(c)
 			}
 
-			// spin up Processor worker pool
-			for i := 0; i < int(cfg.ProcessorWorkerCount); i++ {
-				go StartProcessor(cmd.Context(), a, registeredDomains, processingQueue, sequenceMap, metrics)
-			}
+			// spin up Processor and broadcaster worker pools
+			pool.resize(int(cfg.ProcessorWorkerCount))
+			broadcasters.resize(cfg)
+			health.setStarted()
+
+			// apply config file changes without restarting the listeners
+			reloader.start()
+			go reloader.watch(cmd.Context())
 
 			// wait for context to be done
 			<-cmd.Context().Done()
 
+			// listeners stop with the context, workers finish their current tx or broadcast
+			var workers sync.WaitGroup
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				pool.workers.Wait()
+				broadcasters.wait()
+			}()
+			drainProcessors(logger, cfg, &workers, processingQueue)
+
 			// close clients & output latest block heights
 			for _, c := range registeredDomains {
 				logger.Info(fmt.Sprintf("%s: latest-block: %d last-flushed-block: %d", c.Name(), c.LatestBlock(), c.LastFlushedBlock()))
@@ -146,58 +195,89 @@ func Start(a *AppState) *cobra.Command {
 	return cmd
 }
 
-// StartProcessor is the main processing pipeline.
+// StartProcessor is the attestation stage of the processing pipeline: it filters the messages of each tx, polls
+// iris for their attestations and queues the attested messages for the broadcaster workers of their destination.
+// It returns once ctx is done and the current tx is processed.
 func StartProcessor(
 	ctx context.Context,
 	a *AppState,
 	registeredDomains map[types.Domain]types.Chain,
 	processingQueue chan *types.TxState,
-	sequenceMap *types.SequenceMap,
 	metrics *relayer.PromMetrics,
 ) {
 	logger := a.Logger
-	cfg := a.Config
 
 	for {
-		dequeuedTx := <-processingQueue
+		var dequeuedTx *types.TxState
+		select {
+		case <-ctx.Done():
+			return
+		case dequeuedTx = <-processingQueue:
+		}
+
+		// reloads apply from the next tx on
+		cfg := a.CurrentConfig()
+
+		// if the tx's logs were removed in a reorg, stop relaying its messages
+		if dequeuedTx.Removed {
+			retractTx(logger, dequeuedTx.TxHash)
+			continue
+		}
 
 		// if this is the first time seeing this message, add it to the State
 		tx, ok := State.Load(dequeuedTx.TxHash)
+		if ok && tx != dequeuedTx {
+			reviveRetractedMsgs(logger, tx)
+		}
 		if !ok {
 			State.Store(dequeuedTx.TxHash, dequeuedTx)
 			tx, _ = State.Load(dequeuedTx.TxHash)
 			for _, msg := range tx.Msgs {
-				msg.Status = types.Created
+				if err := msg.SetStatus(types.Created); err != nil {
+					logger.Error(fmt.Sprintf("Unable to create msg from source domain %d with tx hash %s", msg.SourceDomain, msg.SourceTxHash), "err", err)
+				}
 			}
 		}
 
 		var broadcastMsgs = make(map[types.Domain][]*types.MessageState)
 		var requeue bool
+		filters := filterChain(cfg)
+		fc := &FilterContext{Config: cfg, Chains: registeredDomains, Tx: tx, Logger: logger}
+
 		for _, msg := range tx.Msgs {
 			// if a filter's condition is met, mark as filtered
-			if FilterDisabledCCTPRoutes(cfg, logger, msg) ||
-				filterInvalidDestinationCallers(registeredDomains, logger, msg) ||
-				filterLowTransfers(cfg, logger, msg) {
+			if reason, detail := runFilters(fc, filters, msg); reason != "" {
 				State.Mu.Lock()
-				msg.Status = types.Filtered
+				markFiltered(msg, reason, detail, metrics)
 				State.Mu.Unlock()
 			}
 
+			// messages held while their destination or route was paused, or deferred by their route's policy, are
+			// broadcast once they are resumed or may fit
+			if msg.Status() == types.Attested {
+				resumed, due := pauses.release(msg), routeLimits.release(msg)
+				if resumed || due {
+					broadcastMsgs[msg.DestDomain] = append(broadcastMsgs[msg.DestDomain], msg)
+				}
+			}
+
 			// if the message is burned or pending, check for an attestation
-			if msg.Status == types.Created || msg.Status == types.Pending {
-				response := circle.CheckAttestation(cfg.Circle.AttestationBaseURL, logger, msg.IrisLookupID, msg.SourceTxHash, msg.SourceDomain, msg.DestDomain)
+			if msg.Status() == types.Created || msg.Status() == types.Pending {
+				response, attestedMsg := checkAttestation(cfg, logger, msg)
 
 				switch {
 				case response == nil:
 					logger.Debug("Attestation is still processing for 0x" + msg.IrisLookupID + ".  Retrying...")
 					requeue = true
 					continue
-				case msg.Status == types.Created && response.Status == "pending_confirmations":
+				case msg.Status() == types.Created && response.Status == "pending_confirmations":
 					logger.Debug("Attestation is created but still pending confirmations for 0x" + msg.IrisLookupID + ".  Retrying...")
 					State.Mu.Lock()
-					msg.Status = types.Pending
-					msg.Updated = time.Now()
+					err := msg.SetStatus(types.Pending)
 					State.Mu.Unlock()
+					if err != nil {
+						logger.Error("Unable to mark 0x"+msg.IrisLookupID+" as pending", "err", err)
+					}
 					requeue = true
 					continue
 				case response.Status == "pending_confirmations":
@@ -207,9 +287,26 @@ func StartProcessor(
 				case response.Status == "complete":
 					logger.Debug("Attestation is complete for 0x" + msg.IrisLookupID + ".")
 					State.Mu.Lock()
-					msg.Status = types.Attested
+					if attestedMsg != nil {
+						if err := msg.ApplyAttestedMessage(attestedMsg); err != nil {
+							logger.Error("Unable to apply attested v2 message for 0x"+msg.IrisLookupID, "err", err)
+							State.Mu.Unlock()
+							requeue = true
+							continue
+						}
+						// fee and finality are only final once attested
+						if filtered, detail := runFilter(fc, filters, FilterFastTransfers, msg); filtered {
+							markFiltered(msg, FilterFastTransfers, detail, metrics)
+							State.Mu.Unlock()
+							continue
+						}
+					}
+					if err := msg.SetStatus(types.Attested); err != nil {
+						logger.Error("Unable to mark 0x"+msg.IrisLookupID+" as attested", "err", err)
+						State.Mu.Unlock()
+						continue
+					}
 					msg.Attestation = response.Attestation
-					msg.Updated = time.Now()
 					broadcastMsgs[msg.DestDomain] = append(broadcastMsgs[msg.DestDomain], msg)
 					State.Mu.Unlock()
 				default:
@@ -218,7 +315,15 @@ func StartProcessor(
 			}
 		}
 
-		// if the message is attested to, try to broadcast
+		// forwarded transfers are only broadcast once both the mint and the forward are attested
+		broadcastMsgs, held := batchForwardedTransfers(tx, broadcastMsgs)
+		if held {
+			requeue = true
+		}
+		broadcastMsgs = holdPausedMsgs(ctx, logger, tx, broadcastMsgs, processingQueue)
+		broadcastMsgs = deferLimitedMsgs(ctx, logger, cfg, tx, broadcastMsgs, processingQueue, metrics)
+
+		// attested messages are handed to the broadcaster workers of their destination
 		for domain, msgs := range broadcastMsgs {
 			chain, ok := registeredDomains[domain]
 			if !ok {
@@ -226,26 +331,28 @@ func StartProcessor(
 				continue
 			}
 
-			if err := chain.Broadcast(ctx, logger, msgs, sequenceMap, metrics); err != nil {
-				logger.Error("Unable to mint one or more transfers", "error(s)", err, "total_transfers", len(msgs), "name", chain.Name(), "domain", domain)
-				requeue = true
-				continue
-			}
-
-			State.Mu.Lock()
-			for _, msg := range msgs {
-				msg.Status = types.Complete
-				msg.Updated = time.Now()
+			waiting := broadcasts.enqueue(domain, tx, msgs)
+			if metrics != nil {
+				metrics.SetBroadcastQueueSize(chain.Name(), fmt.Sprint(domain), waiting)
 			}
-			State.Mu.Unlock()
 		}
 
 		// requeue txs, ensure not to exceed retry limit
 		if requeue {
 			if dequeuedTx.RetryAttempt < cfg.Circle.FetchRetries {
 				dequeuedTx.RetryAttempt++
-				time.Sleep(time.Duration(cfg.Circle.FetchRetryInterval) * time.Second)
-				processingQueue <- tx
+				// on shutdown the tx is not requeued, the processors no longer read the queue and the tx is dumped
+				// from State
+				timer := time.NewTimer(time.Duration(cfg.Circle.FetchRetryInterval) * time.Second)
+				select {
+				case <-timer.C:
+					select {
+					case processingQueue <- tx:
+					case <-ctx.Done():
+					}
+				case <-ctx.Done():
+					timer.Stop()
+				}
 			} else {
 				logger.Error("Retry limit exceeded for tx", "limit", cfg.Circle.FetchRetries, "tx", dequeuedTx.TxHash)
 			}
@@ -253,89 +360,135 @@ func StartProcessor(
 	}
 }
 
-// filterDisabledCCTPRoutes returns true if we haven't enabled relaying from a source domain to a destination domain
-func FilterDisabledCCTPRoutes(cfg *types.Config, logger log.Logger, msg *types.MessageState) bool {
-	val, ok := cfg.EnabledRoutes[msg.SourceDomain]
-	if !ok {
-		logger.Info(fmt.Sprintf("Filtered tx %s because relaying from %d to %d is not enabled",
-			msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
-		return !ok
-	}
-	for _, dd := range val {
-		if dd == msg.DestDomain {
-			return false
-		}
+// checkAttestation queries the iris api matching the message's CCTP version. For V2 messages it also returns
+// the attested message bytes, which replace the emitted bytes once the attestation is complete.
+func checkAttestation(cfg *types.Config, logger log.Logger, msg *types.MessageState) (*types.AttestationResponse, []byte) {
+	if !msg.IsV2() {
+		return circle.CheckAttestation(cfg.Circle.AttestationBaseURL, logger, msg.IrisLookupID, msg.SourceTxHash, msg.SourceDomain, msg.DestDomain), nil
 	}
-	logger.Info(fmt.Sprintf("Filtered tx %s because relaying from %d to %d is not enabled",
-		msg.SourceTxHash, msg.SourceDomain, msg.DestDomain))
-	return true
-}
 
-// filterInvalidDestinationCallers returns true if the minter is not the destination caller for the specified domain
-func filterInvalidDestinationCallers(registeredDomains map[types.Domain]types.Chain, logger log.Logger, msg *types.MessageState) bool {
-	chain, ok := registeredDomains[msg.DestDomain]
-	if !ok {
-		logger.Error("No chain registered for domain", "domain", msg.DestDomain)
-		return true
+	response := circle.CheckAttestationV2(cfg.Circle.AttestationV2BaseURL, logger, msg.SourceTxHash, msg.SourceDomain, msg.DestDomain)
+	if response == nil {
+		return nil, nil
 	}
-	validCaller, address := chain.IsDestinationCaller(msg.DestinationCaller)
 
-	if validCaller {
-		// we do not want to filter this message if valid caller
-		return false
+	attestation, attestedMsg := response.Find(msg.MsgSentBytes)
+	if attestation == nil {
+		return nil, nil
 	}
 
-	logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d due to destination caller: %s)",
-		msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, address))
-	return true
+	return &types.AttestationResponse{
+		Attestation: attestation.Attestation,
+		Status:      attestation.Status,
+	}, attestedMsg
 }
 
-// filterLowTransfers returns true if the amount being transferred to the destination chain is lower than the min-mint-amount configured
-func filterLowTransfers(cfg *types.Config, logger log.Logger, msg *types.MessageState) bool {
-	bm, err := new(cctptypes.BurnMessage).Parse(msg.MsgBody)
-	if err != nil {
-		logger.Info("This is not a burn message", "err", err)
-		return true
+// batchForwardedTransfers holds back the mint and forward of an IBC forwarded transfer until both are attested,
+// and then broadcasts them together so noble receives both in the same tx. held is true if any message is waiting
+// on its pair.
+func batchForwardedTransfers(
+	tx *types.TxState,
+	broadcastMsgs map[types.Domain][]*types.MessageState,
+) (batched map[types.Domain][]*types.MessageState, held bool) {
+	batched = make(map[types.Domain][]*types.MessageState)
+	for domain, msgs := range broadcastMsgs {
+		for _, msg := range msgs {
+			pair := tx.Pair(msg)
+			if pair == nil {
+				batched[domain] = append(batched[domain], msg)
+				continue
+			}
+
+			switch pair.Status() {
+			case types.Created, types.Pending:
+				held = true
+			case types.Attested:
+				batched[domain] = append(batched[domain], msg)
+				// the pair was attested in an earlier pass and is not part of this batch yet
+				if !slices.Contains(msgs, pair) {
+					batched[domain] = append(batched[domain], pair)
+				}
+			default:
+				// the pair is already done with, nothing to wait for
+				batched[domain] = append(batched[domain], msg)
+			}
+		}
 	}
+	return batched, held
+}
 
-	// TODO: not assume that "noble" is domain 4, add "domain" to the noble chain config
-	var minBurnAmount uint64
-	if msg.DestDomain == types.Domain(4) {
-		nobleCfg, ok := cfg.Chains["noble"].(*noble.ChainConfig)
-		if !ok {
-			logger.Info("Chain named 'noble' not found in config, filtering transaction")
-			return true
+// holdPausedMsgs removes the messages whose destination or route is paused from broadcastMsgs. They stay attested
+// and their tx is requeued once they are resumed.
+func holdPausedMsgs(
+	ctx context.Context,
+	logger log.Logger,
+	tx *types.TxState,
+	broadcastMsgs map[types.Domain][]*types.MessageState,
+	processingQueue chan *types.TxState,
+) map[types.Domain][]*types.MessageState {
+	unpaused := make(map[types.Domain][]*types.MessageState)
+	for domain, msgs := range broadcastMsgs {
+		for _, msg := range msgs {
+			if pauses.holdIfPaused(ctx, tx, msg, processingQueue) {
+				logger.Info(fmt.Sprintf("Holding msg in tx %s from %d to %d until relaying to %d is resumed",
+					msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, msg.DestDomain))
+				continue
+			}
+			unpaused[domain] = append(unpaused[domain], msg)
 		}
-		minBurnAmount = nobleCfg.MinMintAmount
-	} else {
-		for _, chain := range cfg.Chains {
-			c, ok := chain.(*ethereum.ChainConfig)
-			if !ok {
-				// noble chain, handled above
+	}
+	return unpaused
+}
+
+// retractTx marks the unattested messages of a tx whose logs were removed in a reorg as retracted. Attested fast
+// transfers are retracted too, iris attests them before their block is final. Other attested messages are left
+// alone, iris only attests them once their block is final.
+func retractTx(logger log.Logger, txHash string) {
+	tx, ok := State.Load(txHash)
+	if !ok {
+		return
+	}
+
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+	for _, msg := range tx.Msgs {
+		switch msg.Status() {
+		case types.Created, types.Pending:
+			logger.Info(fmt.Sprintf("Retracting msg from source domain %d with tx hash %s after reorg", msg.SourceDomain, msg.SourceTxHash))
+			if err := msg.SetStatus(types.Retracted); err != nil {
+				logger.Error("Unable to retract msg", "err", err)
+			}
+		case types.Attested:
+			if !msg.IsFastTransfer() {
+				logger.Error(fmt.Sprintf("Msg from source domain %d with tx hash %s was removed in a reorg but is already attested", msg.SourceDomain, msg.SourceTxHash))
 				continue
 			}
-			if c.Domain == msg.DestDomain {
-				minBurnAmount = c.MinMintAmount
+			logger.Info(fmt.Sprintf("Retracting attested fast transfer from source domain %d with tx hash %s after reorg", msg.SourceDomain, msg.SourceTxHash))
+			if err := msg.SetStatus(types.Retracted); err != nil {
+				logger.Error("Unable to retract msg", "err", err)
 			}
+		case types.Retracted, types.Filtered:
+		default:
+			logger.Error(fmt.Sprintf("Msg from source domain %d with tx hash %s was removed in a reorg but is already %s", msg.SourceDomain, msg.SourceTxHash, msg.Status()))
 		}
 	}
+}
 
-	if bm.Amount.LT(math.NewIntFromUint64(minBurnAmount)) {
-		logger.Info(
-			"Filtered tx because the transfer amount is less than the minimum allowed amount",
-			"dest domain", msg.DestDomain,
-			"source_domain", msg.SourceDomain,
-			"source_tx", msg.SourceTxHash,
-			"amount", bm.Amount,
-			"min_amount", minBurnAmount,
-		)
-		return true
+// reviveRetractedMsgs resets retracted messages to created when their tx is seen again, e.g. re-included after a reorg
+func reviveRetractedMsgs(logger log.Logger, tx *types.TxState) {
+	State.Mu.Lock()
+	defer State.Mu.Unlock()
+	for _, msg := range tx.Msgs {
+		if msg.Status() == types.Retracted {
+			logger.Info(fmt.Sprintf("Msg from source domain %d with tx hash %s seen again after reorg", msg.SourceDomain, msg.SourceTxHash))
+			if err := msg.SetStatus(types.Created); err != nil {
+				logger.Error("Unable to revive msg", "err", err)
+			}
+		}
 	}
-
-	return false
 }
 
-func startAPI(a *AppState) {
+func startAPI(a *AppState, health *relayerHealth, reloader *configReloader) {
 	logger := a.Logger
 	cfg := a.Config
 	gin.SetMode(gin.ReleaseMode)
@@ -348,6 +501,16 @@ func startAPI(a *AppState) {
 	}
 
 	router.GET("/tx/:txHash", getTxByHash)
+	router.GET("/unscannable-heights", func(c *gin.Context) {
+		getUnscannableHeights(c, health.registeredChains())
+	})
+	router.GET("/healthz", func(c *gin.Context) {
+		getHealthz(c, health)
+	})
+	router.GET("/readyz", func(c *gin.Context) {
+		getReadyz(c, health)
+	})
+	registerAdminRoutes(router, adminToken(cfg), health, reloader)
 	err = router.Run("localhost:8000")
 	if err != nil {
 		logger.Error("Unable to start API server: " + err.Error())
@@ -371,3 +534,15 @@ func getTxByHash(c *gin.Context) {
 
 	c.JSON(http.StatusNotFound, gin.H{"message": "message not found"})
 }
+
+// getUnscannableHeights returns the heights that could not be queried after retrying, by chain name
+func getUnscannableHeights(c *gin.Context, chains []types.Chain) {
+	heights := make(map[string][]noble.UnscannableHeight)
+	for _, chain := range chains {
+		if n, ok := chain.(*noble.Noble); ok {
+			heights[n.Name()] = n.UnscannableHeights()
+		}
+	}
+
+	c.JSON(http.StatusOK, heights)
+}
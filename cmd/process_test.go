@@ -23,10 +23,9 @@ var processingQueue chan *types.TxState
 func TestProcessNewLog(t *testing.T) {
 	a, registeredDomains := testutil.ConfigSetup(t)
 
-	sequenceMap := types.NewSequenceMap()
 	processingQueue = make(chan *types.TxState, 10)
 
-	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, sequenceMap, nil)
+	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, nil)
 
 	emptyBz := make([]byte, 32)
 	expectedState := &types.TxState{
@@ -48,17 +47,16 @@ func TestProcessNewLog(t *testing.T) {
 
 	actualState, ok := cmd.State.Load(expectedState.TxHash)
 	require.True(t, ok)
-	require.Equal(t, types.Created, actualState.Msgs[0].Status)
+	require.Equal(t, types.Created, actualState.Msgs[0].Status())
 }
 
 // created message -> disabled cctp route -> filtered
 func TestProcessDisabledCctpRoute(t *testing.T) {
 	a, registeredDomains := testutil.ConfigSetup(t)
 
-	sequenceMap := types.NewSequenceMap()
 	processingQueue = make(chan *types.TxState, 10)
 
-	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, sequenceMap, nil)
+	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, nil)
 
 	emptyBz := make([]byte, 32)
 	expectedState := &types.TxState{
@@ -67,7 +65,6 @@ func TestProcessDisabledCctpRoute(t *testing.T) {
 			{
 				SourceTxHash:      "123",
 				IrisLookupID:      "a404f4155166a1fc7ffee145b5cac6d0f798333745289ab1db171344e226ef0c",
-				Status:            types.Created,
 				SourceDomain:      0,
 				DestDomain:        5, // not configured
 				DestinationCaller: emptyBz,
@@ -81,17 +78,16 @@ func TestProcessDisabledCctpRoute(t *testing.T) {
 
 	actualState, ok := cmd.State.Load(expectedState.TxHash)
 	require.True(t, ok)
-	require.Equal(t, types.Filtered, actualState.Msgs[0].Status)
+	require.Equal(t, types.Filtered, actualState.Msgs[0].Status())
 }
 
 // created message -> different destination caller -> filtered
 func TestProcessInvalidDestinationCaller(t *testing.T) {
 	a, registeredDomains := testutil.ConfigSetup(t)
 
-	sequenceMap := types.NewSequenceMap()
 	processingQueue = make(chan *types.TxState, 10)
 
-	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, sequenceMap, nil)
+	go cmd.StartProcessor(context.TODO(), a, registeredDomains, processingQueue, nil)
 
 	nonEmptyBytes := make([]byte, 31)
 	nonEmptyBytes = append(nonEmptyBytes, 0x1)
@@ -102,7 +98,6 @@ func TestProcessInvalidDestinationCaller(t *testing.T) {
 			{
 				SourceTxHash:      "123",
 				IrisLookupID:      "a404f4155166a1fc7ffee145b5cac6d0f798333745289ab1db171344e226ef0c",
-				Status:            types.Created,
 				SourceDomain:      0,
 				DestDomain:        4,
 				DestinationCaller: nonEmptyBytes,
@@ -116,7 +111,7 @@ func TestProcessInvalidDestinationCaller(t *testing.T) {
 
 	actualState, ok := cmd.State.Load(expectedState.TxHash)
 	require.True(t, ok)
-	require.Equal(t, types.Filtered, actualState.Msgs[0].Status)
+	require.Equal(t, types.Filtered, actualState.Msgs[0].Status())
 }
 
 // we want to filter out the transaction if the route is not enabled
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+)
+
+// processorPool runs the processor workers. Its size can change while the relayer is running: workers are added
+// right away, removed workers finish the tx they are processing first.
+type processorPool struct {
+	ctx   context.Context
+	start func(ctx context.Context)
+
+	mu      sync.Mutex
+	cancels []context.CancelFunc
+
+	// workers tracks running workers, so shutdown can wait for them to finish
+	workers sync.WaitGroup
+}
+
+func newProcessorPool(ctx context.Context, start func(ctx context.Context)) *processorPool {
+	return &processorPool{ctx: ctx, start: start}
+}
+
+// resize starts or stops workers until size are running
+func (p *processorPool) resize(size int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.cancels) < size {
+		ctx, cancel := context.WithCancel(p.ctx)
+		p.cancels = append(p.cancels, cancel)
+		p.workers.Add(1)
+		go func() {
+			defer p.workers.Done()
+			p.start(ctx)
+		}()
+	}
+	for len(p.cancels) > size {
+		last := len(p.cancels) - 1
+		p.cancels[last]()
+		p.cancels = p.cancels[:last]
+	}
+}
+
+// size returns the number of running workers
+func (p *processorPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.cancels)
+}
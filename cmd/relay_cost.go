@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// Actions for transfers whose estimated mint cost is over relay-cost's max-cost-ratio
+const (
+	RelayCostDefer = "defer"
+	RelayCostSkip  = "skip"
+)
+
+const (
+	// FilterUnprofitableTransfers is the FilterReason of transfers skipped by relay-cost
+	FilterUnprofitableTransfers = "unprofitable-transfers"
+	// DeferRelayCost is the DeferReason of transfers deferred by relay-cost
+	DeferRelayCost = "relay-cost"
+)
+
+const (
+	defaultPriceFeedIntervalSeconds = 60
+	defaultRelayCostDeferSeconds    = 60
+
+	// burnTokenExponent converts burn amounts to USD, burns are of USDC
+	burnTokenExponent = 6
+	// estimateMintCostTimeout bounds the gas estimate of each transfer
+	estimateMintCostTimeout = 10 * time.Second
+)
+
+// feedPrices holds the latest prices of the relay-cost price feed
+var feedPrices = &priceFeed{}
+
+type priceFeed struct {
+	mu     sync.RWMutex
+	prices map[string]float64
+}
+
+func (p *priceFeed) set(prices map[string]float64) {
+	p.mu.Lock()
+	p.prices = prices
+	p.mu.Unlock()
+}
+
+// price returns the USD price of denom from the price feed, or from the static prices if the feed has none
+func (p *priceFeed) price(settings types.RelayCostSettings, denom string) (float64, bool) {
+	p.mu.RLock()
+	price, ok := p.prices[strings.ToUpper(denom)]
+	p.mu.RUnlock()
+	if ok {
+		return price, true
+	}
+
+	for d, price := range settings.Prices {
+		if strings.EqualFold(d, denom) {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+// refreshPrices queries the price feed every price-feed-interval until ctx is done. Failed queries keep the
+// previous prices.
+func refreshPrices(ctx context.Context, a *AppState) {
+	logger := a.Logger.With("routine", "price-feed")
+	for {
+		settings := a.CurrentConfig().RelayCost
+		if settings.Enabled && settings.PriceFeed != "" {
+			prices, err := relayer.FetchPrices(ctx, settings.PriceFeed)
+			if err != nil {
+				logger.Error("Unable to query price feed", "err", err)
+			} else {
+				feedPrices.set(prices)
+				logger.Debug("Updated prices from price feed", "prices", prices)
+			}
+		}
+
+		interval := settings.PriceFeedInterval
+		if interval <= 0 {
+			interval = defaultPriceFeedIntervalSeconds
+		}
+		timer := time.NewTimer(time.Duration(interval) * time.Second)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// permissionless returns true if anyone can receive msg, i.e. it has no destination caller
+func permissionless(msg *types.MessageState) bool {
+	return bytes.Count(msg.DestinationCaller, []byte{0}) == len(msg.DestinationCaller)
+}
+
+// relayCostTooHigh estimates the cost of minting msg on chain and returns true, with the costs, if it is more than
+// max-cost-ratio of the transferred amount
+func relayCostTooHigh(ctx context.Context, settings types.RelayCostSettings, chain types.Chain, msg *types.MessageState) (bool, string, error) {
+	bm, err := new(types.BurnMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return false, "", fmt.Errorf("unable to parse burn message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, estimateMintCostTimeout)
+	defer cancel()
+	cost, err := chain.EstimateMintCost(ctx, msg)
+	if err != nil {
+		return false, "", err
+	}
+
+	fee := cost.Amount()
+	if fee == 0 {
+		return false, "", nil
+	}
+	price, ok := feedPrices.price(settings, cost.Denom)
+	if !ok {
+		return false, "", fmt.Errorf("no price configured for %s", cost.Denom)
+	}
+
+	amount, _ := bm.Amount.Float64()
+	costUSD := fee * price
+	amountUSD := amount / math.Pow10(burnTokenExponent)
+	if costUSD <= settings.MaxCostRatio*amountUSD {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("estimated mint cost of %.6g %s ($%.2f) is over %v of the transfer's $%.2f",
+		fee, cost.Denom, costUSD, settings.MaxCostRatio, amountUSD), nil
+}
+
+// checkRelayCosts removes the permissionless transfers whose estimated mint cost on chain is over relay-cost's
+// max-cost-ratio from msgs. They are deferred until the cost falls, or filtered if the action is skip. Transfers
+// whose cost can not be estimated are relayed. Forwards follow their mint. It runs in the broadcaster workers of
+// chain, right before msgs are broadcast, so a slow destination rpc only delays its own broadcasts. ctx bounds the
+// estimates and the requeues of deferred transfers.
+func checkRelayCosts(
+	ctx context.Context,
+	logger log.Logger,
+	cfg *types.Config,
+	chain types.Chain,
+	tx *types.TxState,
+	msgs []*types.MessageState,
+	processingQueue chan *types.TxState,
+	m *relayer.PromMetrics,
+) []*types.MessageState {
+	settings := cfg.RelayCost
+	if !settings.Enabled {
+		return msgs
+	}
+
+	deferInterval := time.Duration(settings.DeferInterval) * time.Second
+	if settings.DeferInterval <= 0 {
+		deferInterval = defaultRelayCostDeferSeconds * time.Second
+	}
+
+	held := make(map[*types.MessageState]bool)
+	for _, msg := range msgs {
+		// we are the destination caller of the other transfers, they are always relayed
+		if msg.Type == types.Forward || !permissionless(msg) {
+			continue
+		}
+
+		tooHigh, detail, err := relayCostTooHigh(ctx, settings, chain, msg)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Unable to estimate the mint cost of msg in tx %s from %d to %d, relaying it",
+				msg.SourceTxHash, msg.SourceDomain, msg.DestDomain), "err", err)
+			continue
+		}
+
+		State.Mu.Lock()
+		switch {
+		case !tooHigh:
+			if msg.DeferReason == DeferRelayCost {
+				msg.DeferReason = ""
+			}
+		case settings.Action == RelayCostSkip:
+			logger.Info(fmt.Sprintf("Filtered tx %s from %d to %d: %s", msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, detail))
+			markFiltered(msg, FilterUnprofitableTransfers, detail, m)
+		default:
+			if markDeferred(msg, DeferRelayCost, m) {
+				logger.Info(fmt.Sprintf("Deferring msg in tx %s from %d to %d: %s", msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, detail))
+			}
+		}
+		State.Mu.Unlock()
+
+		if tooHigh && settings.Action != RelayCostSkip {
+			routeLimits.deferMsg(ctx, tx, msg, deferInterval, processingQueue)
+		}
+		held[msg] = tooHigh
+	}
+
+	relayed := make([]*types.MessageState, 0, len(msgs))
+	for _, msg := range msgs {
+		if held[msg] || (msg.Type == types.Forward && held[tx.Pair(msg)]) {
+			continue
+		}
+		relayed = append(relayed, msg)
+	}
+	return relayed
+}
+
+// validateRelayCost checks that relay-cost has a max-cost-ratio, a supported action and a source of prices
+func validateRelayCost(cfg *types.Config) error {
+	settings := cfg.RelayCost
+	if !settings.Enabled {
+		return nil
+	}
+
+	if settings.MaxCostRatio <= 0 {
+		return fmt.Errorf("relay-cost max-cost-ratio must be greater than zero")
+	}
+	switch settings.Action {
+	case "", RelayCostDefer, RelayCostSkip:
+	default:
+		return fmt.Errorf("unsupported relay-cost action %q, must be %s or %s", settings.Action, RelayCostDefer, RelayCostSkip)
+	}
+	if len(settings.Prices) == 0 && settings.PriceFeed == "" {
+		return fmt.Errorf("relay-cost requires prices or a price-feed")
+	}
+	for denom, price := range settings.Prices {
+		if price < 0 {
+			return fmt.Errorf("relay-cost price of %s must not be negative", denom)
+		}
+	}
+	return nil
+}
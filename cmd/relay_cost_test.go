@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// costChain estimates every mint at the same cost
+type costChain struct {
+	fakeChain
+	cost types.MintCost
+}
+
+func (c *costChain) EstimateMintCost(context.Context, *types.MessageState) (types.MintCost, error) {
+	return c.cost, nil
+}
+
+func TestCheckRelayCosts(t *testing.T) {
+	routeLimits = newRouteLimiter()
+	feedPrices = &priceFeed{}
+	t.Cleanup(func() {
+		routeLimits = newRouteLimiter()
+		feedPrices = &priceFeed{}
+	})
+
+	// minting costs 0.001 ETH
+	chain := &costChain{
+		fakeChain: fakeChain{name: "ethereum", domain: 0},
+		cost:      types.MintCost{Fee: big.NewInt(1_000_000_000_000_000), Denom: "ETH", Exponent: 18},
+	}
+	cfg := &types.Config{RelayCost: types.RelayCostSettings{
+		Enabled:      true,
+		MaxCostRatio: 0.1,
+		Prices:       map[string]float64{"eth": 3000},
+	}}
+	ourCaller := make([]byte, 32)
+	ourCaller[31] = 0x1
+
+	// $3 is more than 10% of $20, but not of $50
+	small := withStatus(&types.MessageState{DestDomain: 0, MsgBody: burnMsgBody(20_000_000), DestinationCaller: make([]byte, 32)}, types.Attested)
+	large := withStatus(&types.MessageState{DestDomain: 0, MsgBody: burnMsgBody(50_000_000), DestinationCaller: make([]byte, 32)}, types.Attested)
+	ours := withStatus(&types.MessageState{DestDomain: 0, MsgBody: burnMsgBody(1), DestinationCaller: ourCaller}, types.Attested)
+	tx := &types.TxState{TxHash: "0x1", Msgs: []*types.MessageState{small, large, ours}}
+	queue := make(chan *types.TxState, 1)
+
+	check := func() []*types.MessageState {
+		return checkRelayCosts(context.Background(), log.NewNopLogger(), cfg, chain, tx, tx.Msgs, queue, nil)
+	}
+
+	require.Equal(t, []*types.MessageState{large, ours}, check())
+	require.Equal(t, DeferRelayCost, small.DeferReason)
+	require.Equal(t, types.Attested, small.Status())
+
+	// the price feed takes precedence over the static prices
+	feedPrices.set(map[string]float64{"ETH": 1000})
+	require.Equal(t, []*types.MessageState{small, large, ours}, check())
+	require.Empty(t, small.DeferReason)
+
+	// transfers are filtered instead of deferred with the skip action
+	feedPrices.set(map[string]float64{"ETH": 3000})
+	cfg.RelayCost.Action = RelayCostSkip
+	require.Equal(t, []*types.MessageState{large, ours}, check())
+	require.Equal(t, types.Filtered, small.Status())
+	require.Equal(t, FilterUnprofitableTransfers, small.FilterReason)
+	require.Contains(t, small.FilterDetail, "($3.00) is over 0.1 of the transfer's $20.00")
+
+	// transfers are relayed when their cost can not be priced
+	feedPrices.set(nil)
+	cfg.RelayCost.Prices = map[string]float64{"AVAX": 30}
+	small = withStatus(&types.MessageState{DestDomain: 0, MsgBody: burnMsgBody(20_000_000), DestinationCaller: make([]byte, 32)}, types.Attested)
+	tx.Msgs[0] = small
+	require.Equal(t, []*types.MessageState{small, large, ours}, check())
+}
+
+func TestValidateRelayCost(t *testing.T) {
+	cfg := &types.Config{}
+	require.NoError(t, validateRelayCost(cfg))
+
+	cfg.RelayCost = types.RelayCostSettings{Enabled: true, Prices: map[string]float64{"ETH": 3000}}
+	require.ErrorContains(t, validateRelayCost(cfg), "max-cost-ratio")
+
+	cfg.RelayCost.MaxCostRatio = 0.05
+	require.NoError(t, validateRelayCost(cfg))
+
+	cfg.RelayCost.Action = "drop"
+	require.ErrorContains(t, validateRelayCost(cfg), "unsupported relay-cost action")
+
+	cfg.RelayCost.Action = RelayCostSkip
+	cfg.RelayCost.Prices = nil
+	require.ErrorContains(t, validateRelayCost(cfg), "prices or a price-feed")
+
+	cfg.RelayCost.PriceFeed = "http://localhost:9000/prices"
+	require.NoError(t, validateRelayCost(cfg))
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// reloadDebounce groups the file events of a single save, editors often write a file in several steps
+const reloadDebounce = time.Second
+
+// configReloader applies changes to the config file to the running relayer without restarting the chain listeners.
+// Only routing, filtering, retry and worker pool settings can be reloaded, reloads changing anything else are
+// rejected.
+type configReloader struct {
+	a            *AppState
+	logger       log.Logger
+	pool         *processorPool
+	broadcasters *broadcasterPools
+	chains       func() []types.Chain
+
+	// mu serializes reloads
+	mu sync.Mutex
+	// started is set once the processor workers run, reloads are rejected before that
+	started bool
+}
+
+func newConfigReloader(a *AppState, pool *processorPool, broadcasters *broadcasterPools, chains func() []types.Chain) *configReloader {
+	return &configReloader{
+		a:            a,
+		logger:       a.Logger.With("routine", "config-reload"),
+		pool:         pool,
+		broadcasters: broadcasters,
+		chains:       chains,
+	}
+}
+
+// start allows reloads, once every chain is registered and the processor workers run
+func (r *configReloader) start() {
+	r.mu.Lock()
+	r.started = true
+	r.mu.Unlock()
+}
+
+// reload parses and validates the config file, then applies it to the running relayer
+func (r *configReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.started {
+		return errors.New("the relayer is still starting")
+	}
+
+	next, err := ParseConfig(r.a.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("unable to parse config file: %w", err)
+	}
+	if err := (&AppState{Config: next, Logger: r.logger}).validateConfig(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	current := r.a.CurrentConfig()
+	if err := checkReloadable(current, next); err != nil {
+		return err
+	}
+
+	if err := screenedAddresses.load(next.AddressLists); err != nil {
+		return err
+	}
+
+	for _, c := range r.chains() {
+		// noble's config key is not its name
+		name := c.Name()
+		if _, ok := c.(*noble.Noble); ok {
+			name = nobleChainName
+		}
+		switch cc := next.Chains[name].(type) {
+		case *noble.ChainConfig:
+			c.SetBroadcastRetries(cc.BroadcastRetries, cc.BroadcastRetryInterval)
+		case *ethereum.ChainConfig:
+			c.SetBroadcastRetries(cc.BroadcastRetries, cc.BroadcastRetryInterval)
+		}
+	}
+	r.pool.resize(int(next.ProcessorWorkerCount))
+	r.broadcasters.resize(next)
+	r.a.live.Store(next)
+
+	r.logger.Info("Reloaded config", "location", r.a.ConfigPath, "processor_workers", r.pool.size(), "broadcaster_workers", r.broadcasters.sizes())
+	return nil
+}
+
+// checkReloadable returns an error if next changes settings that can only be applied by restarting the relayer.
+// Private keys loaded from the environment are carried over to next.
+func checkReloadable(current, next *types.Config) error {
+	var changed []string
+	if !reflect.DeepEqual(current.API, next.API) {
+		changed = append(changed, "api")
+	}
+	if !reflect.DeepEqual(current.Health, next.Health) {
+		changed = append(changed, "health")
+	}
+	if !reflect.DeepEqual(current.Shutdown, next.Shutdown) {
+		changed = append(changed, "shutdown")
+	}
+
+	for name, cc := range current.Chains {
+		if _, ok := next.Chains[name]; !ok {
+			changed = append(changed, fmt.Sprintf("chains.%s (removed)", name))
+			continue
+		}
+		if !chainReloadable(cc, next.Chains[name]) {
+			changed = append(changed, "chains."+name)
+		}
+	}
+	for name := range next.Chains {
+		if _, ok := current.Chains[name]; !ok {
+			changed = append(changed, fmt.Sprintf("chains.%s (added)", name))
+		}
+	}
+
+	if len(changed) > 0 {
+		slices.Sort(changed)
+		return fmt.Errorf("changes to %v require a restart, only enabled-routes, circle, fast-transfer, "+
+			"filters, address-lists, relay-cost, broadcast-priority, processor-worker-count, broadcaster-worker-count, "+
+			"broadcaster-workers, min-mint-amount, broadcast-retries and broadcast-retry-interval can be reloaded", changed)
+	}
+	return nil
+}
+
+// chainReloadable returns whether next only changes the reloadable settings of current
+func chainReloadable(current, next types.ChainConfig) bool {
+	switch c := current.(type) {
+	case *noble.ChainConfig:
+		n, ok := next.(*noble.ChainConfig)
+		if !ok {
+			return false
+		}
+		if n.MinterPrivateKey == "" {
+			n.MinterPrivateKey = c.MinterPrivateKey
+		}
+		unchanged := *n
+		unchanged.MinMintAmount = c.MinMintAmount
+		unchanged.BroadcastRetries = c.BroadcastRetries
+		unchanged.BroadcastRetryInterval = c.BroadcastRetryInterval
+		return reflect.DeepEqual(*c, unchanged)
+	case *ethereum.ChainConfig:
+		n, ok := next.(*ethereum.ChainConfig)
+		if !ok {
+			return false
+		}
+		if n.MinterPrivateKey == "" {
+			n.MinterPrivateKey = c.MinterPrivateKey
+		}
+		unchanged := *n
+		unchanged.MinMintAmount = c.MinMintAmount
+		unchanged.BroadcastRetries = c.BroadcastRetries
+		unchanged.BroadcastRetryInterval = c.BroadcastRetryInterval
+		return reflect.DeepEqual(*c, unchanged)
+	}
+	return false
+}
+
+// watch reloads the config when the config file changes or the relayer receives SIGHUP, until ctx is done
+func (r *configReloader) watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	// editors often replace the file instead of writing it, so the directory is watched
+	var events chan fsnotify.Event
+	var watchErrors chan error
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		r.logger.Error("Unable to watch config file, reload with SIGHUP instead", "err", err)
+	} else {
+		defer watcher.Close()
+		if err := watcher.Add(filepath.Dir(r.a.ConfigPath)); err != nil {
+			r.logger.Error("Unable to watch config file, reload with SIGHUP instead", "err", err)
+		} else {
+			events = watcher.Events
+			watchErrors = watcher.Errors
+		}
+	}
+
+	configPath := filepath.Clean(r.a.ConfigPath)
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.logger.Info("Received SIGHUP, reloading config")
+			r.reloadAndLog()
+		case event := <-events:
+			if filepath.Clean(event.Name) == configPath && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce = time.After(reloadDebounce)
+			}
+		case err := <-watchErrors:
+			r.logger.Error("Error watching config file", "err", err)
+		case <-debounce:
+			debounce = nil
+			r.logger.Info("Config file changed, reloading config")
+			r.reloadAndLog()
+		}
+	}
+}
+
+func (r *configReloader) reloadAndLog() {
+	if err := r.reload(); err != nil {
+		r.logger.Error("Rejected config reload, keeping the current config", "err", err)
+	}
+}
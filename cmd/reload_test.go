@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
+	"github.com/strangelove-ventures/noble-cctp-relayer/noble"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func reloadTestConfig() *types.Config {
+	return &types.Config{
+		Chains: map[string]types.ChainConfig{
+			"noble":    &noble.ChainConfig{RPC: "https://noble.rpc", MinterPrivateKey: "noble-key", BroadcastRetries: 5},
+			"ethereum": &ethereum.ChainConfig{RPC: "https://eth.rpc", MinterPrivateKey: "eth-key", BroadcastRetries: 5},
+		},
+		EnabledRoutes:        map[types.Domain][]types.Domain{0: {4}},
+		ProcessorWorkerCount: 2,
+	}
+}
+
+func TestCheckReloadable(t *testing.T) {
+	current := reloadTestConfig()
+
+	// routes, worker count and chain retry settings can change, keys from the environment are carried over
+	next := reloadTestConfig()
+	next.EnabledRoutes = map[types.Domain][]types.Domain{0: {4}, 4: {0}}
+	next.ProcessorWorkerCount = 4
+	next.Chains["noble"].(*noble.ChainConfig).MinterPrivateKey = ""
+	next.Chains["noble"].(*noble.ChainConfig).MinMintAmount = 100
+	next.Chains["ethereum"].(*ethereum.ChainConfig).BroadcastRetries = 10
+	require.NoError(t, checkReloadable(current, next))
+	require.Equal(t, "noble-key", next.Chains["noble"].(*noble.ChainConfig).MinterPrivateKey)
+
+	// listener settings require a restart
+	next = reloadTestConfig()
+	next.Chains["ethereum"].(*ethereum.ChainConfig).RPC = "https://other.rpc"
+	next.API.TrustedProxies = []string{"10.0.0.1"}
+	err := checkReloadable(current, next)
+	require.ErrorContains(t, err, "[api chains.ethereum]")
+
+	// so do added and removed chains
+	next = reloadTestConfig()
+	delete(next.Chains, "noble")
+	next.Chains["base"] = &ethereum.ChainConfig{}
+	err = checkReloadable(current, next)
+	require.ErrorContains(t, err, "chains.base (added)")
+	require.ErrorContains(t, err, "chains.noble (removed)")
+}
+
+func TestProcessorPoolResize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var running atomic.Int32
+	pool := newProcessorPool(ctx, func(ctx context.Context) {
+		running.Add(1)
+		defer running.Add(-1)
+		<-ctx.Done()
+	})
+
+	pool.resize(3)
+	require.Equal(t, 3, pool.size())
+	require.Eventually(t, func() bool { return running.Load() == 3 }, time.Second, 10*time.Millisecond)
+
+	pool.resize(1)
+	require.Equal(t, 1, pool.size())
+	require.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	pool.workers.Wait()
+	require.Zero(t, running.Load())
+}
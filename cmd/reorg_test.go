@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestRetractTx(t *testing.T) {
+	pending := withStatus(&types.MessageState{}, types.Created)
+	// attested once its block was final
+	finalized := withStatus(&types.MessageState{Version: types.MessageVersionV2, FinalityThresholdExecuted: types.FinalityThresholdFinalized}, types.Attested)
+	// attested before its block was final
+	fast := withStatus(&types.MessageState{Version: types.MessageVersionV2, FinalityThresholdExecuted: 1000}, types.Attested)
+	complete := withStatus(&types.MessageState{Version: types.MessageVersionV2, FinalityThresholdExecuted: 1000}, types.Complete)
+
+	tx := &types.TxState{TxHash: "0xretract", Msgs: []*types.MessageState{pending, finalized, fast, complete}}
+	State.Store(tx.TxHash, tx)
+
+	retractTx(log.NewNopLogger(), tx.TxHash)
+	require.Equal(t, types.Retracted, pending.Status())
+	require.Equal(t, types.Attested, finalized.Status())
+	require.Equal(t, types.Retracted, fast.Status())
+	require.Equal(t, types.Complete, complete.Status())
+}
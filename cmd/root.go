@@ -26,6 +26,7 @@ func NewRootCmd() *cobra.Command {
 		Start(a),
 		getVersionCmd(),
 		configShowCmd(a),
+		adminCmd(a),
 	)
 
 	addAppPersistantFlags(rootCmd, a)
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// Reasons attested messages are deferred by their route's policy, as recorded in a message's DeferReason and the
+// deferred messages metric
+const (
+	DeferMaxAmount      = "max-amount"
+	DeferVolumeCap      = "volume-cap"
+	DeferMaxTxPerMinute = "max-tx-per-minute"
+)
+
+const (
+	// defaultVolumeWindowSeconds is the volume cap window if volume-window is not set
+	defaultVolumeWindowSeconds = 3600
+	// deferRecheckInterval is how often transfers that do not fit the policy at all, e.g. over max-amount, are
+	// checked again. They are relayed once the policy is changed to allow them.
+	deferRecheckInterval = time.Minute
+)
+
+// routeLimits tracks the transfers relayed on each route, and the attested messages deferred by their route's
+// policy. Deferred messages stay attested and their tx goes back to the processing queue once they may fit.
+var routeLimits = newRouteLimiter()
+
+// relayedTransfer is a transfer counted against its route's limits
+type relayedTransfer struct {
+	msg    *types.MessageState
+	at     time.Time
+	amount *big.Int
+}
+
+// deferredMsg is an attested message waiting for its route's limits, due once its tx was requeued
+type deferredMsg struct {
+	due bool
+}
+
+type routeLimiter struct {
+	mu       sync.Mutex
+	relayed  map[route][]relayedTransfer
+	deferred map[*types.MessageState]*deferredMsg
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func newRouteLimiter() *routeLimiter {
+	return &routeLimiter{
+		relayed:  make(map[route][]relayedTransfer),
+		deferred: make(map[*types.MessageState]*deferredMsg),
+		now:      time.Now,
+	}
+}
+
+// admit returns true if msg may be relayed under policy, and counts it against the route's limits. Otherwise it
+// returns why msg has to wait, and how long until it may fit. Messages admitted before are always admitted again,
+// e.g. when their broadcast is retried.
+func (l *routeLimiter) admit(policy types.RoutePolicy, msg *types.MessageState, amount *big.Int) (ok bool, reason string, wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	r := route{Source: msg.SourceDomain, Dest: msg.DestDomain}
+
+	window := time.Duration(policy.VolumeWindow) * time.Second
+	if policy.VolumeWindow <= 0 {
+		window = defaultVolumeWindowSeconds * time.Second
+	}
+
+	// forget transfers that no limit covers anymore
+	relayed := l.relayed[r][:0]
+	for _, t := range l.relayed[r] {
+		if now.Sub(t.at) < max(window, time.Minute) {
+			relayed = append(relayed, t)
+		}
+	}
+	l.relayed[r] = relayed
+
+	for _, t := range relayed {
+		if t.msg == msg {
+			return true, "", 0
+		}
+	}
+
+	if policy.MaxAmount > 0 && amount.Cmp(new(big.Int).SetUint64(policy.MaxAmount)) > 0 {
+		return false, DeferMaxAmount, deferRecheckInterval
+	}
+
+	if policy.MaxTxPerMinute > 0 {
+		var lastMinute []relayedTransfer
+		for _, t := range relayed {
+			if now.Sub(t.at) < time.Minute {
+				lastMinute = append(lastMinute, t)
+			}
+		}
+		// relayed is in the order transfers were admitted, the oldest leaves the minute first
+		if len(lastMinute) >= policy.MaxTxPerMinute {
+			return false, DeferMaxTxPerMinute, lastMinute[len(lastMinute)-policy.MaxTxPerMinute].at.Add(time.Minute).Sub(now)
+		}
+	}
+
+	if policy.VolumeCap > 0 {
+		volumeCap := new(big.Int).SetUint64(policy.VolumeCap)
+		if amount.Cmp(volumeCap) > 0 {
+			return false, DeferVolumeCap, deferRecheckInterval
+		}
+
+		var inWindow []relayedTransfer
+		volume := new(big.Int).Set(amount)
+		for _, t := range relayed {
+			if now.Sub(t.at) < window {
+				inWindow = append(inWindow, t)
+				volume.Add(volume, t.amount)
+			}
+		}
+		if volume.Cmp(volumeCap) > 0 {
+			// wait until enough of the oldest transfers leave the window
+			for _, t := range inWindow {
+				volume.Sub(volume, t.amount)
+				if volume.Cmp(volumeCap) <= 0 {
+					return false, DeferVolumeCap, t.at.Add(window).Sub(now)
+				}
+			}
+		}
+	}
+
+	l.relayed[r] = append(relayed, relayedTransfer{msg: msg, at: now, amount: amount})
+	return true, "", 0
+}
+
+// deferMsg holds msg until wait has passed, tx is then requeued to queue unless ctx is done; the txs of deferred
+// messages are dumped from State on shutdown. A message that is already waiting keeps its current deadline.
+func (l *routeLimiter) deferMsg(ctx context.Context, tx *types.TxState, msg *types.MessageState, wait time.Duration, queue chan *types.TxState) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pruneLocked()
+	if deferred, ok := l.deferred[msg]; ok && !deferred.due {
+		return
+	}
+
+	deferred := &deferredMsg{}
+	time.AfterFunc(max(wait, time.Second), func() {
+		l.mu.Lock()
+		if l.deferred[msg] != deferred {
+			l.mu.Unlock()
+			return
+		}
+		// messages filtered or retracted while they waited are not checked again
+		if msg.Status() != types.Attested {
+			delete(l.deferred, msg)
+			l.mu.Unlock()
+			return
+		}
+		deferred.due = true
+		l.mu.Unlock()
+
+		select {
+		case queue <- tx:
+		case <-ctx.Done():
+		}
+	})
+	l.deferred[msg] = deferred
+}
+
+// pruneLocked forgets the deferred messages that are no longer attested, e.g. filtered after a reload before their
+// tx was checked again. The caller must hold mu.
+func (l *routeLimiter) pruneLocked() {
+	for msg := range l.deferred {
+		if msg.Status() != types.Attested {
+			delete(l.deferred, msg)
+		}
+	}
+}
+
+// release returns true if msg was deferred and its wait has passed, it is then forgotten and checked against its
+// route's limits again
+func (l *routeLimiter) release(msg *types.MessageState) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if deferred, ok := l.deferred[msg]; !ok || !deferred.due {
+		return false
+	}
+	delete(l.deferred, msg)
+	return true
+}
+
+// deferLimitedMsgs removes the messages that exceed their route's policy from broadcastMsgs. They stay attested, are
+// recorded with a DeferReason and their tx is requeued once they may fit. Forwards are deferred with their mint.
+func deferLimitedMsgs(
+	ctx context.Context,
+	logger log.Logger,
+	cfg *types.Config,
+	tx *types.TxState,
+	broadcastMsgs map[types.Domain][]*types.MessageState,
+	processingQueue chan *types.TxState,
+	m *relayer.PromMetrics,
+) map[types.Domain][]*types.MessageState {
+	admitted := make(map[types.Domain][]*types.MessageState)
+	for domain, msgs := range broadcastMsgs {
+		deferred := make(map[*types.MessageState]bool)
+		for _, msg := range msgs {
+			if msg.Type == types.Forward {
+				continue
+			}
+			policy := cfg.RoutePolicy(msg.SourceDomain, msg.DestDomain)
+			if policy == (types.RoutePolicy{}) {
+				continue
+			}
+
+			bm, err := new(types.BurnMessage).Parse(msg.MsgBody)
+			if err != nil {
+				// the low-transfers filter decides on messages that are not burns
+				continue
+			}
+
+			ok, reason, wait := routeLimits.admit(policy, msg, bm.Amount)
+			State.Mu.Lock()
+			if ok {
+				msg.DeferReason = ""
+				State.Mu.Unlock()
+				continue
+			}
+			if markDeferred(msg, reason, m) {
+				logger.Info(fmt.Sprintf("Deferring msg in tx %s from %d to %d with amount %s for %v, it is over the route's %s",
+					msg.SourceTxHash, msg.SourceDomain, msg.DestDomain, bm.Amount, wait.Round(time.Second), reason))
+			}
+			State.Mu.Unlock()
+
+			deferred[msg] = true
+			routeLimits.deferMsg(ctx, tx, msg, wait, processingQueue)
+		}
+
+		for _, msg := range msgs {
+			if deferred[msg] || (msg.Type == types.Forward && deferred[tx.Pair(msg)]) {
+				continue
+			}
+			admitted[domain] = append(admitted[domain], msg)
+		}
+	}
+	return admitted
+}
+
+// markDeferred records why msg is deferred. It returns true if msg was not deferred for reason yet, it is then
+// counted in the deferred messages metric. The caller must hold State.Mu.
+func markDeferred(msg *types.MessageState, reason string, m *relayer.PromMetrics) bool {
+	msg.Updated = time.Now()
+	if msg.DeferReason == reason {
+		return false
+	}
+	msg.DeferReason = reason
+	if m != nil {
+		m.IncDeferredMessages(reason, fmt.Sprint(msg.SourceDomain), fmt.Sprint(msg.DestDomain))
+	}
+	return true
+}
+
+// validateRoutePolicies checks that the limits of each route's policy are consistent
+func validateRoutePolicies(cfg *types.Config) error {
+	for source, dests := range cfg.RoutePolicies {
+		for dest, policy := range dests {
+			switch {
+			case policy.MaxAmount > 0 && policy.MaxAmount < policy.MinAmount:
+				return fmt.Errorf("route %d -> %d: max-amount must not be less than min-amount", source, dest)
+			case policy.VolumeCap > 0 && policy.VolumeCap < policy.MinAmount:
+				return fmt.Errorf("route %d -> %d: volume-cap must not be less than min-amount", source, dest)
+			case policy.VolumeWindow < 0:
+				return fmt.Errorf("route %d -> %d: volume-window must not be negative", source, dest)
+			case policy.MaxTxPerMinute < 0:
+				return fmt.Errorf("route %d -> %d: max-tx-per-minute must not be negative", source, dest)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestRouteLimiterAdmit(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	limiter := newRouteLimiter()
+	limiter.now = func() time.Time { return now }
+
+	transfer := func() *types.MessageState {
+		return &types.MessageState{SourceDomain: 0, DestDomain: 4}
+	}
+	admit := func(policy types.RoutePolicy, msg *types.MessageState, amount int64) (bool, string, time.Duration) {
+		return limiter.admit(policy, msg, big.NewInt(amount))
+	}
+
+	// transfers over max-amount wait for the policy to change
+	ok, reason, wait := admit(types.RoutePolicy{MaxAmount: 100}, transfer(), 101)
+	require.False(t, ok)
+	require.Equal(t, DeferMaxAmount, reason)
+	require.Equal(t, deferRecheckInterval, wait)
+
+	// max-tx-per-minute waits for the oldest transfer to leave the minute
+	policy := types.RoutePolicy{MaxTxPerMinute: 2}
+	first := transfer()
+	ok, _, _ = admit(policy, first, 10)
+	require.True(t, ok)
+	now = now.Add(20 * time.Second)
+	ok, _, _ = admit(policy, transfer(), 10)
+	require.True(t, ok)
+	ok, reason, wait = admit(policy, transfer(), 10)
+	require.False(t, ok)
+	require.Equal(t, DeferMaxTxPerMinute, reason)
+	require.Equal(t, 40*time.Second, wait)
+
+	// retried broadcasts are not counted twice
+	ok, _, _ = admit(policy, first, 10)
+	require.True(t, ok)
+
+	now = now.Add(40 * time.Second)
+	ok, _, _ = admit(policy, transfer(), 10)
+	require.True(t, ok)
+
+	// the volume cap waits for enough volume to leave the window
+	limiter = newRouteLimiter()
+	limiter.now = func() time.Time { return now }
+	policy = types.RoutePolicy{VolumeCap: 100, VolumeWindow: 600}
+	ok, _, _ = admit(policy, transfer(), 50)
+	require.True(t, ok)
+	now = now.Add(100 * time.Second)
+	ok, _, _ = admit(policy, transfer(), 40)
+	require.True(t, ok)
+	ok, reason, wait = admit(policy, transfer(), 20)
+	require.False(t, ok)
+	require.Equal(t, DeferVolumeCap, reason)
+	require.Equal(t, 500*time.Second, wait)
+
+	// transfers over the cap never fit the window
+	_, _, wait = admit(policy, transfer(), 101)
+	require.Equal(t, deferRecheckInterval, wait)
+
+	now = now.Add(500 * time.Second)
+	ok, _, _ = admit(policy, transfer(), 20)
+	require.True(t, ok)
+}
+
+func TestDeferLimitedMsgs(t *testing.T) {
+	routeLimits = newRouteLimiter()
+	t.Cleanup(func() { routeLimits = newRouteLimiter() })
+
+	cfg := &types.Config{RoutePolicies: map[types.Domain]map[types.Domain]types.RoutePolicy{
+		0: {4: {MaxAmount: 100}},
+	}}
+	mint := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(1000), Type: types.Mint, Channel: "channel-1"}, types.Attested)
+	forward := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4, Type: types.Forward, Channel: "channel-1", Nonce: 1}, types.Attested)
+	small := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4, MsgBody: burnMsgBody(10)}, types.Attested)
+	unlimited := withStatus(&types.MessageState{SourceDomain: 1, DestDomain: 4, MsgBody: burnMsgBody(1000)}, types.Attested)
+	tx := &types.TxState{TxHash: "0x1", Msgs: []*types.MessageState{mint, forward, small, unlimited}}
+	queue := make(chan *types.TxState, 1)
+
+	admitted := deferLimitedMsgs(context.Background(), log.NewNopLogger(), cfg, tx, map[types.Domain][]*types.MessageState{4: tx.Msgs}, queue, nil)
+
+	// the forward waits with its mint
+	require.Equal(t, []*types.MessageState{small, unlimited}, admitted[4])
+	require.Equal(t, DeferMaxAmount, mint.DeferReason)
+	require.Equal(t, types.Attested, mint.Status())
+	require.Empty(t, small.DeferReason)
+
+	// the mint is released once its tx is requeued
+	require.False(t, routeLimits.release(mint))
+	routeLimits.mu.Lock()
+	routeLimits.deferred[mint].due = true
+	routeLimits.mu.Unlock()
+	require.True(t, routeLimits.release(mint))
+	require.False(t, routeLimits.release(mint))
+}
+
+func TestRouteLimiterDeferMsg(t *testing.T) {
+	limiter := newRouteLimiter()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// nothing reads the queue after shutdown, the requeue gives up instead of blocking
+	msg := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4}, types.Attested)
+	tx := &types.TxState{TxHash: "0x1", Msgs: []*types.MessageState{msg}}
+	limiter.deferMsg(ctx, tx, msg, 0, make(chan *types.TxState))
+	require.Eventually(t, func() bool { return limiter.release(msg) }, 5*time.Second, 50*time.Millisecond)
+
+	// messages filtered while they wait are forgotten
+	filtered := withStatus(&types.MessageState{SourceDomain: 0, DestDomain: 4}, types.Attested)
+	limiter.deferMsg(ctx, tx, filtered, time.Hour, make(chan *types.TxState))
+	require.NoError(t, filtered.SetStatus(types.Filtered))
+	limiter.deferMsg(ctx, tx, msg, time.Hour, make(chan *types.TxState))
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	require.NotContains(t, limiter.deferred, filtered)
+	require.Contains(t, limiter.deferred, msg)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// defaultShutdownGracePeriod is how long processor workers get to finish their current broadcasts on shutdown
+const defaultShutdownGracePeriod = 30 * time.Second
+
+// shutdownGracePeriod returns how long in-flight work may continue after shutdown started
+func shutdownGracePeriod(cfg *types.Config) time.Duration {
+	if cfg.Shutdown.GracePeriod > 0 {
+		return time.Duration(cfg.Shutdown.GracePeriod) * time.Second
+	}
+	return defaultShutdownGracePeriod
+}
+
+// withGracePeriod returns a context for in-flight work that outlives ctx by gracePeriod: it is not cancelled with
+// ctx, but once gracePeriod has passed after ctx is done. Broadcasts are not cancelled mid-signing on shutdown, and
+// their retries stop by the time the unfinished txs are dumped.
+func withGracePeriod(ctx context.Context, gracePeriod time.Duration) context.Context {
+	graceCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	context.AfterFunc(ctx, func() {
+		time.AfterFunc(gracePeriod, cancel)
+	})
+	return graceCtx
+}
+
+// drainProcessors waits up to the shutdown grace period for the processor workers to finish their current tx,
+// then dumps every unfinished tx and logs a summary of the relayer's state.
+func drainProcessors(logger log.Logger, cfg *types.Config, workers *sync.WaitGroup, processingQueue chan *types.TxState) {
+	gracePeriod := shutdownGracePeriod(cfg)
+	logger.Info(fmt.Sprintf("Shutting down. Waiting up to %v for in-flight broadcasts", gracePeriod))
+
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+
+	drained := true
+	timer := time.NewTimer(gracePeriod)
+	select {
+	case <-done:
+		timer.Stop()
+	case <-timer.C:
+		drained = false
+		logger.Error(fmt.Sprintf("Processor workers did not finish within %v, their txs are dumped as they are", gracePeriod))
+	}
+
+	unfinished := unfinishedTxs(processingQueue)
+	if err := dumpTxs(logger, cfg.Shutdown.DumpFile, unfinished); err != nil {
+		logger.Error("Unable to dump unfinished txs", "err", err)
+	}
+
+	counts := make(map[string]int)
+	State.Range(func(_ string, tx *types.TxState) bool {
+		for _, msg := range tx.Msgs {
+			counts[msg.Status()]++
+		}
+		return true
+	})
+	logger.Info("Shutdown summary",
+		"drained", drained,
+		"unfinished_txs", len(unfinished),
+		types.Complete, counts[types.Complete],
+		types.Failed, counts[types.Failed],
+		types.Filtered, counts[types.Filtered],
+		types.Retracted, counts[types.Retracted],
+		types.RelayedByOther, counts[types.RelayedByOther],
+		types.Created, counts[types.Created],
+		types.Pending, counts[types.Pending],
+		types.Attested, counts[types.Attested],
+	)
+}
+
+// unfinishedTxs returns the txs left in the processing queue and the txs in State with messages that are still
+// waiting for an attestation or broadcast, without duplicates
+func unfinishedTxs(processingQueue chan *types.TxState) []*types.TxState {
+	seen := make(map[string]bool)
+	var txs []*types.TxState
+
+Drain:
+	for {
+		select {
+		case tx := <-processingQueue:
+			if !seen[tx.TxHash] {
+				seen[tx.TxHash] = true
+				txs = append(txs, tx)
+			}
+		default:
+			break Drain
+		}
+	}
+
+	State.Range(func(txHash string, tx *types.TxState) bool {
+		if seen[txHash] {
+			return true
+		}
+		for _, msg := range tx.Msgs {
+			if msg.Status() == types.Created || msg.Status() == types.Pending || msg.Status() == types.Attested {
+				seen[txHash] = true
+				txs = append(txs, tx)
+				break
+			}
+		}
+		return true
+	})
+
+	return txs
+}
+
+// dumpTxs writes the txs as json to path, or logs them if path is empty
+func dumpTxs(logger log.Logger, path string, txs []*types.TxState) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	State.Mu.Lock()
+	bz, err := json.MarshalIndent(txs, "", "  ")
+	State.Mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("unable to marshal txs: %w", err)
+	}
+
+	if path == "" {
+		logger.Info(fmt.Sprintf("Unfinished txs: %s", bz))
+		return nil
+	}
+
+	if err := os.WriteFile(path, bz, 0o600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	logger.Info(fmt.Sprintf("Dumped %d unfinished tx(s) to %s", len(txs), path))
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestDrainProcessors(t *testing.T) {
+	// attested, but not broadcast yet
+	inFlight := &types.TxState{TxHash: "0xshutdown-in-flight", Msgs: []*types.MessageState{withStatus(&types.MessageState{}, types.Attested)}}
+	State.Store(inFlight.TxHash, inFlight)
+	complete := &types.TxState{TxHash: "0xshutdown-complete", Msgs: []*types.MessageState{withStatus(&types.MessageState{}, types.Complete)}}
+	State.Store(complete.TxHash, complete)
+
+	// not picked up by a worker yet
+	processingQueue := make(chan *types.TxState, 10)
+	queued := &types.TxState{TxHash: "0xshutdown-queued", Msgs: []*types.MessageState{withStatus(&types.MessageState{}, types.Created)}}
+	processingQueue <- queued
+
+	dumpFile := filepath.Join(t.TempDir(), "unfinished.json")
+	cfg := &types.Config{Shutdown: types.ShutdownSettings{GracePeriod: 1, DumpFile: dumpFile}}
+
+	// a worker stuck in a broadcast does not block shutdown past the grace period
+	var workers sync.WaitGroup
+	workers.Add(1)
+	defer workers.Done()
+
+	drainProcessors(log.NewNopLogger(), cfg, &workers, processingQueue)
+
+	bz, err := os.ReadFile(dumpFile)
+	require.NoError(t, err)
+	var dumped []*types.TxState
+	require.NoError(t, json.Unmarshal(bz, &dumped))
+
+	hashes := make(map[string]bool)
+	for _, tx := range dumped {
+		hashes[tx.TxHash] = true
+	}
+	require.True(t, hashes[inFlight.TxHash])
+	require.True(t, hashes[queued.TxHash])
+	require.False(t, hashes[complete.TxHash])
+	require.Empty(t, processingQueue)
+}
+
+func TestWithGracePeriod(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	graceCtx := withGracePeriod(ctx, 50*time.Millisecond)
+
+	// in-flight work outlives ctx until the grace period passed
+	cancel()
+	require.NoError(t, graceCtx.Err())
+	require.Eventually(t, func() bool { return graceCtx.Err() != nil }, time.Second, time.Millisecond)
+}
+
+func TestStartProcessorLeavesRetriesToTheDump(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// iris has no attestation yet, shutdown starts while the tx waits to be retried
+	iris := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(iris.Close)
+
+	cfg := &types.Config{
+		EnabledRoutes: map[types.Domain][]types.Domain{0: {4}},
+		Filters:       []string{FilterDisabledRoutes, FilterDestinationCallers, FilterMessageVersions, FilterUnpairedForwards},
+		Circle:        types.CircleSettings{AttestationBaseURL: iris.URL, FetchRetries: 1, FetchRetryInterval: 60},
+	}
+	a := &AppState{Config: cfg, Logger: log.NewNopLogger()}
+	msg := &types.MessageState{SourceDomain: 0, DestDomain: 4, IrisLookupID: "01", MsgBody: burnMsgBody(1_000_000), DestinationCaller: make([]byte, 32)}
+	tx := &types.TxState{TxHash: "0xshutdown-retry", Msgs: []*types.MessageState{msg}}
+	t.Cleanup(func() { State.Delete(tx.TxHash) })
+
+	processingQueue := make(chan *types.TxState, 1)
+	processingQueue <- tx
+	done := make(chan struct{})
+	go func() {
+		StartProcessor(ctx, a, map[types.Domain]types.Chain{4: &anyCallerChain{fakeChain{name: "noble", domain: 4}}}, processingQueue, nil)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("processor did not stop")
+	}
+
+	// the tx is not requeued after the processors stopped reading the queue, it is dumped from State
+	require.Empty(t, processingQueue)
+	require.Contains(t, unfinishedTxs(processingQueue), tx)
+}
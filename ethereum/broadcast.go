@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"cosmossdk.io/log"
@@ -21,12 +22,30 @@ import (
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
+// accountNonce returns the minter's next account nonce, including pending txs, from the healthiest rpc endpoint
+func (e *Ethereum) accountNonce(ctx context.Context) (int64, error) {
+	client := e.rpcClient()
+	nonce, err := client.PendingNonceAt(ctx, common.HexToAddress(e.minterAddress))
+	e.rpcEndpoints.Report(client, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transaction count: %w", err)
+	}
+	return int64(nonce), nil
+}
+
+// messageTransmitter is the subset of the V1 and V2 MessageTransmitter bindings used to mint.
+type messageTransmitter interface {
+	UsedNonces(opts *bind.CallOpts, arg0 [32]byte) (*big.Int, error)
+	SimulateReceiveMessage(opts *bind.CallOpts, message []byte, attestation []byte) error
+	ReceiveMessage(opts *bind.TransactOpts, message []byte, attestation []byte) (*ethtypes.Transaction, error)
+}
+
 func (e *Ethereum) InitializeBroadcaster(
 	ctx context.Context,
 	logger log.Logger,
 	sequenceMap *types.SequenceMap,
 ) error {
-	nextNonce, err := GetEthereumAccountNonce(e.rpcURL, e.minterAddress)
+	nextNonce, err := e.accountNonce(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to retrieve evm account nonce: %w", err)
 	}
@@ -44,32 +63,47 @@ func (e *Ethereum) Broadcast(
 ) error {
 	logger = logger.With("chain", e.name, "chain_id", e.chainID, "domain", e.domain)
 
-	backend := NewContractBackendWrapper(e.rpcClient)
-
 	auth, err := bind.NewKeyedTransactorWithChainID(e.privateKey, big.NewInt(e.chainID))
 	if err != nil {
 		return fmt.Errorf("unable to create auth: %w", err)
 	}
 
-	messageTransmitter, err := contracts.NewMessageTransmitter(common.HexToAddress(e.messageTransmitterAddress), backend)
-	if err != nil {
-		return fmt.Errorf("unable to create message transmitter: %w", err)
-	}
+	// private txs are signed without sending and submitted to the private rpc
+	auth.NoSend = e.private != nil
+
+	maxRetries, retryIntervalSeconds := e.broadcastRetries()
 
 	var broadcastErrors error
 MsgLoop:
 	for _, msg := range msgs {
+		if ctx.Err() != nil {
+			return errors.Join(broadcastErrors, ctx.Err())
+		}
+
 		attestationBytes, err := hex.DecodeString(msg.Attestation[2:])
 		if err != nil {
 			return errors.New("unable to decode message attestation")
 		}
 
-		for attempt := 0; attempt <= e.maxRetries; attempt++ {
+		if msg.IsV2() && e.messageTransmitterV2Address == "" {
+			err := fmt.Errorf("no v2 message transmitter configured for %s", e.name)
+			msg.SetBroadcastStatus(logger, types.Failed, 0, err)
+			broadcastErrors = errors.Join(broadcastErrors, err)
+			continue
+		}
+
+		for attempt := 0; attempt <= maxRetries; attempt++ {
 			// check if another worker already broadcasted tx due to flush
-			if msg.Status == types.Complete {
+			if msg.Status() == types.Complete {
 				continue MsgLoop
 			}
 
+			// bind to the healthiest rpc endpoint on every attempt, so retries fail over
+			messageTransmitter, err := e.messageTransmitter(msg)
+			if err != nil {
+				return err
+			}
+
 			if err := e.attemptBroadcast(
 				ctx,
 				logger,
@@ -78,15 +112,30 @@ MsgLoop:
 				auth,
 				messageTransmitter,
 				attestationBytes,
+				attempt+1,
 			); err == nil {
 				continue MsgLoop
+			} else if msg.Status() == types.Failed {
+				// the message reverts the same way on every attempt, retrying only burns gas
+				if m != nil {
+					m.IncBroadcastErrors(e.name, fmt.Sprint(e.domain))
+				}
+				broadcastErrors = errors.Join(broadcastErrors, err)
+				continue MsgLoop
 			}
 
 			// if it's not the last attempt, retry
 			// TODO increase the destination.ethereum.broadcast retries (3-5) and retry interval (15s).  By checking for used nonces, there is no gas cost for failed mints.
-			if attempt != e.maxRetries {
-				logger.Info(fmt.Sprintf("Retrying in %d seconds", e.retryIntervalSeconds))
-				time.Sleep(time.Duration(e.retryIntervalSeconds) * time.Second)
+			if attempt != maxRetries {
+				logger.Info(fmt.Sprintf("Retrying in %d seconds", retryIntervalSeconds))
+				timer := time.NewTimer(time.Duration(retryIntervalSeconds) * time.Second)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					// the messages not broadcast yet stay attested
+					timer.Stop()
+					return errors.Join(broadcastErrors, fmt.Errorf("stopped retrying after %d attempts: %w", attempt+1, ctx.Err()))
+				}
 			}
 		}
 
@@ -99,14 +148,34 @@ MsgLoop:
 	return broadcastErrors
 }
 
+// messageTransmitter returns the MessageTransmitter binding for the msg's CCTP version on the healthiest rpc endpoint
+func (e *Ethereum) messageTransmitter(msg *types.MessageState) (messageTransmitter, error) {
+	backend := NewContractBackendWrapper(e.rpcClient())
+
+	if msg.IsV2() {
+		messageTransmitterV2, err := contracts.NewMessageTransmitterV2(common.HexToAddress(e.messageTransmitterV2Address), backend)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create v2 message transmitter: %w", err)
+		}
+		return transmitterV2{messageTransmitterV2}, nil
+	}
+
+	messageTransmitterV1, err := contracts.NewMessageTransmitter(common.HexToAddress(e.messageTransmitterAddress), backend)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create message transmitter: %w", err)
+	}
+	return transmitterV1{messageTransmitterV1}, nil
+}
+
 func (e *Ethereum) attemptBroadcast(
 	ctx context.Context,
 	logger log.Logger,
 	msg *types.MessageState,
 	sequenceMap *types.SequenceMap,
 	auth *bind.TransactOpts,
-	messageTransmitter *contracts.MessageTransmitter,
+	messageTransmitter messageTransmitter,
 	attestationBytes []byte,
+	attempt int,
 ) error {
 	logger.Info(fmt.Sprintf(
 		"Broadcasting message from %d to %d: with source tx hash %s",
@@ -121,7 +190,7 @@ func (e *Ethereum) attemptBroadcast(
 	defer e.mu.Unlock()
 
 	// TODO remove
-	nextNonce, err := GetEthereumAccountNonce(e.rpcURL, e.minterAddress)
+	nextNonce, err := e.accountNonce(ctx)
 	if err != nil {
 		logger.Error("unable to retrieve account number")
 	} else {
@@ -129,6 +198,11 @@ func (e *Ethereum) attemptBroadcast(
 	}
 	// TODO end remove
 
+	// pending private txs are not included in the public rpc's nonce
+	if e.private != nil {
+		auth.Nonce = new(big.Int).SetUint64(e.private.nonce(auth.Nonce.Uint64()))
+	}
+
 	// check if nonce already used
 	co := &bind.CallOpts{
 		Pending: true,
@@ -137,32 +211,68 @@ func (e *Ethereum) attemptBroadcast(
 
 	logger.Debug("Checking if nonce was used for broadcast to Ethereum", "source_domain", msg.SourceDomain, "nonce", msg.Nonce)
 
-	key := append(
-		common.LeftPadBytes((big.NewInt(int64(msg.SourceDomain))).Bytes(), 4),
-		common.LeftPadBytes((big.NewInt(int64(msg.Nonce))).Bytes(), 8)...,
-	)
-
-	response, nonceErr := messageTransmitter.UsedNonces(co, [32]byte(crypto.Keccak256(key)))
+	response, nonceErr := messageTransmitter.UsedNonces(co, usedNonceKey(msg))
 	if nonceErr != nil {
 		logger.Debug("Error querying whether nonce was used.   Continuing...", "error:", nonceErr)
 	} else if response.Uint64() == uint64(1) {
 		// nonce has already been used, mark as complete
 		logger.Debug(fmt.Sprintf("This source domain/nonce has already been used: %d %d",
 			msg.SourceDomain, msg.Nonce), "src-tx", msg.SourceTxHash, "reviever")
-		msg.Status = types.Complete
+		msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
 		return nil
 	}
 
+	// another relayer is already receiving the message, racing it only burns gas
+	if e.mempool != nil {
+		client := e.rpcClient()
+		e.mempool.refreshTxpool(ctx, logger, client.Client())
+		if txHash := e.mempool.otherPendingReceive(msg); txHash != "" {
+			logger.Info(fmt.Sprintf("Message from %d with tx hash %s is received by pending tx %s of another relayer, skipping",
+				msg.SourceDomain, msg.SourceTxHash, txHash))
+			msg.DestTxHash = txHash
+			msg.SetBroadcastStatus(logger, types.RelayedByOther, attempt, nil)
+			return nil
+		}
+	}
+
+	// simulate before signing, so messages that would revert do not cost gas
+	co.From = auth.From
+	if err := messageTransmitter.SimulateReceiveMessage(co, msg.MsgSentBytes, attestationBytes); err != nil {
+		var revert *RevertError
+		switch {
+		case errors.As(err, &revert) && revert.NonceAlreadyUsed():
+			logger.Info(fmt.Sprintf("Message from %d with tx hash %s was already received", msg.SourceDomain, msg.SourceTxHash))
+			msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
+			return nil
+		case errors.As(err, &revert) && revert.Permanent:
+			logger.Error(fmt.Sprintf("Message from %d with tx hash %s can not be received, marking it as failed", msg.SourceDomain, msg.SourceTxHash), "err", err)
+			msg.SetBroadcastStatus(logger, types.Failed, attempt, err)
+			return err
+		default:
+			logger.Error("Simulating receiveMessage failed", "err", err)
+			return err
+		}
+	}
+
 	// broadcast txn
 	tx, err := messageTransmitter.ReceiveMessage(
 		auth,
 		msg.MsgSentBytes,
 		attestationBytes,
 	)
+	if err == nil && e.private != nil {
+		var pending bool
+		pending, err = e.sendPrivateTx(ctx, logger, msg, tx, attempt)
+		if pending {
+			// the msg stays attested until the private tx is mined
+			msg.DestTxHash = tx.Hash().Hex()
+			logger.Info(fmt.Sprintf("Submitted %s to Ethereum privately.  Tx hash: %s", msg.SourceTxHash, msg.DestTxHash))
+			return nil
+		}
+	}
 	if err == nil {
-		msg.Status = types.Complete
-
 		msg.DestTxHash = tx.Hash().Hex()
+		msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
 
 		logger.Info(fmt.Sprintf("Successfully broadcast %s to Ethereum.  Tx hash: %s", msg.SourceTxHash, msg.DestTxHash))
 
@@ -172,7 +282,7 @@ func (e *Ethereum) attemptBroadcast(
 	logger.Error(fmt.Sprintf("error during broadcast: %s", err.Error()))
 	if parsedErr, ok := err.(JSONError); ok {
 		if parsedErr.ErrorCode() == 3 && parsedErr.Error() == "execution reverted: Nonce already used" {
-			msg.Status = types.Complete
+			msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
 			logger.Error(fmt.Sprintf("This account nonce has already been used: %d", nonce))
 
 			return nil
@@ -183,7 +293,7 @@ func (e *Ethereum) attemptBroadcast(
 			numberRegex := regexp.MustCompile("[0-9]+")
 			nextNonce, err := strconv.ParseInt(numberRegex.FindAllString(parsedErr.Error(), 1)[0], 10, 0)
 			if err != nil {
-				nextNonce, err = GetEthereumAccountNonce(e.rpcURL, e.minterAddress)
+				nextNonce, err = e.accountNonce(ctx)
 				if err != nil {
 					logger.Error("unable to retrieve account number")
 				}
@@ -194,3 +304,17 @@ func (e *Ethereum) attemptBroadcast(
 
 	return err
 }
+
+// usedNonceKey returns the key of the MessageTransmitter usedNonces mapping for a message.
+// V1 hashes the source domain and nonce, V2 uses the bytes32 nonce assigned by Circle.
+func usedNonceKey(msg *types.MessageState) [32]byte {
+	if msg.IsV2() {
+		return [32]byte(common.LeftPadBytes(msg.NonceV2, 32))
+	}
+
+	key := append(
+		common.LeftPadBytes((big.NewInt(int64(msg.SourceDomain))).Bytes(), 4),
+		common.LeftPadBytes((big.NewInt(int64(msg.Nonce))).Bytes(), 8)...,
+	)
+	return [32]byte(crypto.Keccak256(key))
+}
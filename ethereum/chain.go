@@ -6,14 +6,19 @@ import (
 	"crypto/ecdsa"
 	"embed"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"cosmossdk.io/log"
 
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
@@ -22,70 +27,139 @@ var content embed.FS
 
 var _ types.Chain = (*Ethereum)(nil)
 
+const (
+	defaultPollIntervalSeconds = 5
+	defaultPollRange           = uint64(100)
+	defaultLogRangeMin         = uint64(1)
+	defaultLogRangeMax         = uint64(10000)
+	defaultLogQueryRetries     = 5
+)
+
 type Ethereum struct {
 	// from config
-	name                      string
-	chainID                   int64
-	domain                    types.Domain
-	rpcURL                    string
-	wsURL                     string
-	messageTransmitterAddress string
-	startBlock                uint64
-	lookbackPeriod            uint64
-	privateKey                *ecdsa.PrivateKey
-	minterAddress             string
-	maxRetries                int
-	retryIntervalSeconds      int
-	minAmount                 uint64
-	MetricsDenom              string
-	MetricsExponent           int
+	name                        string
+	chainID                     int64
+	domain                      types.Domain
+	rpcURLs                     []string
+	wsURLs                      []string
+	messageTransmitterAddress   string
+	messageTransmitterV2Address string
+	startBlock                  uint64
+	lookbackPeriod              uint64
+	confirmations               uint64
+	pollInterval                time.Duration
+	pollRange                   uint64
+	logQueryRetries             int
+	wsReconnectAttempts         int
+	privateKey                  *ecdsa.PrivateKey
+	minterAddress               string
+	maxRetries                  int
+	retryIntervalSeconds        int
+	minAmount                   uint64
+	MetricsDenom                string
+	MetricsExponent             int
+	minWalletBalance            float64
 
 	mu sync.Mutex
 
-	wsClient  *ethclient.Client
-	rpcClient *ethclient.Client
+	wsEndpoints  *relayer.EndpointPool[*ethclient.Client]
+	rpcEndpoints *relayer.EndpointPool[*ethclient.Client]
 
 	latestBlock      uint64
 	lastFlushedBlock uint64
+
+	// logRange is the number of blocks per log query, it adapts to what the endpoints accept
+	logRange *relayer.RangeSize
+
+	// walletBalance is the minter's latest balance in MetricsDenom, nil until it is first queried
+	walletBalance *float64
+	// listenerConnected is set while the listener is subscribed to, or polling for, new logs
+	listenerConnected bool
+
+	// polling is set when logs are polled over rpc instead of streamed over the websocket
+	polling bool
+
+	// flushRequests holds a flush requested with RequestFlush until the flush mechanism runs it
+	flushRequests chan struct{}
+
+	// mempool tracks other relayers' pending receiveMessage txs, nil unless watch-mempool is enabled
+	mempool *mempoolWatcher
+
+	// private submits mints to a private rpc, nil unless private-rpc is set
+	private *privateSubmitter
+
+	// stream messages waiting for confirmations, kept across websocket reconnects
+	confirmationQueue *confirmationQueue
 }
 
-func NewChain(
-	name string,
-	domain types.Domain,
-	chainID int64,
-	rpcURL string,
-	wsURL string,
-	messageTransmitterAddress string,
-	startBlock uint64,
-	lookbackPeriod uint64,
-	privateKey string,
-	maxRetries int,
-	retryIntervalSeconds int,
-	minAmount uint64,
-	metricsDenom string,
-	metricsExponent int,
-) (*Ethereum, error) {
-	privEcdsaKey, ethereumAddress, err := GetEcdsaKeyAddress(privateKey)
+// NewChain returns the chain named name, configured by cfg. Unset polling and log query settings fall back to their
+// defaults.
+func NewChain(name string, cfg *ChainConfig) (*Ethereum, error) {
+	privEcdsaKey, ethereumAddress, err := GetEcdsaKeyAddress(cfg.MinterPrivateKey)
 	if err != nil {
 		return nil, err
 	}
-	return &Ethereum{
-		name:                      name,
-		chainID:                   chainID,
-		domain:                    domain,
-		rpcURL:                    rpcURL,
-		wsURL:                     wsURL,
-		messageTransmitterAddress: messageTransmitterAddress,
-		startBlock:                startBlock,
-		lookbackPeriod:            lookbackPeriod,
-		privateKey:                privEcdsaKey,
-		minterAddress:             ethereumAddress,
-		maxRetries:                maxRetries,
-		retryIntervalSeconds:      retryIntervalSeconds,
-		minAmount:                 minAmount,
-		MetricsDenom:              metricsDenom,
-		MetricsExponent:           metricsExponent,
-	}, nil
+	pollIntervalSeconds := cfg.PollInterval
+	if pollIntervalSeconds <= 0 {
+		pollIntervalSeconds = defaultPollIntervalSeconds
+	}
+	pollRange := cfg.PollRange
+	if pollRange == 0 {
+		pollRange = defaultPollRange
+	}
+	logRangeMin := cfg.LogRangeMin
+	if logRangeMin == 0 {
+		logRangeMin = defaultLogRangeMin
+	}
+	logRangeMax := cfg.LogRangeMax
+	if logRangeMax == 0 {
+		logRangeMax = defaultLogRangeMax
+	}
+	logQueryRetries := cfg.LogQueryRetries
+	if logQueryRetries <= 0 {
+		logQueryRetries = defaultLogQueryRetries
+	}
+	e := &Ethereum{
+		name:                        name,
+		chainID:                     cfg.ChainID,
+		domain:                      cfg.Domain,
+		rpcURLs:                     types.Endpoints(cfg.RPC, cfg.FallbackRPCs),
+		wsURLs:                      types.Endpoints(cfg.WS, cfg.FallbackWS),
+		messageTransmitterAddress:   cfg.MessageTransmitter,
+		messageTransmitterV2Address: cfg.MessageTransmitterV2,
+		startBlock:                  cfg.StartBlock,
+		lookbackPeriod:              cfg.LookbackPeriod,
+		confirmations:               cfg.Confirmations,
+		pollInterval:                time.Duration(pollIntervalSeconds) * time.Second,
+		pollRange:                   pollRange,
+		logQueryRetries:             logQueryRetries,
+		logRange:                    relayer.NewRangeSize(pollRange, logRangeMin, logRangeMax),
+		wsReconnectAttempts:         cfg.WSReconnectAttempts,
+		polling:                     cfg.Polling,
+		privateKey:                  privEcdsaKey,
+		minterAddress:               ethereumAddress,
+		maxRetries:                  cfg.BroadcastRetries,
+		retryIntervalSeconds:        cfg.BroadcastRetryInterval,
+		minAmount:                   cfg.MinMintAmount,
+		MetricsDenom:                cfg.MetricsDenom,
+		MetricsExponent:             cfg.MetricsExponent,
+		minWalletBalance:            cfg.MinWalletBalance,
+		confirmationQueue:           newConfirmationQueue(cfg.Confirmations),
+		flushRequests:               make(chan struct{}, 1),
+	}
+	if cfg.WatchMempool {
+		e.mempool, err = newMempoolWatcher(cfg.ChainID, ethereumAddress, e.messageTransmitterAddresses())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.PrivateRPC != "" {
+		e.private, err = newPrivateSubmitter(cfg.PrivateRPC, cfg.PrivateRPCMethod, cfg.PrivateTxTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
 }
 
 func (e *Ethereum) Name() string {
@@ -109,6 +183,69 @@ func (e *Ethereum) SetLatestBlock(block uint64) {
 	e.mu.Unlock()
 }
 
+// confirmedBlock returns the latest block buried under the configured number of confirmations
+func (e *Ethereum) confirmedBlock() uint64 {
+	latestBlock := e.LatestBlock()
+	if latestBlock < e.confirmations {
+		return 0
+	}
+	return latestBlock - e.confirmations
+}
+
+func (e *Ethereum) isPolling() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.polling
+}
+
+func (e *Ethereum) setPolling() {
+	e.mu.Lock()
+	e.polling = true
+	e.mu.Unlock()
+}
+
+func (e *Ethereum) SetBroadcastRetries(maxRetries, retryIntervalSeconds int) {
+	e.mu.Lock()
+	e.maxRetries = maxRetries
+	e.retryIntervalSeconds = retryIntervalSeconds
+	e.mu.Unlock()
+}
+
+// RequestFlush makes the flush mechanism flush right away instead of waiting for the flush interval
+func (e *Ethereum) RequestFlush() error {
+	select {
+	case e.flushRequests <- struct{}{}:
+		return nil
+	default:
+		return errors.New("a flush is already pending")
+	}
+}
+
+func (e *Ethereum) broadcastRetries() (maxRetries, retryIntervalSeconds int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.maxRetries, e.retryIntervalSeconds
+}
+
+func (e *Ethereum) setListenerConnected(connected bool) {
+	e.mu.Lock()
+	e.listenerConnected = connected
+	e.mu.Unlock()
+}
+
+func (e *Ethereum) Status() types.ChainStatus {
+	rpcReachable := e.rpcEndpoints != nil && e.rpcEndpoints.Healthy()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return types.ChainStatus{
+		RPCReachable:      rpcReachable,
+		ListenerConnected: e.listenerConnected,
+		WalletBalance:     e.walletBalance,
+		MinWalletBalance:  e.minWalletBalance,
+	}
+}
+
 func (e *Ethereum) LastFlushedBlock() uint64 {
 	return e.lastFlushedBlock
 }
@@ -131,27 +268,78 @@ func (e *Ethereum) IsDestinationCaller(destinationCaller []byte) (isCaller bool,
 	}
 	return false, encodedCaller
 }
+
+// messageTransmitterAddresses returns the V1 and, if configured, the V2 MessageTransmitter address.
+// Both versions emit the same MessageSent event, so they are queried together.
+func (e *Ethereum) messageTransmitterAddresses() []common.Address {
+	addresses := []common.Address{common.HexToAddress(e.messageTransmitterAddress)}
+	if e.messageTransmitterV2Address != "" {
+		addresses = append(addresses, common.HexToAddress(e.messageTransmitterV2Address))
+	}
+	return addresses
+}
+
 func (e *Ethereum) InitializeClients(ctx context.Context, logger log.Logger) error {
 	var err error
 
-	e.wsClient, err = ethclient.DialContext(ctx, e.wsURL)
-	if err != nil {
-		return fmt.Errorf("unable to initialize websocket ethereum client; err: %w", err)
+	dial := func(url string) (*ethclient.Client, error) {
+		return ethclient.DialContext(ctx, url)
+	}
+	check := func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.BlockNumber(ctx)
+	}
+
+	// the websocket is not needed when polling
+	if !e.polling {
+		e.wsEndpoints, err = relayer.NewEndpointPool(logger, e.name, e.wsURLs, dial, check)
+		if err != nil {
+			return fmt.Errorf("unable to initialize websocket ethereum client; err: %w", err)
+		}
 	}
 
-	e.rpcClient, err = ethclient.DialContext(ctx, e.rpcURL)
+	e.rpcEndpoints, err = relayer.NewEndpointPool(logger, e.name, e.rpcURLs, dial, check)
 	if err != nil {
 		return fmt.Errorf("unable to initialize rpc ethereum client; err: %w", err)
 	}
+
+	if e.private != nil {
+		e.private.client, err = rpc.DialContext(ctx, e.private.url)
+		if err != nil {
+			return fmt.Errorf("unable to initialize private rpc client; err: %w", err)
+		}
+	}
 	return nil
 }
 
+// rpcClient returns the client of the healthiest rpc endpoint
+func (e *Ethereum) rpcClient() *ethclient.Client {
+	return e.rpcEndpoints.Client()
+}
+
+// blockNumber queries the latest height from the healthiest rpc endpoint
+func (e *Ethereum) blockNumber(ctx context.Context) (uint64, error) {
+	client := e.rpcClient()
+	height, err := client.BlockNumber(ctx)
+	e.rpcEndpoints.Report(client, err)
+	return height, err
+}
+
+// wsClient returns the client of the healthiest websocket endpoint
+func (e *Ethereum) wsClient() *ethclient.Client {
+	return e.wsEndpoints.Client()
+}
+
 func (e *Ethereum) CloseClients() error {
-	if e.wsClient != nil {
-		e.wsClient.Close()
+	for _, pool := range []*relayer.EndpointPool[*ethclient.Client]{e.wsEndpoints, e.rpcEndpoints} {
+		if pool == nil {
+			continue
+		}
+		for _, client := range pool.Clients() {
+			client.Close()
+		}
 	}
-	if e.rpcClient != nil {
-		e.rpcClient.Close()
+	if e.private != nil && e.private.client != nil {
+		e.private.client.Close()
 	}
 	return nil
 }
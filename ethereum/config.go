@@ -11,15 +11,39 @@ import (
 var _ types.ChainConfig = (*ChainConfig)(nil)
 
 type ChainConfig struct {
-	RPC                string `yaml:"rpc"`
-	WS                 string `yaml:"ws"`
+	RPC string `yaml:"rpc"`
+	WS  string `yaml:"ws"`
+	// FallbackRPCs and FallbackWS are used alongside RPC and WS, the healthiest endpoint is used
+	FallbackRPCs       []string `yaml:"fallback-rpcs"`
+	FallbackWS         []string `yaml:"fallback-ws"`
 	Domain             types.Domain
 	ChainID            int64  `yaml:"chain-id"`
 	MessageTransmitter string `yaml:"message-transmitter"`
 
+	// MessageTransmitterV2 is optional, when set CCTP V2 messages are listened for and minted as well
+	MessageTransmitterV2 string `yaml:"message-transmitter-v2"`
+
 	StartBlock     uint64 `yaml:"start-block"`
 	LookbackPeriod uint64 `yaml:"lookback-period"`
 
+	// Confirmations is the number of blocks a streamed message must be buried under before it is processed.
+	Confirmations uint64 `yaml:"confirmations"`
+
+	// Polling queries logs with eth_getLogs over the rpc endpoint instead of subscribing over the websocket.
+	// The ws endpoint is not required when polling is enabled.
+	Polling      bool   `yaml:"polling"`
+	PollInterval int    `yaml:"poll-interval"`
+	PollRange    uint64 `yaml:"poll-range"`
+	// LogRangeMin and LogRangeMax bound the number of blocks per log query. Queries start at PollRange blocks,
+	// the range doubles after successful queries and halves when the endpoint rejects it as too large.
+	LogRangeMin uint64 `yaml:"log-range-min"`
+	LogRangeMax uint64 `yaml:"log-range-max"`
+	// LogQueryRetries is the number of times a failing log query is retried before it is given up on
+	LogQueryRetries int `yaml:"log-query-retries"`
+	// WSReconnectAttempts is the number of failed websocket subscriptions in a row after which the listener
+	// falls back to polling. Zero keeps retrying the websocket.
+	WSReconnectAttempts int `yaml:"ws-reconnect-attempts"`
+
 	BroadcastRetries       int `yaml:"broadcast-retries"`
 	BroadcastRetryInterval int `yaml:"broadcast-retry-interval"`
 
@@ -28,6 +52,19 @@ type ChainConfig struct {
 	MetricsDenom    string `yaml:"metrics-denom"`
 	MetricsExponent int    `yaml:"metrics-exponent"`
 
+	// MinWalletBalance is the balance, in metrics-denom, below which the chain is reported as degraded
+	MinWalletBalance float64 `yaml:"min-wallet-balance"`
+
+	// WatchMempool skips messages another relayer's pending tx is already receiving
+	WatchMempool bool `yaml:"watch-mempool"`
+
+	// PrivateRPC is optional, mints are submitted to it instead of the public mempool
+	PrivateRPC string `yaml:"private-rpc"`
+	// PrivateRPCMethod is eth_sendPrivateTransaction (default) or eth_sendRawTransaction
+	PrivateRPCMethod string `yaml:"private-rpc-method"`
+	// PrivateTxTimeout is the number of seconds after which a private tx that was not mined is broadcast publicly
+	PrivateTxTimeout int `yaml:"private-tx-timeout"`
+
 	MinterPrivateKey string `yaml:"minter-private-key"`
 }
 
@@ -43,20 +80,5 @@ func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 		}
 	}
 
-	return NewChain(
-		name,
-		c.Domain,
-		c.ChainID,
-		c.RPC,
-		c.WS,
-		c.MessageTransmitter,
-		c.StartBlock,
-		c.LookbackPeriod,
-		c.MinterPrivateKey,
-		c.BroadcastRetries,
-		c.BroadcastRetryInterval,
-		c.MinMintAmount,
-		c.MetricsDenom,
-		c.MetricsExponent,
-	)
+	return NewChain(name, c)
 }
@@ -0,0 +1,182 @@
+package ethereum
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// releasedRetention is how many blocks released txs are remembered for, so they can still be retracted
+// if their logs are removed in a reorg deeper than the confirmation depth.
+const releasedRetention = uint64(256)
+
+// confirmationQueue holds messages seen on the websocket stream until their block is `confirmations` blocks deep.
+// Every message is tracked with the hash of the block its log was included in: logs removed in a reorg are dropped
+// while pending, and reported for retraction if they were already released to the processor.
+//
+// The queue lives on the chain so pending messages survive websocket reconnects.
+type confirmationQueue struct {
+	mu sync.Mutex
+
+	confirmations uint64
+
+	pending  []*pendingMsg
+	released map[common.Hash]releasedTx
+
+	// highestBlock is the highest block a stream log was seen in
+	highestBlock uint64
+	// reorgDepth is the depth of the reorg currently being delivered as removed logs
+	reorgDepth uint64
+}
+
+type pendingMsg struct {
+	log ethtypes.Log
+	msg *types.MessageState
+}
+
+type releasedTx struct {
+	blockNumber uint64
+	blockHash   common.Hash
+	msgs        int
+}
+
+func newConfirmationQueue(confirmations uint64) *confirmationQueue {
+	return &confirmationQueue{
+		confirmations: confirmations,
+		released:      make(map[common.Hash]releasedTx),
+	}
+}
+
+// add queues a message parsed from a stream log. Duplicate logs (e.g. after a reconnect) are ignored.
+func (q *confirmationQueue) add(log ethtypes.Log, msg *types.MessageState) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, p := range q.pending {
+		if p.log.BlockHash == log.BlockHash && p.log.Index == log.Index {
+			return
+		}
+	}
+	if released, ok := q.released[log.TxHash]; ok && released.blockHash == log.BlockHash {
+		return
+	}
+
+	if log.BlockNumber > q.highestBlock {
+		q.highestBlock = log.BlockNumber
+	}
+	q.pending = append(q.pending, &pendingMsg{log: log, msg: msg})
+}
+
+// remove handles a log removed in a reorg. Pending messages from the log are dropped. If the log's tx was already
+// released, it is forgotten and the number of released messages is returned so the caller can retract them.
+func (q *confirmationQueue) remove(log ethtypes.Log) (retractedMsgs int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if log.BlockNumber <= q.highestBlock {
+		if depth := q.highestBlock - log.BlockNumber + 1; depth > q.reorgDepth {
+			q.reorgDepth = depth
+		}
+	}
+
+	pending := q.pending[:0]
+	for _, p := range q.pending {
+		if p.log.BlockHash == log.BlockHash && p.log.Index == log.Index {
+			continue
+		}
+		pending = append(pending, p)
+	}
+	q.pending = pending
+
+	released, ok := q.released[log.TxHash]
+	if !ok || released.blockHash != log.BlockHash {
+		return 0
+	}
+	delete(q.released, log.TxHash)
+	return released.msgs
+}
+
+// takeReorgDepth returns the depth of the reorg observed through removed logs since the last call, or zero.
+func (q *confirmationQueue) takeReorgDepth() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	depth := q.reorgDepth
+	q.reorgDepth = 0
+	return depth
+}
+
+// len returns the number of pending messages
+func (q *confirmationQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.pending)
+}
+
+// release returns the pending messages that have enough confirmations at head, grouped by tx in the order they were
+// seen. Each block's hash is checked against canonicalHash first: messages from blocks that are no longer canonical
+// are dropped and their block numbers returned in reorged. Messages whose block hash cannot be fetched stay pending.
+func (q *confirmationQueue) release(
+	head uint64,
+	canonicalHash func(blockNumber uint64) (common.Hash, error),
+) (txStates []*types.TxState, reorged []uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	canonical := make(map[uint64]common.Hash)
+	byTx := make(map[common.Hash]*types.TxState)
+
+	pending := q.pending[:0]
+	for _, p := range q.pending {
+		blockNumber := p.log.BlockNumber
+		if blockNumber+q.confirmations > head {
+			pending = append(pending, p)
+			continue
+		}
+
+		hash, ok := canonical[blockNumber]
+		if !ok {
+			var err error
+			hash, err = canonicalHash(blockNumber)
+			if err != nil {
+				pending = append(pending, p)
+				continue
+			}
+			canonical[blockNumber] = hash
+			if hash != p.log.BlockHash {
+				reorged = append(reorged, blockNumber)
+			}
+		}
+		if hash != p.log.BlockHash {
+			continue
+		}
+
+		txState, ok := byTx[p.log.TxHash]
+		if !ok {
+			txState = &types.TxState{TxHash: p.msg.SourceTxHash}
+			byTx[p.log.TxHash] = txState
+			txStates = append(txStates, txState)
+		}
+		txState.Msgs = append(txState.Msgs, p.msg)
+
+		q.released[p.log.TxHash] = releasedTx{
+			blockNumber: blockNumber,
+			blockHash:   p.log.BlockHash,
+			msgs:        len(txState.Msgs),
+		}
+	}
+	q.pending = pending
+
+	// forget released txs that can no longer be reorged
+	for txHash, released := range q.released {
+		if released.blockNumber+releasedRetention < head {
+			delete(q.released, txHash)
+		}
+	}
+
+	return txStates, reorged
+}
@@ -0,0 +1,89 @@
+package ethereum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func testLog(blockNumber uint64, blockHash, txHash byte, index uint) (ethtypes.Log, *types.MessageState) {
+	log := ethtypes.Log{
+		BlockNumber: blockNumber,
+		BlockHash:   common.Hash{blockHash},
+		TxHash:      common.Hash{txHash},
+		Index:       index,
+	}
+	return log, &types.MessageState{SourceTxHash: log.TxHash.Hex(), Nonce: uint64(index)}
+}
+
+func TestConfirmationQueue(t *testing.T) {
+	canonical := map[uint64]common.Hash{
+		10: {0xa},
+		11: {0xb},
+	}
+	canonicalHash := func(blockNumber uint64) (common.Hash, error) {
+		hash, ok := canonical[blockNumber]
+		if !ok {
+			return common.Hash{}, errors.New("not found")
+		}
+		return hash, nil
+	}
+
+	q := newConfirmationQueue(3)
+
+	burnLog, burn := testLog(10, 0xa, 0x1, 0)
+	forwardLog, forward := testLog(10, 0xa, 0x1, 1)
+	q.add(burnLog, burn)
+	q.add(forwardLog, forward)
+	// duplicate logs are ignored
+	q.add(burnLog, burn)
+	require.Equal(t, 2, q.len())
+
+	// not enough confirmations yet
+	txStates, reorged := q.release(12, canonicalHash)
+	require.Empty(t, txStates)
+	require.Empty(t, reorged)
+
+	txStates, reorged = q.release(13, canonicalHash)
+	require.Empty(t, reorged)
+	require.Len(t, txStates, 1)
+	require.Equal(t, burn.SourceTxHash, txStates[0].TxHash)
+	require.Equal(t, []*types.MessageState{burn, forward}, txStates[0].Msgs)
+	require.Zero(t, q.len())
+
+	// released logs are not queued again
+	q.add(burnLog, burn)
+	require.Zero(t, q.len())
+
+	// a released tx removed in a reorg is retracted
+	require.Equal(t, 2, q.remove(burnLog))
+	require.Equal(t, uint64(1), q.takeReorgDepth())
+	require.Zero(t, q.takeReorgDepth())
+
+	// a pending log removed in a reorg is dropped
+	pendingLog, pending := testLog(11, 0xb, 0x2, 0)
+	q.add(pendingLog, pending)
+	require.Zero(t, q.remove(pendingLog))
+	require.Zero(t, q.len())
+
+	// a log from a block that is no longer canonical is dropped at release
+	staleLog, stale := testLog(11, 0xc, 0x3, 0)
+	q.add(staleLog, stale)
+	txStates, reorged = q.release(20, canonicalHash)
+	require.Empty(t, txStates)
+	require.Equal(t, []uint64{11}, reorged)
+	require.Zero(t, q.len())
+
+	// a log whose block hash cannot be fetched stays pending
+	unknownLog, unknown := testLog(12, 0xd, 0x4, 0)
+	q.add(unknownLog, unknown)
+	txStates, reorged = q.release(20, canonicalHash)
+	require.Empty(t, txStates)
+	require.Empty(t, reorged)
+	require.Equal(t, 1, q.len())
+}
@@ -0,0 +1,81 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum/contracts"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// transmitterStateCaller is the subset of the V1 and V2 MessageTransmitter bindings describing the contract's state
+type transmitterStateCaller interface {
+	Paused(opts *bind.CallOpts) (bool, error)
+	LocalDomain(opts *bind.CallOpts) (uint32, error)
+	Version(opts *bind.CallOpts) (uint32, error)
+	MaxMessageBodySize(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// ContractStates queries the state of the V1 MessageTransmitter, and of the V2 MessageTransmitter if configured
+func (e *Ethereum) ContractStates(ctx context.Context) ([]types.ContractState, error) {
+	client := e.rpcClient()
+	backend := NewContractBackendWrapper(client)
+
+	v1, err := contracts.NewMessageTransmitter(common.HexToAddress(e.messageTransmitterAddress), backend)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create message transmitter: %w", err)
+	}
+	state, err := transmitterState(ctx, "MessageTransmitter", v1, types.MessageVersionV1)
+	e.rpcEndpoints.Report(client, err)
+	if err != nil {
+		return nil, err
+	}
+	states := []types.ContractState{state}
+
+	if e.messageTransmitterV2Address == "" {
+		return states, nil
+	}
+
+	v2, err := contracts.NewMessageTransmitterV2(common.HexToAddress(e.messageTransmitterV2Address), backend)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create v2 message transmitter: %w", err)
+	}
+	state, err = transmitterState(ctx, "MessageTransmitterV2", v2, types.MessageVersionV2)
+	e.rpcEndpoints.Report(client, err)
+	if err != nil {
+		return nil, err
+	}
+	return append(states, state), nil
+}
+
+func transmitterState(ctx context.Context, name string, caller transmitterStateCaller, expectedVersion uint32) (types.ContractState, error) {
+	state := types.ContractState{Contract: name, ExpectedVersion: expectedVersion}
+	opts := &bind.CallOpts{Context: ctx}
+
+	var err error
+	if state.Paused, err = caller.Paused(opts); err != nil {
+		return state, fmt.Errorf("unable to query whether the %s is paused: %w", name, err)
+	}
+
+	localDomain, err := caller.LocalDomain(opts)
+	if err != nil {
+		return state, fmt.Errorf("unable to query the %s local domain: %w", name, err)
+	}
+	state.LocalDomain = types.Domain(localDomain)
+
+	if state.Version, err = caller.Version(opts); err != nil {
+		return state, fmt.Errorf("unable to query the %s version: %w", name, err)
+	}
+
+	maxMessageBodySize, err := caller.MaxMessageBodySize(opts)
+	if err != nil {
+		return state, fmt.Errorf("unable to query the %s max message body size: %w", name, err)
+	}
+	state.MaxMessageBodySize = maxMessageBodySize.Uint64()
+
+	return state, nil
+}
@@ -0,0 +1,2561 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// MessageTransmitterV2MetaData contains all meta data concerning the MessageTransmitterV2 contract.
+var MessageTransmitterV2MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"_localDomain\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"_version\",\"type\":\"uint32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"AttesterDisabled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"AttesterEnabled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousAttesterManager\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newAttesterManager\",\"type\":\"address\"}],\"name\":\"AttesterManagerUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newMaxMessageBodySize\",\"type\":\"uint256\"}],\"name\":\"MaxMessageBodySizeUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"caller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"sourceDomain\",\"type\":\"uint32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"nonce\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"sender\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"uint32\",\"name\":\"finalityThresholdExecuted\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"messageBody\",\"type\":\"bytes\"}],\"name\":\"MessageReceived\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"message\",\"type\":\"bytes\"}],\"name\":\"MessageSent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferStarted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"Pause\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"PauserChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newRescuer\",\"type\":\"address\"}],\"name\":\"RescuerChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldSignatureThreshold\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newSignatureThreshold\",\"type\":\"uint256\"}],\"name\":\"SignatureThresholdUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"Unpause\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"NONCE_USED\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"attesterManager\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"getEnabledAttester\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getNumEnabledAttesters\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"isEnabledAttester\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"localDomain\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"maxMessageBodySize\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pauser\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pendingOwner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"message\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"attestation\",\"type\":\"bytes\"}],\"name\":\"receiveMessage\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rescuer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"internalType\":\"bytes32\",\"name\":\"recipient\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"destinationCaller\",\"type\":\"bytes32\"},{\"internalType\":\"uint32\",\"name\":\"minFinalityThreshold\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"messageBody\",\"type\":\"bytes\"}],\"name\":\"sendMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"signatureThreshold\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"usedNonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"version\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// MessageTransmitterV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use MessageTransmitterV2MetaData.ABI instead.
+var MessageTransmitterV2ABI = MessageTransmitterV2MetaData.ABI
+
+// MessageTransmitterV2 is an auto generated Go binding around an Ethereum contract.
+type MessageTransmitterV2 struct {
+	MessageTransmitterV2Caller     // Read-only binding to the contract
+	MessageTransmitterV2Transactor // Write-only binding to the contract
+	MessageTransmitterV2Filterer   // Log filterer for contract events
+}
+
+// MessageTransmitterV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type MessageTransmitterV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MessageTransmitterV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type MessageTransmitterV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MessageTransmitterV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type MessageTransmitterV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MessageTransmitterV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type MessageTransmitterV2Session struct {
+	Contract     *MessageTransmitterV2 // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// MessageTransmitterV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type MessageTransmitterV2CallerSession struct {
+	Contract *MessageTransmitterV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// MessageTransmitterV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type MessageTransmitterV2TransactorSession struct {
+	Contract     *MessageTransmitterV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// MessageTransmitterV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type MessageTransmitterV2Raw struct {
+	Contract *MessageTransmitterV2 // Generic contract binding to access the raw methods on
+}
+
+// MessageTransmitterV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type MessageTransmitterV2CallerRaw struct {
+	Contract *MessageTransmitterV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// MessageTransmitterV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type MessageTransmitterV2TransactorRaw struct {
+	Contract *MessageTransmitterV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewMessageTransmitterV2 creates a new instance of MessageTransmitterV2, bound to a specific deployed contract.
+func NewMessageTransmitterV2(address common.Address, backend bind.ContractBackend) (*MessageTransmitterV2, error) {
+	contract, err := bindMessageTransmitterV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2{MessageTransmitterV2Caller: MessageTransmitterV2Caller{contract: contract}, MessageTransmitterV2Transactor: MessageTransmitterV2Transactor{contract: contract}, MessageTransmitterV2Filterer: MessageTransmitterV2Filterer{contract: contract}}, nil
+}
+
+// NewMessageTransmitterV2Caller creates a new read-only instance of MessageTransmitterV2, bound to a specific deployed contract.
+func NewMessageTransmitterV2Caller(address common.Address, caller bind.ContractCaller) (*MessageTransmitterV2Caller, error) {
+	contract, err := bindMessageTransmitterV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2Caller{contract: contract}, nil
+}
+
+// NewMessageTransmitterV2Transactor creates a new write-only instance of MessageTransmitterV2, bound to a specific deployed contract.
+func NewMessageTransmitterV2Transactor(address common.Address, transactor bind.ContractTransactor) (*MessageTransmitterV2Transactor, error) {
+	contract, err := bindMessageTransmitterV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2Transactor{contract: contract}, nil
+}
+
+// NewMessageTransmitterV2Filterer creates a new log filterer instance of MessageTransmitterV2, bound to a specific deployed contract.
+func NewMessageTransmitterV2Filterer(address common.Address, filterer bind.ContractFilterer) (*MessageTransmitterV2Filterer, error) {
+	contract, err := bindMessageTransmitterV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2Filterer{contract: contract}, nil
+}
+
+// bindMessageTransmitterV2 binds a generic wrapper to an already deployed contract.
+func bindMessageTransmitterV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := MessageTransmitterV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MessageTransmitterV2 *MessageTransmitterV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MessageTransmitterV2.Contract.MessageTransmitterV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MessageTransmitterV2 *MessageTransmitterV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.MessageTransmitterV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MessageTransmitterV2 *MessageTransmitterV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.MessageTransmitterV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MessageTransmitterV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MessageTransmitterV2 *MessageTransmitterV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MessageTransmitterV2 *MessageTransmitterV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// NONCEUSED is a free data retrieval call binding the contract method 0x7de25ae4.
+//
+// Solidity: function NONCE_USED() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) NONCEUSED(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "NONCE_USED")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NONCEUSED is a free data retrieval call binding the contract method 0x7de25ae4.
+//
+// Solidity: function NONCE_USED() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) NONCEUSED() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.NONCEUSED(&_MessageTransmitterV2.CallOpts)
+}
+
+// NONCEUSED is a free data retrieval call binding the contract method 0x7de25ae4.
+//
+// Solidity: function NONCE_USED() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) NONCEUSED() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.NONCEUSED(&_MessageTransmitterV2.CallOpts)
+}
+
+// AttesterManager is a free data retrieval call binding the contract method 0x9b0d94b7.
+//
+// Solidity: function attesterManager() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) AttesterManager(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "attesterManager")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AttesterManager is a free data retrieval call binding the contract method 0x9b0d94b7.
+//
+// Solidity: function attesterManager() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) AttesterManager() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.AttesterManager(&_MessageTransmitterV2.CallOpts)
+}
+
+// AttesterManager is a free data retrieval call binding the contract method 0x9b0d94b7.
+//
+// Solidity: function attesterManager() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) AttesterManager() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.AttesterManager(&_MessageTransmitterV2.CallOpts)
+}
+
+// GetEnabledAttester is a free data retrieval call binding the contract method 0xbeb673d8.
+//
+// Solidity: function getEnabledAttester(uint256 index) view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) GetEnabledAttester(opts *bind.CallOpts, index *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "getEnabledAttester", index)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetEnabledAttester is a free data retrieval call binding the contract method 0xbeb673d8.
+//
+// Solidity: function getEnabledAttester(uint256 index) view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) GetEnabledAttester(index *big.Int) (common.Address, error) {
+	return _MessageTransmitterV2.Contract.GetEnabledAttester(&_MessageTransmitterV2.CallOpts, index)
+}
+
+// GetEnabledAttester is a free data retrieval call binding the contract method 0xbeb673d8.
+//
+// Solidity: function getEnabledAttester(uint256 index) view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) GetEnabledAttester(index *big.Int) (common.Address, error) {
+	return _MessageTransmitterV2.Contract.GetEnabledAttester(&_MessageTransmitterV2.CallOpts, index)
+}
+
+// GetNumEnabledAttesters is a free data retrieval call binding the contract method 0x51079a53.
+//
+// Solidity: function getNumEnabledAttesters() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) GetNumEnabledAttesters(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "getNumEnabledAttesters")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetNumEnabledAttesters is a free data retrieval call binding the contract method 0x51079a53.
+//
+// Solidity: function getNumEnabledAttesters() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) GetNumEnabledAttesters() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.GetNumEnabledAttesters(&_MessageTransmitterV2.CallOpts)
+}
+
+// GetNumEnabledAttesters is a free data retrieval call binding the contract method 0x51079a53.
+//
+// Solidity: function getNumEnabledAttesters() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) GetNumEnabledAttesters() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.GetNumEnabledAttesters(&_MessageTransmitterV2.CallOpts)
+}
+
+// IsEnabledAttester is a free data retrieval call binding the contract method 0x7af82f60.
+//
+// Solidity: function isEnabledAttester(address attester) view returns(bool)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) IsEnabledAttester(opts *bind.CallOpts, attester common.Address) (bool, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "isEnabledAttester", attester)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsEnabledAttester is a free data retrieval call binding the contract method 0x7af82f60.
+//
+// Solidity: function isEnabledAttester(address attester) view returns(bool)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) IsEnabledAttester(attester common.Address) (bool, error) {
+	return _MessageTransmitterV2.Contract.IsEnabledAttester(&_MessageTransmitterV2.CallOpts, attester)
+}
+
+// IsEnabledAttester is a free data retrieval call binding the contract method 0x7af82f60.
+//
+// Solidity: function isEnabledAttester(address attester) view returns(bool)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) IsEnabledAttester(attester common.Address) (bool, error) {
+	return _MessageTransmitterV2.Contract.IsEnabledAttester(&_MessageTransmitterV2.CallOpts, attester)
+}
+
+// LocalDomain is a free data retrieval call binding the contract method 0x8d3638f4.
+//
+// Solidity: function localDomain() view returns(uint32)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) LocalDomain(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "localDomain")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// LocalDomain is a free data retrieval call binding the contract method 0x8d3638f4.
+//
+// Solidity: function localDomain() view returns(uint32)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) LocalDomain() (uint32, error) {
+	return _MessageTransmitterV2.Contract.LocalDomain(&_MessageTransmitterV2.CallOpts)
+}
+
+// LocalDomain is a free data retrieval call binding the contract method 0x8d3638f4.
+//
+// Solidity: function localDomain() view returns(uint32)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) LocalDomain() (uint32, error) {
+	return _MessageTransmitterV2.Contract.LocalDomain(&_MessageTransmitterV2.CallOpts)
+}
+
+// MaxMessageBodySize is a free data retrieval call binding the contract method 0xaf47b9bb.
+//
+// Solidity: function maxMessageBodySize() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) MaxMessageBodySize(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "maxMessageBodySize")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxMessageBodySize is a free data retrieval call binding the contract method 0xaf47b9bb.
+//
+// Solidity: function maxMessageBodySize() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) MaxMessageBodySize() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.MaxMessageBodySize(&_MessageTransmitterV2.CallOpts)
+}
+
+// MaxMessageBodySize is a free data retrieval call binding the contract method 0xaf47b9bb.
+//
+// Solidity: function maxMessageBodySize() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) MaxMessageBodySize() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.MaxMessageBodySize(&_MessageTransmitterV2.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) Owner() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.Owner(&_MessageTransmitterV2.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) Owner() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.Owner(&_MessageTransmitterV2.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) Paused() (bool, error) {
+	return _MessageTransmitterV2.Contract.Paused(&_MessageTransmitterV2.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) Paused() (bool, error) {
+	return _MessageTransmitterV2.Contract.Paused(&_MessageTransmitterV2.CallOpts)
+}
+
+// Pauser is a free data retrieval call binding the contract method 0x9fd0506d.
+//
+// Solidity: function pauser() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) Pauser(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "pauser")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Pauser is a free data retrieval call binding the contract method 0x9fd0506d.
+//
+// Solidity: function pauser() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) Pauser() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.Pauser(&_MessageTransmitterV2.CallOpts)
+}
+
+// Pauser is a free data retrieval call binding the contract method 0x9fd0506d.
+//
+// Solidity: function pauser() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) Pauser() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.Pauser(&_MessageTransmitterV2.CallOpts)
+}
+
+// PendingOwner is a free data retrieval call binding the contract method 0xe30c3978.
+//
+// Solidity: function pendingOwner() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) PendingOwner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "pendingOwner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PendingOwner is a free data retrieval call binding the contract method 0xe30c3978.
+//
+// Solidity: function pendingOwner() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) PendingOwner() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.PendingOwner(&_MessageTransmitterV2.CallOpts)
+}
+
+// PendingOwner is a free data retrieval call binding the contract method 0xe30c3978.
+//
+// Solidity: function pendingOwner() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) PendingOwner() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.PendingOwner(&_MessageTransmitterV2.CallOpts)
+}
+
+// Rescuer is a free data retrieval call binding the contract method 0x38a63183.
+//
+// Solidity: function rescuer() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) Rescuer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "rescuer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Rescuer is a free data retrieval call binding the contract method 0x38a63183.
+//
+// Solidity: function rescuer() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) Rescuer() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.Rescuer(&_MessageTransmitterV2.CallOpts)
+}
+
+// Rescuer is a free data retrieval call binding the contract method 0x38a63183.
+//
+// Solidity: function rescuer() view returns(address)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) Rescuer() (common.Address, error) {
+	return _MessageTransmitterV2.Contract.Rescuer(&_MessageTransmitterV2.CallOpts)
+}
+
+// SignatureThreshold is a free data retrieval call binding the contract method 0xa82f2e26.
+//
+// Solidity: function signatureThreshold() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) SignatureThreshold(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "signatureThreshold")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SignatureThreshold is a free data retrieval call binding the contract method 0xa82f2e26.
+//
+// Solidity: function signatureThreshold() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) SignatureThreshold() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.SignatureThreshold(&_MessageTransmitterV2.CallOpts)
+}
+
+// SignatureThreshold is a free data retrieval call binding the contract method 0xa82f2e26.
+//
+// Solidity: function signatureThreshold() view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) SignatureThreshold() (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.SignatureThreshold(&_MessageTransmitterV2.CallOpts)
+}
+
+// UsedNonces is a free data retrieval call binding the contract method 0xfeb61724.
+//
+// Solidity: function usedNonces(bytes32 ) view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) UsedNonces(opts *bind.CallOpts, arg0 [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "usedNonces", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UsedNonces is a free data retrieval call binding the contract method 0xfeb61724.
+//
+// Solidity: function usedNonces(bytes32 ) view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) UsedNonces(arg0 [32]byte) (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.UsedNonces(&_MessageTransmitterV2.CallOpts, arg0)
+}
+
+// UsedNonces is a free data retrieval call binding the contract method 0xfeb61724.
+//
+// Solidity: function usedNonces(bytes32 ) view returns(uint256)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) UsedNonces(arg0 [32]byte) (*big.Int, error) {
+	return _MessageTransmitterV2.Contract.UsedNonces(&_MessageTransmitterV2.CallOpts, arg0)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint32)
+func (_MessageTransmitterV2 *MessageTransmitterV2Caller) Version(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _MessageTransmitterV2.contract.Call(opts, &out, "version")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint32)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) Version() (uint32, error) {
+	return _MessageTransmitterV2.Contract.Version(&_MessageTransmitterV2.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint32)
+func (_MessageTransmitterV2 *MessageTransmitterV2CallerSession) Version() (uint32, error) {
+	return _MessageTransmitterV2.Contract.Version(&_MessageTransmitterV2.CallOpts)
+}
+
+// ReceiveMessage is a paid mutator transaction binding the contract method 0x57ecfd28.
+//
+// Solidity: function receiveMessage(bytes message, bytes attestation) returns(bool success)
+func (_MessageTransmitterV2 *MessageTransmitterV2Transactor) ReceiveMessage(opts *bind.TransactOpts, message []byte, attestation []byte) (*types.Transaction, error) {
+	return _MessageTransmitterV2.contract.Transact(opts, "receiveMessage", message, attestation)
+}
+
+// ReceiveMessage is a paid mutator transaction binding the contract method 0x57ecfd28.
+//
+// Solidity: function receiveMessage(bytes message, bytes attestation) returns(bool success)
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) ReceiveMessage(message []byte, attestation []byte) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.ReceiveMessage(&_MessageTransmitterV2.TransactOpts, message, attestation)
+}
+
+// ReceiveMessage is a paid mutator transaction binding the contract method 0x57ecfd28.
+//
+// Solidity: function receiveMessage(bytes message, bytes attestation) returns(bool success)
+func (_MessageTransmitterV2 *MessageTransmitterV2TransactorSession) ReceiveMessage(message []byte, attestation []byte) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.ReceiveMessage(&_MessageTransmitterV2.TransactOpts, message, attestation)
+}
+
+// SendMessage is a paid mutator transaction binding the contract method 0x14b157ab.
+//
+// Solidity: function sendMessage(uint32 destinationDomain, bytes32 recipient, bytes32 destinationCaller, uint32 minFinalityThreshold, bytes messageBody) returns()
+func (_MessageTransmitterV2 *MessageTransmitterV2Transactor) SendMessage(opts *bind.TransactOpts, destinationDomain uint32, recipient [32]byte, destinationCaller [32]byte, minFinalityThreshold uint32, messageBody []byte) (*types.Transaction, error) {
+	return _MessageTransmitterV2.contract.Transact(opts, "sendMessage", destinationDomain, recipient, destinationCaller, minFinalityThreshold, messageBody)
+}
+
+// SendMessage is a paid mutator transaction binding the contract method 0x14b157ab.
+//
+// Solidity: function sendMessage(uint32 destinationDomain, bytes32 recipient, bytes32 destinationCaller, uint32 minFinalityThreshold, bytes messageBody) returns()
+func (_MessageTransmitterV2 *MessageTransmitterV2Session) SendMessage(destinationDomain uint32, recipient [32]byte, destinationCaller [32]byte, minFinalityThreshold uint32, messageBody []byte) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.SendMessage(&_MessageTransmitterV2.TransactOpts, destinationDomain, recipient, destinationCaller, minFinalityThreshold, messageBody)
+}
+
+// SendMessage is a paid mutator transaction binding the contract method 0x14b157ab.
+//
+// Solidity: function sendMessage(uint32 destinationDomain, bytes32 recipient, bytes32 destinationCaller, uint32 minFinalityThreshold, bytes messageBody) returns()
+func (_MessageTransmitterV2 *MessageTransmitterV2TransactorSession) SendMessage(destinationDomain uint32, recipient [32]byte, destinationCaller [32]byte, minFinalityThreshold uint32, messageBody []byte) (*types.Transaction, error) {
+	return _MessageTransmitterV2.Contract.SendMessage(&_MessageTransmitterV2.TransactOpts, destinationDomain, recipient, destinationCaller, minFinalityThreshold, messageBody)
+}
+
+// MessageTransmitterV2AttesterDisabledIterator is returned from FilterAttesterDisabled and is used to iterate over the raw logs and unpacked data for AttesterDisabled events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2AttesterDisabledIterator struct {
+	Event *MessageTransmitterV2AttesterDisabled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2AttesterDisabledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2AttesterDisabled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2AttesterDisabled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2AttesterDisabledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2AttesterDisabledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2AttesterDisabled represents a AttesterDisabled event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2AttesterDisabled struct {
+	Attester common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttesterDisabled is a free log retrieval operation binding the contract event 0x78e573a18c75957b7cadaab01511aa1c19a659f06ecf53e01de37ed92d3261fc.
+//
+// Solidity: event AttesterDisabled(address indexed attester)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterAttesterDisabled(opts *bind.FilterOpts, attester []common.Address) (*MessageTransmitterV2AttesterDisabledIterator, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "AttesterDisabled", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2AttesterDisabledIterator{contract: _MessageTransmitterV2.contract, event: "AttesterDisabled", logs: logs, sub: sub}, nil
+}
+
+// WatchAttesterDisabled is a free log subscription operation binding the contract event 0x78e573a18c75957b7cadaab01511aa1c19a659f06ecf53e01de37ed92d3261fc.
+//
+// Solidity: event AttesterDisabled(address indexed attester)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchAttesterDisabled(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2AttesterDisabled, attester []common.Address) (event.Subscription, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "AttesterDisabled", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2AttesterDisabled)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "AttesterDisabled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttesterDisabled is a log parse operation binding the contract event 0x78e573a18c75957b7cadaab01511aa1c19a659f06ecf53e01de37ed92d3261fc.
+//
+// Solidity: event AttesterDisabled(address indexed attester)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseAttesterDisabled(log types.Log) (*MessageTransmitterV2AttesterDisabled, error) {
+	event := new(MessageTransmitterV2AttesterDisabled)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "AttesterDisabled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2AttesterEnabledIterator is returned from FilterAttesterEnabled and is used to iterate over the raw logs and unpacked data for AttesterEnabled events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2AttesterEnabledIterator struct {
+	Event *MessageTransmitterV2AttesterEnabled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2AttesterEnabledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2AttesterEnabled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2AttesterEnabled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2AttesterEnabledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2AttesterEnabledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2AttesterEnabled represents a AttesterEnabled event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2AttesterEnabled struct {
+	Attester common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttesterEnabled is a free log retrieval operation binding the contract event 0x5b99bab45c72ce67e89466dbc47480b9c1fde1400e7268bbf463b8354ee4653f.
+//
+// Solidity: event AttesterEnabled(address indexed attester)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterAttesterEnabled(opts *bind.FilterOpts, attester []common.Address) (*MessageTransmitterV2AttesterEnabledIterator, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "AttesterEnabled", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2AttesterEnabledIterator{contract: _MessageTransmitterV2.contract, event: "AttesterEnabled", logs: logs, sub: sub}, nil
+}
+
+// WatchAttesterEnabled is a free log subscription operation binding the contract event 0x5b99bab45c72ce67e89466dbc47480b9c1fde1400e7268bbf463b8354ee4653f.
+//
+// Solidity: event AttesterEnabled(address indexed attester)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchAttesterEnabled(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2AttesterEnabled, attester []common.Address) (event.Subscription, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "AttesterEnabled", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2AttesterEnabled)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "AttesterEnabled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttesterEnabled is a log parse operation binding the contract event 0x5b99bab45c72ce67e89466dbc47480b9c1fde1400e7268bbf463b8354ee4653f.
+//
+// Solidity: event AttesterEnabled(address indexed attester)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseAttesterEnabled(log types.Log) (*MessageTransmitterV2AttesterEnabled, error) {
+	event := new(MessageTransmitterV2AttesterEnabled)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "AttesterEnabled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2AttesterManagerUpdatedIterator is returned from FilterAttesterManagerUpdated and is used to iterate over the raw logs and unpacked data for AttesterManagerUpdated events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2AttesterManagerUpdatedIterator struct {
+	Event *MessageTransmitterV2AttesterManagerUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2AttesterManagerUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2AttesterManagerUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2AttesterManagerUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2AttesterManagerUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2AttesterManagerUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2AttesterManagerUpdated represents a AttesterManagerUpdated event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2AttesterManagerUpdated struct {
+	PreviousAttesterManager common.Address
+	NewAttesterManager      common.Address
+	Raw                     types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttesterManagerUpdated is a free log retrieval operation binding the contract event 0x0cee1b7ae04f3c788dd3a46c6fa677eb95b913611ef7ab59524fdc09d3460219.
+//
+// Solidity: event AttesterManagerUpdated(address indexed previousAttesterManager, address indexed newAttesterManager)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterAttesterManagerUpdated(opts *bind.FilterOpts, previousAttesterManager []common.Address, newAttesterManager []common.Address) (*MessageTransmitterV2AttesterManagerUpdatedIterator, error) {
+
+	var previousAttesterManagerRule []interface{}
+	for _, previousAttesterManagerItem := range previousAttesterManager {
+		previousAttesterManagerRule = append(previousAttesterManagerRule, previousAttesterManagerItem)
+	}
+	var newAttesterManagerRule []interface{}
+	for _, newAttesterManagerItem := range newAttesterManager {
+		newAttesterManagerRule = append(newAttesterManagerRule, newAttesterManagerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "AttesterManagerUpdated", previousAttesterManagerRule, newAttesterManagerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2AttesterManagerUpdatedIterator{contract: _MessageTransmitterV2.contract, event: "AttesterManagerUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchAttesterManagerUpdated is a free log subscription operation binding the contract event 0x0cee1b7ae04f3c788dd3a46c6fa677eb95b913611ef7ab59524fdc09d3460219.
+//
+// Solidity: event AttesterManagerUpdated(address indexed previousAttesterManager, address indexed newAttesterManager)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchAttesterManagerUpdated(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2AttesterManagerUpdated, previousAttesterManager []common.Address, newAttesterManager []common.Address) (event.Subscription, error) {
+
+	var previousAttesterManagerRule []interface{}
+	for _, previousAttesterManagerItem := range previousAttesterManager {
+		previousAttesterManagerRule = append(previousAttesterManagerRule, previousAttesterManagerItem)
+	}
+	var newAttesterManagerRule []interface{}
+	for _, newAttesterManagerItem := range newAttesterManager {
+		newAttesterManagerRule = append(newAttesterManagerRule, newAttesterManagerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "AttesterManagerUpdated", previousAttesterManagerRule, newAttesterManagerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2AttesterManagerUpdated)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "AttesterManagerUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttesterManagerUpdated is a log parse operation binding the contract event 0x0cee1b7ae04f3c788dd3a46c6fa677eb95b913611ef7ab59524fdc09d3460219.
+//
+// Solidity: event AttesterManagerUpdated(address indexed previousAttesterManager, address indexed newAttesterManager)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseAttesterManagerUpdated(log types.Log) (*MessageTransmitterV2AttesterManagerUpdated, error) {
+	event := new(MessageTransmitterV2AttesterManagerUpdated)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "AttesterManagerUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2MaxMessageBodySizeUpdatedIterator is returned from FilterMaxMessageBodySizeUpdated and is used to iterate over the raw logs and unpacked data for MaxMessageBodySizeUpdated events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2MaxMessageBodySizeUpdatedIterator struct {
+	Event *MessageTransmitterV2MaxMessageBodySizeUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2MaxMessageBodySizeUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2MaxMessageBodySizeUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2MaxMessageBodySizeUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2MaxMessageBodySizeUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2MaxMessageBodySizeUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2MaxMessageBodySizeUpdated represents a MaxMessageBodySizeUpdated event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2MaxMessageBodySizeUpdated struct {
+	NewMaxMessageBodySize *big.Int
+	Raw                   types.Log // Blockchain specific contextual infos
+}
+
+// FilterMaxMessageBodySizeUpdated is a free log retrieval operation binding the contract event 0xb13bf6bebed03d1b318e3ea32e4b2a3ad9f5e2312cdf340a2f4bbfaee39f928d.
+//
+// Solidity: event MaxMessageBodySizeUpdated(uint256 newMaxMessageBodySize)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterMaxMessageBodySizeUpdated(opts *bind.FilterOpts) (*MessageTransmitterV2MaxMessageBodySizeUpdatedIterator, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "MaxMessageBodySizeUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2MaxMessageBodySizeUpdatedIterator{contract: _MessageTransmitterV2.contract, event: "MaxMessageBodySizeUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchMaxMessageBodySizeUpdated is a free log subscription operation binding the contract event 0xb13bf6bebed03d1b318e3ea32e4b2a3ad9f5e2312cdf340a2f4bbfaee39f928d.
+//
+// Solidity: event MaxMessageBodySizeUpdated(uint256 newMaxMessageBodySize)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchMaxMessageBodySizeUpdated(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2MaxMessageBodySizeUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "MaxMessageBodySizeUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2MaxMessageBodySizeUpdated)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "MaxMessageBodySizeUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMaxMessageBodySizeUpdated is a log parse operation binding the contract event 0xb13bf6bebed03d1b318e3ea32e4b2a3ad9f5e2312cdf340a2f4bbfaee39f928d.
+//
+// Solidity: event MaxMessageBodySizeUpdated(uint256 newMaxMessageBodySize)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseMaxMessageBodySizeUpdated(log types.Log) (*MessageTransmitterV2MaxMessageBodySizeUpdated, error) {
+	event := new(MessageTransmitterV2MaxMessageBodySizeUpdated)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "MaxMessageBodySizeUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2MessageReceivedIterator is returned from FilterMessageReceived and is used to iterate over the raw logs and unpacked data for MessageReceived events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2MessageReceivedIterator struct {
+	Event *MessageTransmitterV2MessageReceived // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2MessageReceivedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2MessageReceived)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2MessageReceived)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2MessageReceivedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2MessageReceivedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2MessageReceived represents a MessageReceived event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2MessageReceived struct {
+	Caller                    common.Address
+	SourceDomain              uint32
+	Nonce                     [32]byte
+	Sender                    [32]byte
+	FinalityThresholdExecuted uint32
+	MessageBody               []byte
+	Raw                       types.Log // Blockchain specific contextual infos
+}
+
+// FilterMessageReceived is a free log retrieval operation binding the contract event 0xff48c13eda96b1cceacc6b9edeedc9e9db9d6226afbc30146b720c19d3addb1c.
+//
+// Solidity: event MessageReceived(address indexed caller, uint32 sourceDomain, bytes32 indexed nonce, bytes32 sender, uint32 indexed finalityThresholdExecuted, bytes messageBody)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterMessageReceived(opts *bind.FilterOpts, caller []common.Address, nonce [][32]byte, finalityThresholdExecuted []uint32) (*MessageTransmitterV2MessageReceivedIterator, error) {
+
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+
+	var finalityThresholdExecutedRule []interface{}
+	for _, finalityThresholdExecutedItem := range finalityThresholdExecuted {
+		finalityThresholdExecutedRule = append(finalityThresholdExecutedRule, finalityThresholdExecutedItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "MessageReceived", callerRule, nonceRule, finalityThresholdExecutedRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2MessageReceivedIterator{contract: _MessageTransmitterV2.contract, event: "MessageReceived", logs: logs, sub: sub}, nil
+}
+
+// WatchMessageReceived is a free log subscription operation binding the contract event 0xff48c13eda96b1cceacc6b9edeedc9e9db9d6226afbc30146b720c19d3addb1c.
+//
+// Solidity: event MessageReceived(address indexed caller, uint32 sourceDomain, bytes32 indexed nonce, bytes32 sender, uint32 indexed finalityThresholdExecuted, bytes messageBody)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchMessageReceived(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2MessageReceived, caller []common.Address, nonce [][32]byte, finalityThresholdExecuted []uint32) (event.Subscription, error) {
+
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+
+	var finalityThresholdExecutedRule []interface{}
+	for _, finalityThresholdExecutedItem := range finalityThresholdExecuted {
+		finalityThresholdExecutedRule = append(finalityThresholdExecutedRule, finalityThresholdExecutedItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "MessageReceived", callerRule, nonceRule, finalityThresholdExecutedRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2MessageReceived)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "MessageReceived", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMessageReceived is a log parse operation binding the contract event 0xff48c13eda96b1cceacc6b9edeedc9e9db9d6226afbc30146b720c19d3addb1c.
+//
+// Solidity: event MessageReceived(address indexed caller, uint32 sourceDomain, bytes32 indexed nonce, bytes32 sender, uint32 indexed finalityThresholdExecuted, bytes messageBody)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseMessageReceived(log types.Log) (*MessageTransmitterV2MessageReceived, error) {
+	event := new(MessageTransmitterV2MessageReceived)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "MessageReceived", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2MessageSentIterator is returned from FilterMessageSent and is used to iterate over the raw logs and unpacked data for MessageSent events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2MessageSentIterator struct {
+	Event *MessageTransmitterV2MessageSent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2MessageSentIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2MessageSent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2MessageSent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2MessageSentIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2MessageSentIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2MessageSent represents a MessageSent event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2MessageSent struct {
+	Message []byte
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterMessageSent is a free log retrieval operation binding the contract event 0x8c5261668696ce22758910d05bab8f186d6eb247ceac2af2e82c7dc17669b036.
+//
+// Solidity: event MessageSent(bytes message)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterMessageSent(opts *bind.FilterOpts) (*MessageTransmitterV2MessageSentIterator, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "MessageSent")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2MessageSentIterator{contract: _MessageTransmitterV2.contract, event: "MessageSent", logs: logs, sub: sub}, nil
+}
+
+// WatchMessageSent is a free log subscription operation binding the contract event 0x8c5261668696ce22758910d05bab8f186d6eb247ceac2af2e82c7dc17669b036.
+//
+// Solidity: event MessageSent(bytes message)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchMessageSent(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2MessageSent) (event.Subscription, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "MessageSent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2MessageSent)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "MessageSent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMessageSent is a log parse operation binding the contract event 0x8c5261668696ce22758910d05bab8f186d6eb247ceac2af2e82c7dc17669b036.
+//
+// Solidity: event MessageSent(bytes message)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseMessageSent(log types.Log) (*MessageTransmitterV2MessageSent, error) {
+	event := new(MessageTransmitterV2MessageSent)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "MessageSent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2OwnershipTransferStartedIterator is returned from FilterOwnershipTransferStarted and is used to iterate over the raw logs and unpacked data for OwnershipTransferStarted events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2OwnershipTransferStartedIterator struct {
+	Event *MessageTransmitterV2OwnershipTransferStarted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2OwnershipTransferStartedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2OwnershipTransferStarted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2OwnershipTransferStarted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2OwnershipTransferStartedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2OwnershipTransferStartedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2OwnershipTransferStarted represents a OwnershipTransferStarted event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2OwnershipTransferStarted struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferStarted is a free log retrieval operation binding the contract event 0x38d16b8cac22d99fc7c124b9cd0de2d3fa1faef420bfe791d8c362d765e22700.
+//
+// Solidity: event OwnershipTransferStarted(address indexed previousOwner, address indexed newOwner)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterOwnershipTransferStarted(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*MessageTransmitterV2OwnershipTransferStartedIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "OwnershipTransferStarted", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2OwnershipTransferStartedIterator{contract: _MessageTransmitterV2.contract, event: "OwnershipTransferStarted", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferStarted is a free log subscription operation binding the contract event 0x38d16b8cac22d99fc7c124b9cd0de2d3fa1faef420bfe791d8c362d765e22700.
+//
+// Solidity: event OwnershipTransferStarted(address indexed previousOwner, address indexed newOwner)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchOwnershipTransferStarted(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2OwnershipTransferStarted, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "OwnershipTransferStarted", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2OwnershipTransferStarted)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "OwnershipTransferStarted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferStarted is a log parse operation binding the contract event 0x38d16b8cac22d99fc7c124b9cd0de2d3fa1faef420bfe791d8c362d765e22700.
+//
+// Solidity: event OwnershipTransferStarted(address indexed previousOwner, address indexed newOwner)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseOwnershipTransferStarted(log types.Log) (*MessageTransmitterV2OwnershipTransferStarted, error) {
+	event := new(MessageTransmitterV2OwnershipTransferStarted)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "OwnershipTransferStarted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2OwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2OwnershipTransferredIterator struct {
+	Event *MessageTransmitterV2OwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2OwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2OwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2OwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2OwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2OwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2OwnershipTransferred represents a OwnershipTransferred event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2OwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*MessageTransmitterV2OwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2OwnershipTransferredIterator{contract: _MessageTransmitterV2.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2OwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2OwnershipTransferred)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseOwnershipTransferred(log types.Log) (*MessageTransmitterV2OwnershipTransferred, error) {
+	event := new(MessageTransmitterV2OwnershipTransferred)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2PauseIterator is returned from FilterPause and is used to iterate over the raw logs and unpacked data for Pause events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2PauseIterator struct {
+	Event *MessageTransmitterV2Pause // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2PauseIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2Pause)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2Pause)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2PauseIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2PauseIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2Pause represents a Pause event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2Pause struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterPause is a free log retrieval operation binding the contract event 0x6985a02210a168e66602d3235cb6db0e70f92b3ba4d376a33c0f3d9434bff625.
+//
+// Solidity: event Pause()
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterPause(opts *bind.FilterOpts) (*MessageTransmitterV2PauseIterator, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "Pause")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2PauseIterator{contract: _MessageTransmitterV2.contract, event: "Pause", logs: logs, sub: sub}, nil
+}
+
+// WatchPause is a free log subscription operation binding the contract event 0x6985a02210a168e66602d3235cb6db0e70f92b3ba4d376a33c0f3d9434bff625.
+//
+// Solidity: event Pause()
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchPause(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2Pause) (event.Subscription, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "Pause")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2Pause)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "Pause", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePause is a log parse operation binding the contract event 0x6985a02210a168e66602d3235cb6db0e70f92b3ba4d376a33c0f3d9434bff625.
+//
+// Solidity: event Pause()
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParsePause(log types.Log) (*MessageTransmitterV2Pause, error) {
+	event := new(MessageTransmitterV2Pause)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "Pause", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2PauserChangedIterator is returned from FilterPauserChanged and is used to iterate over the raw logs and unpacked data for PauserChanged events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2PauserChangedIterator struct {
+	Event *MessageTransmitterV2PauserChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2PauserChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2PauserChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2PauserChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2PauserChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2PauserChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2PauserChanged represents a PauserChanged event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2PauserChanged struct {
+	NewAddress common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterPauserChanged is a free log retrieval operation binding the contract event 0xb80482a293ca2e013eda8683c9bd7fc8347cfdaeea5ede58cba46df502c2a604.
+//
+// Solidity: event PauserChanged(address indexed newAddress)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterPauserChanged(opts *bind.FilterOpts, newAddress []common.Address) (*MessageTransmitterV2PauserChangedIterator, error) {
+
+	var newAddressRule []interface{}
+	for _, newAddressItem := range newAddress {
+		newAddressRule = append(newAddressRule, newAddressItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "PauserChanged", newAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2PauserChangedIterator{contract: _MessageTransmitterV2.contract, event: "PauserChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchPauserChanged is a free log subscription operation binding the contract event 0xb80482a293ca2e013eda8683c9bd7fc8347cfdaeea5ede58cba46df502c2a604.
+//
+// Solidity: event PauserChanged(address indexed newAddress)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchPauserChanged(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2PauserChanged, newAddress []common.Address) (event.Subscription, error) {
+
+	var newAddressRule []interface{}
+	for _, newAddressItem := range newAddress {
+		newAddressRule = append(newAddressRule, newAddressItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "PauserChanged", newAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2PauserChanged)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "PauserChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePauserChanged is a log parse operation binding the contract event 0xb80482a293ca2e013eda8683c9bd7fc8347cfdaeea5ede58cba46df502c2a604.
+//
+// Solidity: event PauserChanged(address indexed newAddress)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParsePauserChanged(log types.Log) (*MessageTransmitterV2PauserChanged, error) {
+	event := new(MessageTransmitterV2PauserChanged)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "PauserChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2RescuerChangedIterator is returned from FilterRescuerChanged and is used to iterate over the raw logs and unpacked data for RescuerChanged events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2RescuerChangedIterator struct {
+	Event *MessageTransmitterV2RescuerChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2RescuerChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2RescuerChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2RescuerChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2RescuerChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2RescuerChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2RescuerChanged represents a RescuerChanged event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2RescuerChanged struct {
+	NewRescuer common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterRescuerChanged is a free log retrieval operation binding the contract event 0xe475e580d85111348e40d8ca33cfdd74c30fe1655c2d8537a13abc10065ffa5a.
+//
+// Solidity: event RescuerChanged(address indexed newRescuer)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterRescuerChanged(opts *bind.FilterOpts, newRescuer []common.Address) (*MessageTransmitterV2RescuerChangedIterator, error) {
+
+	var newRescuerRule []interface{}
+	for _, newRescuerItem := range newRescuer {
+		newRescuerRule = append(newRescuerRule, newRescuerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "RescuerChanged", newRescuerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2RescuerChangedIterator{contract: _MessageTransmitterV2.contract, event: "RescuerChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchRescuerChanged is a free log subscription operation binding the contract event 0xe475e580d85111348e40d8ca33cfdd74c30fe1655c2d8537a13abc10065ffa5a.
+//
+// Solidity: event RescuerChanged(address indexed newRescuer)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchRescuerChanged(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2RescuerChanged, newRescuer []common.Address) (event.Subscription, error) {
+
+	var newRescuerRule []interface{}
+	for _, newRescuerItem := range newRescuer {
+		newRescuerRule = append(newRescuerRule, newRescuerItem)
+	}
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "RescuerChanged", newRescuerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2RescuerChanged)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "RescuerChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRescuerChanged is a log parse operation binding the contract event 0xe475e580d85111348e40d8ca33cfdd74c30fe1655c2d8537a13abc10065ffa5a.
+//
+// Solidity: event RescuerChanged(address indexed newRescuer)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseRescuerChanged(log types.Log) (*MessageTransmitterV2RescuerChanged, error) {
+	event := new(MessageTransmitterV2RescuerChanged)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "RescuerChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2SignatureThresholdUpdatedIterator is returned from FilterSignatureThresholdUpdated and is used to iterate over the raw logs and unpacked data for SignatureThresholdUpdated events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2SignatureThresholdUpdatedIterator struct {
+	Event *MessageTransmitterV2SignatureThresholdUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2SignatureThresholdUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2SignatureThresholdUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2SignatureThresholdUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2SignatureThresholdUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2SignatureThresholdUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2SignatureThresholdUpdated represents a SignatureThresholdUpdated event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2SignatureThresholdUpdated struct {
+	OldSignatureThreshold *big.Int
+	NewSignatureThreshold *big.Int
+	Raw                   types.Log // Blockchain specific contextual infos
+}
+
+// FilterSignatureThresholdUpdated is a free log retrieval operation binding the contract event 0x149153f58b4da003a8cfd4523709a202402182cb5aa335046911277a1be6eede.
+//
+// Solidity: event SignatureThresholdUpdated(uint256 oldSignatureThreshold, uint256 newSignatureThreshold)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterSignatureThresholdUpdated(opts *bind.FilterOpts) (*MessageTransmitterV2SignatureThresholdUpdatedIterator, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "SignatureThresholdUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2SignatureThresholdUpdatedIterator{contract: _MessageTransmitterV2.contract, event: "SignatureThresholdUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchSignatureThresholdUpdated is a free log subscription operation binding the contract event 0x149153f58b4da003a8cfd4523709a202402182cb5aa335046911277a1be6eede.
+//
+// Solidity: event SignatureThresholdUpdated(uint256 oldSignatureThreshold, uint256 newSignatureThreshold)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchSignatureThresholdUpdated(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2SignatureThresholdUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "SignatureThresholdUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2SignatureThresholdUpdated)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "SignatureThresholdUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSignatureThresholdUpdated is a log parse operation binding the contract event 0x149153f58b4da003a8cfd4523709a202402182cb5aa335046911277a1be6eede.
+//
+// Solidity: event SignatureThresholdUpdated(uint256 oldSignatureThreshold, uint256 newSignatureThreshold)
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseSignatureThresholdUpdated(log types.Log) (*MessageTransmitterV2SignatureThresholdUpdated, error) {
+	event := new(MessageTransmitterV2SignatureThresholdUpdated)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "SignatureThresholdUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageTransmitterV2UnpauseIterator is returned from FilterUnpause and is used to iterate over the raw logs and unpacked data for Unpause events raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2UnpauseIterator struct {
+	Event *MessageTransmitterV2Unpause // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageTransmitterV2UnpauseIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageTransmitterV2Unpause)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageTransmitterV2Unpause)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageTransmitterV2UnpauseIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageTransmitterV2UnpauseIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageTransmitterV2Unpause represents a Unpause event raised by the MessageTransmitterV2 contract.
+type MessageTransmitterV2Unpause struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpause is a free log retrieval operation binding the contract event 0x7805862f689e2f13df9f062ff482ad3ad112aca9e0847911ed832e158c525b33.
+//
+// Solidity: event Unpause()
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) FilterUnpause(opts *bind.FilterOpts) (*MessageTransmitterV2UnpauseIterator, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.FilterLogs(opts, "Unpause")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageTransmitterV2UnpauseIterator{contract: _MessageTransmitterV2.contract, event: "Unpause", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpause is a free log subscription operation binding the contract event 0x7805862f689e2f13df9f062ff482ad3ad112aca9e0847911ed832e158c525b33.
+//
+// Solidity: event Unpause()
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) WatchUnpause(opts *bind.WatchOpts, sink chan<- *MessageTransmitterV2Unpause) (event.Subscription, error) {
+
+	logs, sub, err := _MessageTransmitterV2.contract.WatchLogs(opts, "Unpause")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageTransmitterV2Unpause)
+				if err := _MessageTransmitterV2.contract.UnpackLog(event, "Unpause", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpause is a log parse operation binding the contract event 0x7805862f689e2f13df9f062ff482ad3ad112aca9e0847911ed832e158c525b33.
+//
+// Solidity: event Unpause()
+func (_MessageTransmitterV2 *MessageTransmitterV2Filterer) ParseUnpause(log types.Log) (*MessageTransmitterV2Unpause, error) {
+	event := new(MessageTransmitterV2Unpause)
+	if err := _MessageTransmitterV2.contract.UnpackLog(event, "Unpause", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,677 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TokenMessengerV2MetaData contains all meta data concerning the TokenMessengerV2 contract.
+var TokenMessengerV2MetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"burnToken\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"depositor\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"mintRecipient\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"destinationTokenMessenger\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"destinationCaller\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"maxFee\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint32\",\"name\":\"minFinalityThreshold\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"hookData\",\"type\":\"bytes\"}],\"name\":\"DepositForBurn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"mintRecipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"mintToken\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"feeCollected\",\"type\":\"uint256\"}],\"name\":\"MintAndWithdraw\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"internalType\":\"bytes32\",\"name\":\"mintRecipient\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"burnToken\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"destinationCaller\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"maxFee\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"minFinalityThreshold\",\"type\":\"uint32\"}],\"name\":\"depositForBurn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"internalType\":\"bytes32\",\"name\":\"mintRecipient\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"burnToken\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"destinationCaller\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"maxFee\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"minFinalityThreshold\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"hookData\",\"type\":\"bytes\"}],\"name\":\"depositForBurnWithHook\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"localMessageTransmitter\",\"outputs\":[{\"internalType\":\"contractIMessageTransmitterV2\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"localMinter\",\"outputs\":[{\"internalType\":\"contractITokenMinterV2\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messageBodyVersion\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"name\":\"remoteTokenMessengers\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// TokenMessengerV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use TokenMessengerV2MetaData.ABI instead.
+var TokenMessengerV2ABI = TokenMessengerV2MetaData.ABI
+
+// TokenMessengerV2 is an auto generated Go binding around an Ethereum contract.
+type TokenMessengerV2 struct {
+	TokenMessengerV2Caller     // Read-only binding to the contract
+	TokenMessengerV2Transactor // Write-only binding to the contract
+	TokenMessengerV2Filterer   // Log filterer for contract events
+}
+
+// TokenMessengerV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type TokenMessengerV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenMessengerV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type TokenMessengerV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenMessengerV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TokenMessengerV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenMessengerV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TokenMessengerV2Session struct {
+	Contract     *TokenMessengerV2 // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TokenMessengerV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TokenMessengerV2CallerSession struct {
+	Contract *TokenMessengerV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// TokenMessengerV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TokenMessengerV2TransactorSession struct {
+	Contract     *TokenMessengerV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// TokenMessengerV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type TokenMessengerV2Raw struct {
+	Contract *TokenMessengerV2 // Generic contract binding to access the raw methods on
+}
+
+// TokenMessengerV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TokenMessengerV2CallerRaw struct {
+	Contract *TokenMessengerV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// TokenMessengerV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TokenMessengerV2TransactorRaw struct {
+	Contract *TokenMessengerV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTokenMessengerV2 creates a new instance of TokenMessengerV2, bound to a specific deployed contract.
+func NewTokenMessengerV2(address common.Address, backend bind.ContractBackend) (*TokenMessengerV2, error) {
+	contract, err := bindTokenMessengerV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerV2{TokenMessengerV2Caller: TokenMessengerV2Caller{contract: contract}, TokenMessengerV2Transactor: TokenMessengerV2Transactor{contract: contract}, TokenMessengerV2Filterer: TokenMessengerV2Filterer{contract: contract}}, nil
+}
+
+// NewTokenMessengerV2Caller creates a new read-only instance of TokenMessengerV2, bound to a specific deployed contract.
+func NewTokenMessengerV2Caller(address common.Address, caller bind.ContractCaller) (*TokenMessengerV2Caller, error) {
+	contract, err := bindTokenMessengerV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerV2Caller{contract: contract}, nil
+}
+
+// NewTokenMessengerV2Transactor creates a new write-only instance of TokenMessengerV2, bound to a specific deployed contract.
+func NewTokenMessengerV2Transactor(address common.Address, transactor bind.ContractTransactor) (*TokenMessengerV2Transactor, error) {
+	contract, err := bindTokenMessengerV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerV2Transactor{contract: contract}, nil
+}
+
+// NewTokenMessengerV2Filterer creates a new log filterer instance of TokenMessengerV2, bound to a specific deployed contract.
+func NewTokenMessengerV2Filterer(address common.Address, filterer bind.ContractFilterer) (*TokenMessengerV2Filterer, error) {
+	contract, err := bindTokenMessengerV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerV2Filterer{contract: contract}, nil
+}
+
+// bindTokenMessengerV2 binds a generic wrapper to an already deployed contract.
+func bindTokenMessengerV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TokenMessengerV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenMessengerV2 *TokenMessengerV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenMessengerV2.Contract.TokenMessengerV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenMessengerV2 *TokenMessengerV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.TokenMessengerV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenMessengerV2 *TokenMessengerV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.TokenMessengerV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenMessengerV2 *TokenMessengerV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenMessengerV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenMessengerV2 *TokenMessengerV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenMessengerV2 *TokenMessengerV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// LocalMessageTransmitter is a free data retrieval call binding the contract method 0x2c121921.
+//
+// Solidity: function localMessageTransmitter() view returns(address)
+func (_TokenMessengerV2 *TokenMessengerV2Caller) LocalMessageTransmitter(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenMessengerV2.contract.Call(opts, &out, "localMessageTransmitter")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LocalMessageTransmitter is a free data retrieval call binding the contract method 0x2c121921.
+//
+// Solidity: function localMessageTransmitter() view returns(address)
+func (_TokenMessengerV2 *TokenMessengerV2Session) LocalMessageTransmitter() (common.Address, error) {
+	return _TokenMessengerV2.Contract.LocalMessageTransmitter(&_TokenMessengerV2.CallOpts)
+}
+
+// LocalMessageTransmitter is a free data retrieval call binding the contract method 0x2c121921.
+//
+// Solidity: function localMessageTransmitter() view returns(address)
+func (_TokenMessengerV2 *TokenMessengerV2CallerSession) LocalMessageTransmitter() (common.Address, error) {
+	return _TokenMessengerV2.Contract.LocalMessageTransmitter(&_TokenMessengerV2.CallOpts)
+}
+
+// LocalMinter is a free data retrieval call binding the contract method 0xcb75c11c.
+//
+// Solidity: function localMinter() view returns(address)
+func (_TokenMessengerV2 *TokenMessengerV2Caller) LocalMinter(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenMessengerV2.contract.Call(opts, &out, "localMinter")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LocalMinter is a free data retrieval call binding the contract method 0xcb75c11c.
+//
+// Solidity: function localMinter() view returns(address)
+func (_TokenMessengerV2 *TokenMessengerV2Session) LocalMinter() (common.Address, error) {
+	return _TokenMessengerV2.Contract.LocalMinter(&_TokenMessengerV2.CallOpts)
+}
+
+// LocalMinter is a free data retrieval call binding the contract method 0xcb75c11c.
+//
+// Solidity: function localMinter() view returns(address)
+func (_TokenMessengerV2 *TokenMessengerV2CallerSession) LocalMinter() (common.Address, error) {
+	return _TokenMessengerV2.Contract.LocalMinter(&_TokenMessengerV2.CallOpts)
+}
+
+// MessageBodyVersion is a free data retrieval call binding the contract method 0x9cdbb181.
+//
+// Solidity: function messageBodyVersion() view returns(uint32)
+func (_TokenMessengerV2 *TokenMessengerV2Caller) MessageBodyVersion(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _TokenMessengerV2.contract.Call(opts, &out, "messageBodyVersion")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// MessageBodyVersion is a free data retrieval call binding the contract method 0x9cdbb181.
+//
+// Solidity: function messageBodyVersion() view returns(uint32)
+func (_TokenMessengerV2 *TokenMessengerV2Session) MessageBodyVersion() (uint32, error) {
+	return _TokenMessengerV2.Contract.MessageBodyVersion(&_TokenMessengerV2.CallOpts)
+}
+
+// MessageBodyVersion is a free data retrieval call binding the contract method 0x9cdbb181.
+//
+// Solidity: function messageBodyVersion() view returns(uint32)
+func (_TokenMessengerV2 *TokenMessengerV2CallerSession) MessageBodyVersion() (uint32, error) {
+	return _TokenMessengerV2.Contract.MessageBodyVersion(&_TokenMessengerV2.CallOpts)
+}
+
+// RemoteTokenMessengers is a free data retrieval call binding the contract method 0x82a5e665.
+//
+// Solidity: function remoteTokenMessengers(uint32 ) view returns(bytes32)
+func (_TokenMessengerV2 *TokenMessengerV2Caller) RemoteTokenMessengers(opts *bind.CallOpts, arg0 uint32) ([32]byte, error) {
+	var out []interface{}
+	err := _TokenMessengerV2.contract.Call(opts, &out, "remoteTokenMessengers", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// RemoteTokenMessengers is a free data retrieval call binding the contract method 0x82a5e665.
+//
+// Solidity: function remoteTokenMessengers(uint32 ) view returns(bytes32)
+func (_TokenMessengerV2 *TokenMessengerV2Session) RemoteTokenMessengers(arg0 uint32) ([32]byte, error) {
+	return _TokenMessengerV2.Contract.RemoteTokenMessengers(&_TokenMessengerV2.CallOpts, arg0)
+}
+
+// RemoteTokenMessengers is a free data retrieval call binding the contract method 0x82a5e665.
+//
+// Solidity: function remoteTokenMessengers(uint32 ) view returns(bytes32)
+func (_TokenMessengerV2 *TokenMessengerV2CallerSession) RemoteTokenMessengers(arg0 uint32) ([32]byte, error) {
+	return _TokenMessengerV2.Contract.RemoteTokenMessengers(&_TokenMessengerV2.CallOpts, arg0)
+}
+
+// DepositForBurn is a paid mutator transaction binding the contract method 0x8e0250ee.
+//
+// Solidity: function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller, uint256 maxFee, uint32 minFinalityThreshold) returns()
+func (_TokenMessengerV2 *TokenMessengerV2Transactor) DepositForBurn(opts *bind.TransactOpts, amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte, maxFee *big.Int, minFinalityThreshold uint32) (*types.Transaction, error) {
+	return _TokenMessengerV2.contract.Transact(opts, "depositForBurn", amount, destinationDomain, mintRecipient, burnToken, destinationCaller, maxFee, minFinalityThreshold)
+}
+
+// DepositForBurn is a paid mutator transaction binding the contract method 0x8e0250ee.
+//
+// Solidity: function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller, uint256 maxFee, uint32 minFinalityThreshold) returns()
+func (_TokenMessengerV2 *TokenMessengerV2Session) DepositForBurn(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte, maxFee *big.Int, minFinalityThreshold uint32) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.DepositForBurn(&_TokenMessengerV2.TransactOpts, amount, destinationDomain, mintRecipient, burnToken, destinationCaller, maxFee, minFinalityThreshold)
+}
+
+// DepositForBurn is a paid mutator transaction binding the contract method 0x8e0250ee.
+//
+// Solidity: function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller, uint256 maxFee, uint32 minFinalityThreshold) returns()
+func (_TokenMessengerV2 *TokenMessengerV2TransactorSession) DepositForBurn(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte, maxFee *big.Int, minFinalityThreshold uint32) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.DepositForBurn(&_TokenMessengerV2.TransactOpts, amount, destinationDomain, mintRecipient, burnToken, destinationCaller, maxFee, minFinalityThreshold)
+}
+
+// DepositForBurnWithHook is a paid mutator transaction binding the contract method 0x779b432d.
+//
+// Solidity: function depositForBurnWithHook(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller, uint256 maxFee, uint32 minFinalityThreshold, bytes hookData) returns()
+func (_TokenMessengerV2 *TokenMessengerV2Transactor) DepositForBurnWithHook(opts *bind.TransactOpts, amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte, maxFee *big.Int, minFinalityThreshold uint32, hookData []byte) (*types.Transaction, error) {
+	return _TokenMessengerV2.contract.Transact(opts, "depositForBurnWithHook", amount, destinationDomain, mintRecipient, burnToken, destinationCaller, maxFee, minFinalityThreshold, hookData)
+}
+
+// DepositForBurnWithHook is a paid mutator transaction binding the contract method 0x779b432d.
+//
+// Solidity: function depositForBurnWithHook(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller, uint256 maxFee, uint32 minFinalityThreshold, bytes hookData) returns()
+func (_TokenMessengerV2 *TokenMessengerV2Session) DepositForBurnWithHook(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte, maxFee *big.Int, minFinalityThreshold uint32, hookData []byte) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.DepositForBurnWithHook(&_TokenMessengerV2.TransactOpts, amount, destinationDomain, mintRecipient, burnToken, destinationCaller, maxFee, minFinalityThreshold, hookData)
+}
+
+// DepositForBurnWithHook is a paid mutator transaction binding the contract method 0x779b432d.
+//
+// Solidity: function depositForBurnWithHook(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller, uint256 maxFee, uint32 minFinalityThreshold, bytes hookData) returns()
+func (_TokenMessengerV2 *TokenMessengerV2TransactorSession) DepositForBurnWithHook(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte, maxFee *big.Int, minFinalityThreshold uint32, hookData []byte) (*types.Transaction, error) {
+	return _TokenMessengerV2.Contract.DepositForBurnWithHook(&_TokenMessengerV2.TransactOpts, amount, destinationDomain, mintRecipient, burnToken, destinationCaller, maxFee, minFinalityThreshold, hookData)
+}
+
+// TokenMessengerV2DepositForBurnIterator is returned from FilterDepositForBurn and is used to iterate over the raw logs and unpacked data for DepositForBurn events raised by the TokenMessengerV2 contract.
+type TokenMessengerV2DepositForBurnIterator struct {
+	Event *TokenMessengerV2DepositForBurn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenMessengerV2DepositForBurnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenMessengerV2DepositForBurn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenMessengerV2DepositForBurn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenMessengerV2DepositForBurnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenMessengerV2DepositForBurnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenMessengerV2DepositForBurn represents a DepositForBurn event raised by the TokenMessengerV2 contract.
+type TokenMessengerV2DepositForBurn struct {
+	BurnToken                 common.Address
+	Amount                    *big.Int
+	Depositor                 common.Address
+	MintRecipient             [32]byte
+	DestinationDomain         uint32
+	DestinationTokenMessenger [32]byte
+	DestinationCaller         [32]byte
+	MaxFee                    *big.Int
+	MinFinalityThreshold      uint32
+	HookData                  []byte
+	Raw                       types.Log // Blockchain specific contextual infos
+}
+
+// FilterDepositForBurn is a free log retrieval operation binding the contract event 0x0c8c1cbdc5190613ebd485511d4e2812cfa45eecb79d845893331fedad5130a5.
+//
+// Solidity: event DepositForBurn(address indexed burnToken, uint256 amount, address indexed depositor, bytes32 mintRecipient, uint32 destinationDomain, bytes32 destinationTokenMessenger, bytes32 destinationCaller, uint256 maxFee, uint32 indexed minFinalityThreshold, bytes hookData)
+func (_TokenMessengerV2 *TokenMessengerV2Filterer) FilterDepositForBurn(opts *bind.FilterOpts, burnToken []common.Address, depositor []common.Address, minFinalityThreshold []uint32) (*TokenMessengerV2DepositForBurnIterator, error) {
+
+	var burnTokenRule []interface{}
+	for _, burnTokenItem := range burnToken {
+		burnTokenRule = append(burnTokenRule, burnTokenItem)
+	}
+
+	var depositorRule []interface{}
+	for _, depositorItem := range depositor {
+		depositorRule = append(depositorRule, depositorItem)
+	}
+
+	var minFinalityThresholdRule []interface{}
+	for _, minFinalityThresholdItem := range minFinalityThreshold {
+		minFinalityThresholdRule = append(minFinalityThresholdRule, minFinalityThresholdItem)
+	}
+
+	logs, sub, err := _TokenMessengerV2.contract.FilterLogs(opts, "DepositForBurn", burnTokenRule, depositorRule, minFinalityThresholdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerV2DepositForBurnIterator{contract: _TokenMessengerV2.contract, event: "DepositForBurn", logs: logs, sub: sub}, nil
+}
+
+// WatchDepositForBurn is a free log subscription operation binding the contract event 0x0c8c1cbdc5190613ebd485511d4e2812cfa45eecb79d845893331fedad5130a5.
+//
+// Solidity: event DepositForBurn(address indexed burnToken, uint256 amount, address indexed depositor, bytes32 mintRecipient, uint32 destinationDomain, bytes32 destinationTokenMessenger, bytes32 destinationCaller, uint256 maxFee, uint32 indexed minFinalityThreshold, bytes hookData)
+func (_TokenMessengerV2 *TokenMessengerV2Filterer) WatchDepositForBurn(opts *bind.WatchOpts, sink chan<- *TokenMessengerV2DepositForBurn, burnToken []common.Address, depositor []common.Address, minFinalityThreshold []uint32) (event.Subscription, error) {
+
+	var burnTokenRule []interface{}
+	for _, burnTokenItem := range burnToken {
+		burnTokenRule = append(burnTokenRule, burnTokenItem)
+	}
+
+	var depositorRule []interface{}
+	for _, depositorItem := range depositor {
+		depositorRule = append(depositorRule, depositorItem)
+	}
+
+	var minFinalityThresholdRule []interface{}
+	for _, minFinalityThresholdItem := range minFinalityThreshold {
+		minFinalityThresholdRule = append(minFinalityThresholdRule, minFinalityThresholdItem)
+	}
+
+	logs, sub, err := _TokenMessengerV2.contract.WatchLogs(opts, "DepositForBurn", burnTokenRule, depositorRule, minFinalityThresholdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenMessengerV2DepositForBurn)
+				if err := _TokenMessengerV2.contract.UnpackLog(event, "DepositForBurn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDepositForBurn is a log parse operation binding the contract event 0x0c8c1cbdc5190613ebd485511d4e2812cfa45eecb79d845893331fedad5130a5.
+//
+// Solidity: event DepositForBurn(address indexed burnToken, uint256 amount, address indexed depositor, bytes32 mintRecipient, uint32 destinationDomain, bytes32 destinationTokenMessenger, bytes32 destinationCaller, uint256 maxFee, uint32 indexed minFinalityThreshold, bytes hookData)
+func (_TokenMessengerV2 *TokenMessengerV2Filterer) ParseDepositForBurn(log types.Log) (*TokenMessengerV2DepositForBurn, error) {
+	event := new(TokenMessengerV2DepositForBurn)
+	if err := _TokenMessengerV2.contract.UnpackLog(event, "DepositForBurn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenMessengerV2MintAndWithdrawIterator is returned from FilterMintAndWithdraw and is used to iterate over the raw logs and unpacked data for MintAndWithdraw events raised by the TokenMessengerV2 contract.
+type TokenMessengerV2MintAndWithdrawIterator struct {
+	Event *TokenMessengerV2MintAndWithdraw // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenMessengerV2MintAndWithdrawIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenMessengerV2MintAndWithdraw)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenMessengerV2MintAndWithdraw)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenMessengerV2MintAndWithdrawIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenMessengerV2MintAndWithdrawIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenMessengerV2MintAndWithdraw represents a MintAndWithdraw event raised by the TokenMessengerV2 contract.
+type TokenMessengerV2MintAndWithdraw struct {
+	MintRecipient common.Address
+	Amount        *big.Int
+	MintToken     common.Address
+	FeeCollected  *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterMintAndWithdraw is a free log retrieval operation binding the contract event 0x50c55e915134d457debfa58eb6f4342956f8b0616d51a89a3659360178e1ab63.
+//
+// Solidity: event MintAndWithdraw(address indexed mintRecipient, uint256 amount, address indexed mintToken, uint256 feeCollected)
+func (_TokenMessengerV2 *TokenMessengerV2Filterer) FilterMintAndWithdraw(opts *bind.FilterOpts, mintRecipient []common.Address, mintToken []common.Address) (*TokenMessengerV2MintAndWithdrawIterator, error) {
+
+	var mintRecipientRule []interface{}
+	for _, mintRecipientItem := range mintRecipient {
+		mintRecipientRule = append(mintRecipientRule, mintRecipientItem)
+	}
+
+	var mintTokenRule []interface{}
+	for _, mintTokenItem := range mintToken {
+		mintTokenRule = append(mintTokenRule, mintTokenItem)
+	}
+
+	logs, sub, err := _TokenMessengerV2.contract.FilterLogs(opts, "MintAndWithdraw", mintRecipientRule, mintTokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerV2MintAndWithdrawIterator{contract: _TokenMessengerV2.contract, event: "MintAndWithdraw", logs: logs, sub: sub}, nil
+}
+
+// WatchMintAndWithdraw is a free log subscription operation binding the contract event 0x50c55e915134d457debfa58eb6f4342956f8b0616d51a89a3659360178e1ab63.
+//
+// Solidity: event MintAndWithdraw(address indexed mintRecipient, uint256 amount, address indexed mintToken, uint256 feeCollected)
+func (_TokenMessengerV2 *TokenMessengerV2Filterer) WatchMintAndWithdraw(opts *bind.WatchOpts, sink chan<- *TokenMessengerV2MintAndWithdraw, mintRecipient []common.Address, mintToken []common.Address) (event.Subscription, error) {
+
+	var mintRecipientRule []interface{}
+	for _, mintRecipientItem := range mintRecipient {
+		mintRecipientRule = append(mintRecipientRule, mintRecipientItem)
+	}
+
+	var mintTokenRule []interface{}
+	for _, mintTokenItem := range mintToken {
+		mintTokenRule = append(mintTokenRule, mintTokenItem)
+	}
+
+	logs, sub, err := _TokenMessengerV2.contract.WatchLogs(opts, "MintAndWithdraw", mintRecipientRule, mintTokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenMessengerV2MintAndWithdraw)
+				if err := _TokenMessengerV2.contract.UnpackLog(event, "MintAndWithdraw", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMintAndWithdraw is a log parse operation binding the contract event 0x50c55e915134d457debfa58eb6f4342956f8b0616d51a89a3659360178e1ab63.
+//
+// Solidity: event MintAndWithdraw(address indexed mintRecipient, uint256 amount, address indexed mintToken, uint256 feeCollected)
+func (_TokenMessengerV2 *TokenMessengerV2Filterer) ParseMintAndWithdraw(log types.Log) (*TokenMessengerV2MintAndWithdraw, error) {
+	event := new(TokenMessengerV2MintAndWithdraw)
+	if err := _TokenMessengerV2.contract.UnpackLog(event, "MintAndWithdraw", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,75 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum/contracts"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// EstimateMintCost estimates the gas of receiving msg with eth_estimateGas, at the rpc's suggested gas price
+func (e *Ethereum) EstimateMintCost(ctx context.Context, msg *types.MessageState) (types.MintCost, error) {
+	call, err := e.receiveMessageCall(msg)
+	if err != nil {
+		return types.MintCost{}, err
+	}
+
+	client := e.rpcClient()
+	gas, err := client.EstimateGas(ctx, call)
+	e.rpcEndpoints.Report(client, err)
+	if err != nil {
+		return types.MintCost{}, fmt.Errorf("unable to estimate receiveMessage gas: %w", decodeRevert(err))
+	}
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	e.rpcEndpoints.Report(client, err)
+	if err != nil {
+		return types.MintCost{}, fmt.Errorf("unable to query gas price: %w", err)
+	}
+
+	return types.MintCost{
+		Fee:      new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice),
+		Denom:    e.MetricsDenom,
+		Exponent: e.MetricsExponent,
+	}, nil
+}
+
+// receiveMessageCall returns the call of receiveMessage for msg on the MessageTransmitter of its CCTP version
+func (e *Ethereum) receiveMessageCall(msg *types.MessageState) (ethereum.CallMsg, error) {
+	if len(msg.Attestation) < 2 {
+		return ethereum.CallMsg{}, fmt.Errorf("message is not attested")
+	}
+	attestation, err := hex.DecodeString(msg.Attestation[2:])
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("unable to decode message attestation: %w", err)
+	}
+
+	address, metadata := e.messageTransmitterAddress, contracts.MessageTransmitterMetaData
+	if msg.IsV2() {
+		if e.messageTransmitterV2Address == "" {
+			return ethereum.CallMsg{}, fmt.Errorf("no v2 message transmitter configured for %s", e.name)
+		}
+		address, metadata = e.messageTransmitterV2Address, contracts.MessageTransmitterV2MetaData
+	}
+
+	transmitterABI, err := metadata.GetAbi()
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("unable to parse message transmitter abi: %w", err)
+	}
+	data, err := transmitterABI.Pack("receiveMessage", msg.MsgSentBytes, attestation)
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("unable to encode receiveMessage: %w", err)
+	}
+
+	to := common.HexToAddress(address)
+	return ethereum.CallMsg{
+		From: common.HexToAddress(e.minterAddress),
+		To:   &to,
+		Data: data,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum/contracts"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// fakeGasService stands in for an rpc estimating receiveMessage gas, recording the estimated call
+type fakeGasService struct {
+	call map[string]any
+}
+
+func (s *fakeGasService) EstimateGas(call map[string]any) hexutil.Uint64 {
+	s.call = call
+	return 100_000
+}
+
+func (s *fakeGasService) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(20_000_000_000))
+}
+
+func (s *fakeGasService) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+func TestEstimateMintCost(t *testing.T) {
+	service := &fakeGasService{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	e, err := NewChain("ethereum", &ChainConfig{
+		RPC:                    httpServer.URL,
+		ChainID:                1,
+		MessageTransmitter:     testTransmitter.Hex(),
+		Confirmations:          1,
+		Polling:                true,
+		PollInterval:           1,
+		PollRange:              2,
+		BroadcastRetries:       1,
+		BroadcastRetryInterval: 1,
+		MinMintAmount:          1,
+		MetricsDenom:           "ETH",
+		MetricsExponent:        18,
+		MinterPrivateKey:       hex.EncodeToString(crypto.FromECDSA(key)),
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.InitializeClients(context.Background(), log.NewNopLogger()))
+	t.Cleanup(func() { _ = e.CloseClients() })
+
+	msg := &types.MessageState{MsgSentBytes: []byte{0x1, 0x2}, Attestation: "0x0304"}
+	cost, err := e.EstimateMintCost(context.Background(), msg)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100_000*20_000_000_000), cost.Fee)
+	require.Equal(t, "ETH", cost.Denom)
+	require.InDelta(t, 0.002, cost.Amount(), 1e-12)
+
+	// the receiveMessage call is estimated from the minter
+	transmitterABI, err := contracts.MessageTransmitterMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := transmitterABI.Pack("receiveMessage", []byte{0x1, 0x2}, []byte{0x3, 0x4})
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Encode(data), service.call["input"])
+	require.Equal(t, strings.ToLower(testTransmitter.Hex()), service.call["to"])
+	require.Equal(t, strings.ToLower(e.minterAddress), service.call["from"])
+
+	// V2 messages need a V2 message transmitter
+	_, err = e.EstimateMintCost(context.Background(), &types.MessageState{Version: types.MessageVersionV2, Attestation: "0x00"})
+	require.ErrorContains(t, err, "no v2 message transmitter")
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
-	"os"
+	"strings"
 	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -20,6 +20,13 @@ import (
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
+const (
+	// failed log queries are retried with exponential backoff, starting at 1 second
+	maxLogQueryBackoff = 30 * time.Second
+	// historyProgressInterval is how often the progress of long history queries is logged
+	historyProgressInterval = 30 * time.Second
+)
+
 // errSignal allows broadcasting an error value to multiple receivers.
 type errSignal struct {
 	Ready chan struct{}
@@ -28,75 +35,163 @@ type errSignal struct {
 // StartListener starts the ethereum websocket subscription, queries history pertaining to the lookback period,
 // and starts the reoccurring flush
 //
-// If an error occurs in websocket stream, this function will handle relevant sub routines and then re-run itself.
+// If the websocket stream fails, the relevant sub routines are stopped and an error is returned so the listener is
+// restarted from the last flushed block.
 func (e *Ethereum) StartListener(
 	ctx context.Context,
 	logger log.Logger,
 	processingQueue chan *types.TxState,
 	flushOnlyMode bool,
 	flushInterval time.Duration,
-) {
+	m *relayer.PromMetrics,
+) error {
 	logger = logger.With("chain", e.name, "chain_id", e.chainID, "domain", e.domain)
 
 	messageTransmitter, err := content.ReadFile("abi/MessageTransmitter.json")
 	if err != nil {
-		logger.Error("Unable to read MessageTransmitter abi", "err", err)
-		os.Exit(1)
+		return fmt.Errorf("unable to read MessageTransmitter abi: %w", err)
 	}
 	messageTransmitterABI, err := abi.JSON(bytes.NewReader(messageTransmitter))
 	if err != nil {
-		logger.Error("Unable to parse MessageTransmitter abi", "err", err)
-		os.Exit(1)
+		return fmt.Errorf("unable to parse MessageTransmitter abi: %w", err)
 	}
 
 	messageSent := messageTransmitterABI.Events["MessageSent"]
-	messageTransmitterAddress := common.HexToAddress(e.messageTransmitterAddress)
+	messageTransmitterAddresses := e.messageTransmitterAddresses()
 
 	sig := &errSignal{
 		Ready: make(chan struct{}),
 	}
 
+	// pending txs are streamed over the websocket while the listener runs
+	if e.mempool != nil && e.wsEndpoints != nil {
+		go e.watchMempool(ctx, logger)
+	}
+
 	// FlushOnlyMode is used for the secondary, flush only relayer. When enabled, the main stream is not started.
 	if flushOnlyMode {
-		go e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, flushOnlyMode, flushInterval, sig)
-	} else {
-		// start main stream (does not account for lookback period or specific start block)
-		stream, sub, history := e.startMainStream(ctx, logger, messageSent, messageTransmitterAddress)
+		e.setListenerConnected(true)
+		defer e.setListenerConnected(false)
+		e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, flushOnlyMode, flushInterval, sig)
+		return nil
+	}
 
-		go e.consumeStream(ctx, logger, processingQueue, messageSent, messageTransmitterABI, stream, sig)
-		consumeHistory(logger, history, processingQueue, messageSent, messageTransmitterABI)
+	if e.isPolling() {
+		e.setListenerConnected(true)
+		defer e.setListenerConnected(false)
+		e.startPolling(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, flushInterval, sig)
+		return nil
+	}
 
-		// get history from (start block - lookback) up until latest block
-		latestBlock := e.LatestBlock()
-		start := latestBlock
-		if e.startBlock != 0 {
-			start = e.startBlock
+	// start main stream (does not account for lookback period or specific start block)
+	stream, sub, history, err := e.startMainStream(ctx, logger, messageSent, messageTransmitterAddresses)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
 		}
-		startLookback := start - e.lookbackPeriod
+		e.setPolling()
+		return fmt.Errorf("websocket failed to reconnect after %d attempts, falling back to polling: %w", e.wsReconnectAttempts, err)
+	}
+	e.setListenerConnected(true)
+	defer e.setListenerConnected(false)
+
+	// the initial stream history is not yet confirmed, so it goes through the confirmation queue
+	for _, historicalLog := range history {
+		e.queueStreamLog(logger, processingQueue, messageSent, messageTransmitterABI, historicalLog, m)
+	}
+	go e.consumeStream(ctx, logger, processingQueue, messageSent, messageTransmitterABI, stream, sig, m)
+
+	// get history from (start block - lookback) up until the confirmed block the main stream starts at
+	latestBlock := e.confirmedBlock()
+	e.getAndConsumeLookback(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, latestBlock)
+
+	go e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, flushOnlyMode, flushInterval, sig)
+
+	// listen for errors in the main websocket stream
+	// if error occurs, trigger sig.Ready
+	// This will cancel `consumeStream` and `flushMechanism` routines
+	select {
+	case <-ctx.Done():
+		sub.Unsubscribe()
+		return nil
+	case err := <-sub.Err():
+		close(sig.Ready)
+
+		// restart from the last flushed block
+		e.startBlock = e.lastFlushedBlock
+		return fmt.Errorf("websocket %s disconnected: %w", e.wsEndpoints.Active(), err)
+	}
+}
 
-		logger.Info(fmt.Sprintf("Getting history from %d: starting at: %d looking back %d blocks", startLookback, start, e.lookbackPeriod))
-		e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, startLookback, latestBlock)
-		logger.Info("Finished getting history")
+// getAndConsumeLookback gets history from (start block - lookback) up until the end block
+func (e *Ethereum) getAndConsumeLookback(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	messageSent abi.Event,
+	messageTransmitterAddresses []common.Address,
+	messageTransmitterABI abi.ABI,
+	end uint64,
+) {
+	start := end
+	if e.startBlock != 0 {
+		start = e.startBlock
+	}
+	startLookback := start - e.lookbackPeriod
 
-		if flushInterval > 0 {
-			go e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, flushOnlyMode, flushInterval, sig)
-		}
+	logger.Info(fmt.Sprintf("Getting history from %d: starting at: %d looking back %d blocks", startLookback, start, e.lookbackPeriod))
+	if err := e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, startLookback, end); err != nil {
+		logger.Error("Unable to get history", "err", err)
+		return
+	}
+	logger.Info("Finished getting history")
+}
+
+// startPolling queries new logs with eth_getLogs every poll interval, for chains without a (working) websocket.
+// Only blocks with enough confirmations are queried, so polled messages are passed straight to the processingQueue.
+func (e *Ethereum) startPolling(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	messageSent abi.Event,
+	messageTransmitterAddresses []common.Address,
+	messageTransmitterABI abi.ABI,
+	flushInterval time.Duration,
+	sig *errSignal,
+) {
+	logger.Info(fmt.Sprintf("Starting Ethereum listener. Polling every %v in ranges starting at %d blocks", e.pollInterval, e.pollRange))
+
+	latestBlock := e.confirmedBlock()
+	e.getAndConsumeLookback(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, latestBlock)
 
-		// listen for errors in the main websocket stream
-		// if error occurs, trigger sig.Ready
-		// This will cancel `consumeStream` and `flushMechanism` routines
+	go e.flushMechanism(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, false, flushInterval, sig)
+
+	nextBlock := latestBlock + 1
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	for {
 		select {
 		case <-ctx.Done():
 			return
-		case err := <-sub.Err():
-			logger.Error("Websocket disconnected. Reconnecting...", "err", err)
-			close(sig.Ready)
-
-			// restart
-			e.startBlock = e.lastFlushedBlock
-			time.Sleep(10 * time.Millisecond)
-			e.StartListener(ctx, logger, processingQueue, flushOnlyMode, flushInterval)
-			return
+		case <-ticker.C:
+			head, err := e.blockNumber(ctx)
+			if err != nil {
+				logger.Error("Unable to query latest height for polling", "err", err)
+				continue
+			}
+			if head < e.confirmations || head-e.confirmations < nextBlock {
+				continue
+			}
+			endBlock := head - e.confirmations
+
+			logger.Debug(fmt.Sprintf("Polling logs from %d to %d", nextBlock, endBlock))
+			// a failed poll is retried from the same block on the next tick
+			if err := e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, nextBlock, endBlock); err != nil {
+				logger.Error("Unable to poll logs", "err", err)
+				continue
+			}
+			nextBlock = endBlock + 1
 		}
 	}
 }
@@ -105,19 +200,15 @@ func (e *Ethereum) startMainStream(
 	ctx context.Context,
 	logger log.Logger,
 	messageSent abi.Event,
-	messageTransmitterAddress common.Address,
-) (stream <-chan ethtypes.Log, sub ethereum.Subscription, history []ethtypes.Log) {
-	var err error
-
-	etherReader := etherstream.Reader{Backend: e.wsClient}
-
-	latestBlock := e.LatestBlock()
+	messageTransmitterAddresses []common.Address,
+) (stream <-chan ethtypes.Log, sub ethereum.Subscription, history []ethtypes.Log, err error) {
+	latestBlock := e.confirmedBlock()
 
 	// start initial stream (start-block and lookback period handled separately)
 	logger.Info("Starting Ethereum listener")
 
 	query := ethereum.FilterQuery{
-		Addresses: []common.Address{messageTransmitterAddress},
+		Addresses: messageTransmitterAddresses,
 		Topics:    [][]common.Hash{{messageSent.ID}},
 		FromBlock: big.NewInt(int64(latestBlock)),
 	}
@@ -126,80 +217,171 @@ func (e *Ethereum) startMainStream(
 	for {
 		// websockets do not query history
 		// https://github.com/ethereum/go-ethereum/issues/15063
+		// the healthiest websocket can change between attempts
+		client := e.wsClient()
+		etherReader := etherstream.Reader{Backend: client}
 		stream, sub, history, err = etherReader.QueryWithHistory(ctx, &query)
+		e.wsEndpoints.Report(client, err)
 		if err != nil {
 			logger.Error("Unable to subscribe to logs", "attempt", queryAttempt, "err", err)
+			if e.wsReconnectAttempts > 0 && queryAttempt >= e.wsReconnectAttempts {
+				return nil, nil, nil, err
+			}
 			queryAttempt++
-			time.Sleep(1 * time.Second)
+			select {
+			case <-time.After(1 * time.Second):
+			case <-ctx.Done():
+				return nil, nil, nil, ctx.Err()
+			}
 			continue
 		}
 		break
 	}
 
-	return stream, sub, history
+	return stream, sub, history, nil
 }
 
+// getAndConsumeHistory queries the logs from start up to and including end and passes them to the processingQueue.
+// The number of blocks per query adapts to the endpoints: it grows while queries succeed and halves when a query is
+// rejected as too large. Other errors are retried with exponential backoff, up to logQueryRetries times.
 func (e *Ethereum) getAndConsumeHistory(
 	ctx context.Context,
 	logger log.Logger,
 	processingQueue chan *types.TxState,
 	messageSent abi.Event,
-	messageTransmitterAddress common.Address,
+	messageTransmitterAddresses []common.Address,
 	messageTransmitterABI abi.ABI,
-	start, end uint64) {
-	var toUnSub ethereum.Subscription
-	var history []ethtypes.Log
-	var err error
-
+	start, end uint64) error {
 	if start > end {
 		logger.Error(fmt.Sprintf("Unable to get history from %d to %d where the start block is greater than the end block", start, end))
-		return
+		return nil
 	}
 
-	// handle historical queries in chunks (some websockets only allow small history queries)
-	const chunkSize = uint64(100)
-	chunk := 1
-	totalChunksNeeded := (end - start + chunkSize - 1) / chunkSize
+	progress := newHistoryProgress(start, end)
 
-	for start < end {
+	// attempt counts the failed queries of a range that can not shrink
+	attempt := 0
+	for start <= end {
 		fromBlock := start
-		toBlock := start + chunkSize
-		if toBlock > end {
-			toBlock = end
-		}
-
-		logger.Debug(fmt.Sprintf("Looking back in chunks of %d: chunk: %d/%d start-block: %d end-block: %d", chunkSize, chunk, totalChunksNeeded, fromBlock, toBlock))
+		toBlock := min(start+e.logRange.Get()-1, end)
 
-		etherReader := etherstream.Reader{Backend: e.wsClient}
+		logger.Debug(fmt.Sprintf("Looking back in chunks of %d: start-block: %d end-block: %d", toBlock-fromBlock+1, fromBlock, toBlock))
 
 		query := ethereum.FilterQuery{
-			Addresses: []common.Address{messageTransmitterAddress},
+			Addresses: messageTransmitterAddresses,
 			Topics:    [][]common.Hash{{messageSent.ID}},
-			FromBlock: big.NewInt(int64(fromBlock)),
-			ToBlock:   big.NewInt(int64(toBlock)),
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
 		}
-		queryAttempt := 1
-		for {
-			_, toUnSub, history, err = etherReader.QueryWithHistory(ctx, &query)
-			if err != nil {
-				// TODO: add metrics for this log
-				logger.Error(fmt.Sprintf("Unable to query history from %d to %d. attempt: %d", start, end, queryAttempt), "err", err)
-				queryAttempt++
-				time.Sleep(1 * time.Second)
+		history, err := e.queryLogs(ctx, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if isRangeError(err) && e.logRange.Shrink() {
+				logger.Debug(fmt.Sprintf("Range of history from %d to %d rejected. Retrying with ranges of %d blocks", fromBlock, toBlock, e.logRange.Get()), "err", err)
 				continue
 			}
-			break
+
+			attempt++
+			if attempt > e.logQueryRetries {
+				return fmt.Errorf("unable to query history from %d to %d after %d attempts: %w", fromBlock, toBlock, attempt, err)
+			}
+
+			backoff := min(time.Second<<(attempt-1), maxLogQueryBackoff)
+			logger.Error(fmt.Sprintf("Unable to query history from %d to %d. Retrying in %v (attempt %d)", fromBlock, toBlock, backoff, attempt), "err", err)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+			continue
 		}
-		toUnSub.Unsubscribe()
+		attempt = 0
+
 		consumeHistory(logger, history, processingQueue, messageSent, messageTransmitterABI)
 
-		start += chunkSize
-		chunk++
+		start = toBlock + 1
+		e.logRange.Grow()
+		progress.report(logger, toBlock)
+	}
+	return nil
+}
+
+// rangeErrors are substrings of the errors providers return when a log query spans too many blocks or results
+var rangeErrors = []string{
+	"range too large",
+	"range is too large",
+	"block range",
+	"too many results",
+	"too many blocks",
+	"query returned more than",
+	"response size exceeded",
+	"limit exceeded",
+}
+
+// isRangeError returns whether err means the log query should be retried over a smaller range
+func isRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, rangeErr := range rangeErrors {
+		if strings.Contains(msg, rangeErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// historyProgress logs the progress of long history queries every historyProgressInterval
+type historyProgress struct {
+	start, end uint64
+	started    time.Time
+	lastReport time.Time
+}
+
+func newHistoryProgress(start, end uint64) *historyProgress {
+	now := time.Now()
+	return &historyProgress{start: start, end: end, started: now, lastReport: now}
+}
+
+// report logs the blocks per second and estimated time left once historyProgressInterval has passed
+func (p *historyProgress) report(logger log.Logger, block uint64) {
+	if time.Since(p.lastReport) < historyProgressInterval || block >= p.end {
+		return
+	}
+	p.lastReport = time.Now()
+
+	done := block - p.start + 1
+	blocksPerSecond := float64(done) / time.Since(p.started).Seconds()
+	eta := time.Duration(float64(p.end-block) / blocksPerSecond * float64(time.Second))
+
+	logger.Info(fmt.Sprintf("Getting history: %d/%d blocks (%.1f blocks/sec, ETA %v)", done, p.end-p.start+1, blocksPerSecond, eta.Round(time.Second)))
+}
+
+// queryLogs returns the logs matching the query. Logs are queried with eth_getLogs over rpc when polling,
+// otherwise over the websocket.
+func (e *Ethereum) queryLogs(ctx context.Context, query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	if e.isPolling() {
+		client := e.rpcClient()
+		logs, err := client.FilterLogs(ctx, query)
+		e.rpcEndpoints.Report(client, err)
+		return logs, err
+	}
+
+	client := e.wsClient()
+	etherReader := etherstream.Reader{Backend: client}
+	_, toUnSub, history, err := etherReader.QueryWithHistory(ctx, &query)
+	e.wsEndpoints.Report(client, err)
+	if err != nil {
+		return nil, err
 	}
+	toUnSub.Unsubscribe()
+	return history, nil
 }
 
 // consumeHistory consumes the history from a QueryWithHistory() go-ethereum call.
-// it passes messages to the processingQueue
+// it groups messages by source tx and passes them to the processingQueue
 func consumeHistory(
 	logger log.Logger,
 	history []ethtypes.Log,
@@ -207,6 +389,7 @@ func consumeHistory(
 	messageSent abi.Event,
 	messageTransmitterABI abi.ABI,
 ) {
+	var txState *types.TxState
 	for i := range history {
 		historicalLog := history[i]
 		parsedMsg, err := types.EvmLogToMessageState(messageTransmitterABI, messageSent, &historicalLog)
@@ -216,12 +399,30 @@ func consumeHistory(
 		}
 		logger.Info(fmt.Sprintf("New historical msg from source domain %d with tx hash %s", parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
 
-		processingQueue <- &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
+		if txState != nil && txState.TxHash == parsedMsg.SourceTxHash {
+			txState.Msgs = append(txState.Msgs, parsedMsg)
+			continue
+		}
+		enqueueTxState(processingQueue, txState)
+		txState = &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
+	}
+	enqueueTxState(processingQueue, txState)
+}
+
+// enqueueTxState pairs forwarding messages with their burns and passes the tx to the processingQueue
+func enqueueTxState(processingQueue chan *types.TxState, txState *types.TxState) {
+	if txState == nil {
+		return
+	}
+	txState.PairForwards()
+	if len(txState.Msgs) > 0 {
+		processingQueue <- txState
 	}
 }
 
 // consumeStream consumes incoming transactions from a QueryWithHistory() go-ethereum call.
-// if the websocket is disconnect, it restarts the stream using the last seen block height as the start height.
+// Messages are held in the chain's confirmation queue until they are buried under the configured number of
+// confirmations. Logs removed in a reorg are dropped from the queue, or retracted if they were already released.
 func (e *Ethereum) consumeStream(
 	ctx context.Context,
 	logger log.Logger,
@@ -230,10 +431,13 @@ func (e *Ethereum) consumeStream(
 	messageTransmitterABI abi.ABI,
 	stream <-chan ethtypes.Log,
 	sig *errSignal,
-
+	m *relayer.PromMetrics,
 ) {
 	logger.Info("Starting consumption of incoming stream")
-	var txState *types.TxState
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -242,28 +446,94 @@ func (e *Ethereum) consumeStream(
 			logger.Debug("Websocket disconnected... Stopped consuming stream. Will restart after websocket is re-established")
 			return
 		case streamLog := <-stream:
-			parsedMsg, err := types.EvmLogToMessageState(messageTransmitterABI, messageSent, &streamLog)
-			if err != nil {
-				logger.Error("Unable to parse ws log into MessageState, skipping", "source tx", streamLog.TxHash.Hex(), "err", err)
-				continue
-			}
-			logger.Info(fmt.Sprintf("New stream msg from %d with tx hash %s", parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
-
-			switch {
-			case txState == nil:
-				txState = &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
-			case parsedMsg.SourceTxHash != txState.TxHash:
-				processingQueue <- txState
-				txState = &types.TxState{TxHash: parsedMsg.SourceTxHash, Msgs: []*types.MessageState{parsedMsg}}
-			default:
-				txState.Msgs = append(txState.Msgs, parsedMsg)
-			}
-		default:
-			if txState != nil {
-				processingQueue <- txState
-				txState = nil
-			}
+			e.queueStreamLog(logger, processingQueue, messageSent, messageTransmitterABI, streamLog, m)
+		case <-ticker.C:
+			e.releaseConfirmed(ctx, logger, processingQueue, m)
+		}
+	}
+}
+
+// queueStreamLog adds a stream log to the confirmation queue, or handles its removal if it was reorged out
+func (e *Ethereum) queueStreamLog(
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	messageSent abi.Event,
+	messageTransmitterABI abi.ABI,
+	streamLog ethtypes.Log,
+	m *relayer.PromMetrics,
+) {
+	if streamLog.Removed {
+		logger.Info(fmt.Sprintf("Stream log removed by reorg at block %d with tx hash %s", streamLog.BlockNumber, streamLog.TxHash.Hex()))
+
+		retracted := e.confirmationQueue.remove(streamLog)
+		if retracted == 0 {
+			return
+		}
+		logger.Error(fmt.Sprintf("Retracting %d released msg(s) with tx hash %s", retracted, streamLog.TxHash.Hex()))
+		if m != nil {
+			m.IncRetractedMessages(e.name, fmt.Sprint(e.domain), retracted)
 		}
+		processingQueue <- &types.TxState{TxHash: streamLog.TxHash.Hex(), Removed: true}
+		return
+	}
+
+	parsedMsg, err := types.EvmLogToMessageState(messageTransmitterABI, messageSent, &streamLog)
+	if err != nil {
+		logger.Error("Unable to parse ws log into MessageState, skipping", "source tx", streamLog.TxHash.Hex(), "err", err)
+		return
+	}
+	logger.Info(fmt.Sprintf("New stream msg from %d with tx hash %s at block %d", parsedMsg.SourceDomain, parsedMsg.SourceTxHash, streamLog.BlockNumber))
+
+	e.confirmationQueue.add(streamLog, parsedMsg)
+}
+
+// releaseConfirmed passes the queued messages with enough confirmations to the processingQueue
+func (e *Ethereum) releaseConfirmed(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	m *relayer.PromMetrics,
+) {
+	d := fmt.Sprint(e.domain)
+
+	if depth := e.confirmationQueue.takeReorgDepth(); depth > 0 {
+		logger.Info(fmt.Sprintf("Reorg detected with depth %d", depth))
+		if m != nil {
+			m.ObserveReorgDepth(e.name, d, depth)
+		}
+	}
+
+	if e.confirmationQueue.len() == 0 {
+		return
+	}
+
+	head, err := e.blockNumber(ctx)
+	if err != nil {
+		logger.Error("Unable to query latest height for confirmations", "err", err)
+		return
+	}
+
+	txStates, reorged := e.confirmationQueue.release(head, func(blockNumber uint64) (common.Hash, error) {
+		client := e.rpcClient()
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		e.rpcEndpoints.Report(client, err)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Unable to query header for block %d", blockNumber), "err", err)
+			return common.Hash{}, err
+		}
+		return header.Hash(), nil
+	})
+
+	for _, blockNumber := range reorged {
+		depth := head - blockNumber + 1
+		logger.Info(fmt.Sprintf("Dropped queued msgs from non-canonical block %d (reorg depth at least %d)", blockNumber, depth))
+		if m != nil {
+			m.ObserveReorgDepth(e.name, d, depth)
+		}
+	}
+
+	for _, txState := range txStates {
+		enqueueTxState(processingQueue, txState)
 	}
 }
 
@@ -277,18 +547,24 @@ func (e *Ethereum) consumeStream(
 //
 // Note: The first time the flush mechanism is run, it will set the lastFlushedBlock to the latest block
 // minus twice the lookback period.
+//
+// Flushes requested with RequestFlush run right away. Without a flushInterval, flushes only run on request.
 func (e *Ethereum) flushMechanism(
 	ctx context.Context,
 	logger log.Logger,
 	processingQueue chan *types.TxState,
 	messageSent abi.Event,
-	messageTransmitterAddress common.Address,
+	messageTransmitterAddresses []common.Address,
 	messageTransmitterABI abi.ABI,
 	flushOnlyMode bool,
 	flushInterval time.Duration,
 	sig *errSignal,
 ) {
-	logger.Info(fmt.Sprintf("Starting flush mechanism. Will flush every %v", flushInterval))
+	if flushInterval > 0 {
+		logger.Info(fmt.Sprintf("Starting flush mechanism. Will flush every %v", flushInterval))
+	} else {
+		logger.Info("Starting flush mechanism. Will only flush on request")
+	}
 
 	// extraFlushBlocks is used to add an extra space between latest height and last flushed block
 	// this setting should only be used for the secondary, flush only relayer
@@ -298,62 +574,88 @@ func (e *Ethereum) flushMechanism(
 	}
 
 	for {
-		timer := time.NewTimer(flushInterval)
+		interval, stop := relayer.FlushTimer(flushInterval)
 		select {
-		case <-timer.C:
-			latestBlock := e.LatestBlock()
+		case <-e.flushRequests:
+			stop()
+			logger.Info("Flush requested")
+			e.flush(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, extraFlushBlocks)
+		case <-interval:
+			e.flush(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, extraFlushBlocks)
+		// if main websocket stream is disconnected, stop flush. It will be restarted once websocket is reconnected
+		case <-sig.Ready:
+			stop()
+			logger.Debug("Websocket disconnected... Flush stopped. Will restart after websocket is re-established")
+			return
+		case <-ctx.Done():
+			stop()
+			return
+		}
+	}
+}
 
-			// initialize first lastFlushedBlock if not set
-			if e.lastFlushedBlock == 0 {
-				e.lastFlushedBlock = latestBlock - (2*e.lookbackPeriod + extraFlushBlocks)
+// flush consumes the history from the last flushed block up to the lookback period behind the latest block
+func (e *Ethereum) flush(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	messageSent abi.Event,
+	messageTransmitterAddresses []common.Address,
+	messageTransmitterABI abi.ABI,
+	extraFlushBlocks uint64,
+) {
+	latestBlock := e.LatestBlock()
 
-				if latestBlock < e.lookbackPeriod {
-					e.lastFlushedBlock = 0
-				}
-			}
+	// initialize first lastFlushedBlock if not set
+	if e.lastFlushedBlock == 0 {
+		e.lastFlushedBlock = latestBlock - (2*e.lookbackPeriod + extraFlushBlocks)
 
-			// start from the last block it flushed
-			startBlock := e.lastFlushedBlock
+		if latestBlock < e.lookbackPeriod {
+			e.lastFlushedBlock = 0
+		}
+	}
 
-			// set finish block to be latestBlock - lookbackPeriod
-			finishBlock := latestBlock - (e.lookbackPeriod + extraFlushBlocks)
+	// start from the last block it flushed
+	startBlock := e.lastFlushedBlock
 
-			if startBlock >= finishBlock {
-				logger.Debug("No new blocks to flush")
-				continue
-			}
+	// set finish block to be latestBlock - lookbackPeriod, never flushing unconfirmed blocks
+	finishBlock := latestBlock - (max(e.lookbackPeriod, e.confirmations) + extraFlushBlocks)
 
-			logger.Info(fmt.Sprintf("Flush started from %d to %d (current height: %d, lookback period: %d)", startBlock, finishBlock, latestBlock, e.lookbackPeriod))
+	if startBlock >= finishBlock {
+		logger.Debug("No new blocks to flush")
+		return
+	}
 
-			// consume from lastFlushedBlock to the finishBlock
-			e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddress, messageTransmitterABI, startBlock, finishBlock)
+	logger.Info(fmt.Sprintf("Flush started from %d to %d (current height: %d, lookback period: %d)", startBlock, finishBlock, latestBlock, e.lookbackPeriod))
 
-			// update lastFlushedBlock to the last block it flushed
-			e.lastFlushedBlock = finishBlock
+	// consume from lastFlushedBlock to the finishBlock
+	// a failed flush is retried from the same block on the next interval
+	if err := e.getAndConsumeHistory(ctx, logger, processingQueue, messageSent, messageTransmitterAddresses, messageTransmitterABI, startBlock, finishBlock); err != nil {
+		logger.Error("Unable to flush", "err", err)
+		return
+	}
 
-			logger.Info("Flush complete")
+	// update lastFlushedBlock to the last block it flushed
+	e.lastFlushedBlock = finishBlock
 
-		// if main websocket stream is disconnected, stop flush. It will be restarted once websocket is reconnected
-		case <-sig.Ready:
-			timer.Stop()
-			logger.Debug("Websocket disconnected... Flush stopped. Will restart after websocket is re-established")
-			return
-		case <-ctx.Done():
-			timer.Stop()
-			return
-		}
-	}
+	logger.Info("Flush complete")
 }
 
-func (e *Ethereum) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
+func (e *Ethereum) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) error {
 	logger.With("routine", "TrackLatestBlockHeight", "chain", e.name, "domain", e.domain)
 
 	d := fmt.Sprint(e.domain)
 
+	// keep scoring the endpoints so queries, listeners and broadcasts use the healthiest one
+	go e.rpcEndpoints.Monitor(ctx, m)
+	if e.wsEndpoints != nil {
+		go e.wsEndpoints.Monitor(ctx, m)
+	}
+
 	// helper function to query latest height and set metric
 	queryHeightAndSetMetric := func() {
 		// first time
-		res, err := e.rpcClient.BlockNumber(ctx)
+		res, err := e.blockNumber(ctx)
 		if err != nil {
 			logger.Error("Unable to query latest height", "err", err)
 		} else {
@@ -375,12 +677,12 @@ func (e *Ethereum) TrackLatestBlockHeight(ctx context.Context, logger log.Logger
 			queryHeightAndSetMetric()
 		case <-ctx.Done():
 			timer.Stop()
-			return
+			return nil
 		}
 	}
 }
 
-func (e *Ethereum) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
+func (e *Ethereum) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) error {
 	logger = logger.With("metric", "wallet balance", "chain", e.name, "domain", e.domain)
 	queryRate := 5 * time.Minute
 
@@ -391,13 +693,17 @@ func (e *Ethereum) WalletBalanceMetric(ctx context.Context, logger log.Logger, m
 
 	// helper function to query balance and set metric
 	queryBalanceAndSetMetric := func() {
-		balance, err := e.rpcClient.BalanceAt(ctx, account, nil)
+		balance, err := e.rpcClient().BalanceAt(ctx, account, nil)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Error querying balance. Will try again in %.2f sec", queryRate.Seconds()), "error", err)
 		} else {
 			balanceBigFloat := new(big.Float).SetInt(balance)
 			balanceScaled, _ := new(big.Float).Quo(balanceBigFloat, scaleFactor).Float64()
 
+			e.mu.Lock()
+			e.walletBalance = &balanceScaled
+			e.mu.Unlock()
+
 			if m != nil {
 				m.SetWalletBalance(e.name, e.minterAddress, e.MetricsDenom, balanceScaled)
 			}
@@ -414,7 +720,7 @@ func (e *Ethereum) WalletBalanceMetric(ctx context.Context, logger log.Logger, m
 			queryBalanceAndSetMetric()
 		case <-ctx.Done():
 			timer.Stop()
-			return
+			return nil
 		}
 	}
 }
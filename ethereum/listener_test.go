@@ -2,11 +2,24 @@ package ethereum_test
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
+	"cosmossdk.io/log"
+
 	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum"
 	testutil "github.com/strangelove-ventures/noble-cctp-relayer/test_util"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
@@ -28,7 +41,7 @@ func TestStartListener(t *testing.T) {
 
 	processingQueue := make(chan *types.TxState, 10000)
 
-	go eth.StartListener(ctx, a.Logger, processingQueue, false, 0)
+	go eth.StartListener(ctx, a.Logger, processingQueue, false, 0, nil)
 
 	time.Sleep(5 * time.Second)
 
@@ -36,14 +49,191 @@ func TestStartListener(t *testing.T) {
 
 	expectedMsg := &types.MessageState{
 		IrisLookupID: "a404f4155166a1fc7ffee145b5cac6d0f798333745289ab1db171344e226ef0c",
-		Status:       "created",
 		SourceDomain: 0,
 		DestDomain:   4,
 		SourceTxHash: "0xe1d7729de300274ee3a2fd20ba179b14a8e3ffcd9d847c506b06760f0dad7802",
 	}
 	require.Equal(t, expectedMsg.IrisLookupID, tx.Msgs[0].IrisLookupID)
-	require.Equal(t, expectedMsg.Status, tx.Msgs[0].Status)
+	require.Equal(t, types.Created, tx.Msgs[0].Status())
 	require.Equal(t, expectedMsg.SourceDomain, tx.Msgs[0].SourceDomain)
 	require.Equal(t, expectedMsg.DestDomain, tx.Msgs[0].DestDomain)
 	require.Equal(t, expectedMsg.SourceTxHash, tx.Msgs[0].SourceTxHash)
 }
+
+// fakeEthService is a local stand-in for the eth_blockNumber and eth_getLogs json-rpc methods.
+// If maxRange is set, log queries over more blocks are rejected like providers with range limits do.
+type fakeEthService struct {
+	head     uint64
+	logs     []ethtypes.Log
+	maxRange uint64
+
+	rejected atomic.Int32
+}
+
+func (s *fakeEthService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.head)
+}
+
+type fakeFilterArgs struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+}
+
+func (s *fakeEthService) GetLogs(args fakeFilterArgs) ([]ethtypes.Log, error) {
+	if s.maxRange > 0 && uint64(args.ToBlock-args.FromBlock)+1 > s.maxRange {
+		s.rejected.Add(1)
+		return nil, fmt.Errorf("block range too large, max is %d blocks", s.maxRange)
+	}
+
+	logs := []ethtypes.Log{}
+	for _, l := range s.logs {
+		if l.BlockNumber >= uint64(args.FromBlock) && l.BlockNumber <= uint64(args.ToBlock) {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+// messageSentLog builds a MessageSent log for a v1 burn from domain 0 to noble
+func messageSentLog(t *testing.T, blockNumber uint64, txHash common.Hash) ethtypes.Log {
+	msg := make([]byte, 116+132)
+	binary.BigEndian.PutUint32(msg[8:], 4)
+	binary.BigEndian.PutUint64(msg[12:], blockNumber)
+	msg[116+67] = 10
+
+	bytesType, err := abi.NewType("bytes", "", nil)
+	require.NoError(t, err)
+	data, err := abi.Arguments{{Type: bytesType}}.Pack(msg)
+	require.NoError(t, err)
+
+	return ethtypes.Log{
+		Address:     common.HexToAddress("0x26413e8157CD32011E726065a5462e97dD4d03D9"),
+		Topics:      []common.Hash{crypto.Keccak256Hash([]byte("MessageSent(bytes)"))},
+		Data:        data,
+		BlockNumber: blockNumber,
+		BlockHash:   common.Hash{byte(blockNumber)},
+		TxHash:      txHash,
+	}
+}
+
+func TestStartListenerPolling(t *testing.T) {
+	service := &fakeEthService{
+		head: 13,
+		logs: []ethtypes.Log{
+			messageSentLog(t, 10, common.Hash{0x1}),
+			messageSentLog(t, 12, common.Hash{0x2}),
+			// not confirmed yet
+			messageSentLog(t, 13, common.Hash{0x3}),
+		},
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	eth, err := ethereum.NewChain("ethereum", &ethereum.ChainConfig{
+		RPC:                    httpServer.URL,
+		ChainID:                1,
+		MessageTransmitter:     "0x26413e8157CD32011E726065a5462e97dD4d03D9",
+		Confirmations:          1,
+		Polling:                true,
+		PollInterval:           1,
+		PollRange:              2,
+		BroadcastRetries:       1,
+		BroadcastRetryInterval: 1,
+		MinMintAmount:          1,
+		MinterPrivateKey:       hex.EncodeToString(crypto.FromECDSA(key)),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, eth.InitializeClients(ctx, log.NewNopLogger()))
+	defer eth.CloseClients()
+	eth.SetLatestBlock(11)
+
+	processingQueue := make(chan *types.TxState, 10)
+	go eth.StartListener(ctx, log.NewNopLogger(), processingQueue, false, 0, nil)
+
+	// block 10 is picked up by the lookback, block 12 by polling
+	for _, txHash := range []common.Hash{{0x1}, {0x2}} {
+		select {
+		case tx := <-processingQueue:
+			require.Equal(t, txHash.Hex(), tx.TxHash)
+			require.Len(t, tx.Msgs, 1)
+			require.Equal(t, types.Domain(4), tx.Msgs[0].DestDomain)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for polled tx")
+		}
+	}
+
+	select {
+	case tx := <-processingQueue:
+		t.Fatalf("unexpected unconfirmed tx %s", tx.TxHash)
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
+func TestHistoryRangeSizing(t *testing.T) {
+	service := &fakeEthService{
+		head:     1001,
+		maxRange: 50,
+		logs: []ethtypes.Log{
+			messageSentLog(t, 10, common.Hash{0x1}),
+			messageSentLog(t, 500, common.Hash{0x2}),
+			messageSentLog(t, 990, common.Hash{0x3}),
+		},
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// history starts with ranges of 400 blocks, more than the endpoint accepts
+	eth, err := ethereum.NewChain("ethereum", &ethereum.ChainConfig{
+		RPC:                    httpServer.URL,
+		ChainID:                1,
+		MessageTransmitter:     "0x26413e8157CD32011E726065a5462e97dD4d03D9",
+		LookbackPeriod:         1000,
+		Confirmations:          1,
+		Polling:                true,
+		PollInterval:           60,
+		PollRange:              400,
+		LogRangeMin:            10,
+		LogRangeMax:            1000,
+		LogQueryRetries:        1,
+		BroadcastRetries:       1,
+		BroadcastRetryInterval: 1,
+		MinMintAmount:          1,
+		MinterPrivateKey:       hex.EncodeToString(crypto.FromECDSA(key)),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, eth.InitializeClients(ctx, log.NewNopLogger()))
+	defer eth.CloseClients()
+	eth.SetLatestBlock(1001)
+
+	processingQueue := make(chan *types.TxState, 10)
+	go eth.StartListener(ctx, log.NewNopLogger(), processingQueue, false, 0, nil)
+
+	// the lookback shrinks its ranges until the endpoint accepts them, without giving up on any blocks
+	for _, txHash := range []common.Hash{{0x1}, {0x2}, {0x3}} {
+		select {
+		case tx := <-processingQueue:
+			require.Equal(t, txHash.Hex(), tx.TxHash)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for history")
+		}
+	}
+	require.Positive(t, service.rejected.Load())
+}
@@ -0,0 +1,237 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum/contracts"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// pendingReceiveTTL is how long a receiveMessage seen in a pending tx is remembered. Pending txs that are not
+	// mined by then were likely dropped, the broadcasters queue messages relayed by another relayer again after as long.
+	pendingReceiveTTL = 2 * time.Minute
+	// txpoolContentTTL is how long the result of txpool_content is reused by consecutive broadcasts
+	txpoolContentTTL = 3 * time.Second
+	// mempoolResubscribeInterval is the time between attempts to subscribe to pending txs
+	mempoolResubscribeInterval = 5 * time.Second
+)
+
+// pendingReceive is a receiveMessage call in another relayer's pending tx
+type pendingReceive struct {
+	txHash string
+	seen   time.Time
+}
+
+// mempoolWatcher tracks the receiveMessage calls in other relayers' pending txs on the destination chain, so
+// messages they are already minting are not raced. Pending txs are streamed with eth_subscribe
+// newPendingTransactions when a websocket is available, and queried with txpool_content otherwise.
+type mempoolWatcher struct {
+	transmitters   []common.Address
+	minter         common.Address
+	signer         ethtypes.Signer
+	receiveMessage abi.Method
+
+	mu      sync.Mutex
+	pending map[string]pendingReceive
+	// subscribed is set while pending txs are streamed, txpool_content is only queried when they are not
+	subscribed        bool
+	txpoolQueried     time.Time
+	txpoolUnsupported bool
+}
+
+func newMempoolWatcher(chainID int64, minter string, transmitters []common.Address) (*mempoolWatcher, error) {
+	// the receiveMessage signature is the same for V1 and V2
+	transmitterABI, err := contracts.MessageTransmitterMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse MessageTransmitter abi: %w", err)
+	}
+
+	return &mempoolWatcher{
+		transmitters:   transmitters,
+		minter:         common.HexToAddress(minter),
+		signer:         ethtypes.LatestSignerForChainID(big.NewInt(chainID)),
+		receiveMessage: transmitterABI.Methods["receiveMessage"],
+		pending:        make(map[string]pendingReceive),
+	}, nil
+}
+
+// observe records the message received by a pending tx, if it is another relayer's receiveMessage call
+func (w *mempoolWatcher) observe(tx *ethtypes.Transaction) {
+	key, ok := w.receiveKey(tx)
+	if !ok {
+		return
+	}
+
+	w.mu.Lock()
+	w.pending[key] = pendingReceive{txHash: tx.Hash().Hex(), seen: time.Now()}
+	w.mu.Unlock()
+}
+
+// receiveKey returns the receive key of the message received by tx
+func (w *mempoolWatcher) receiveKey(tx *ethtypes.Transaction) (string, bool) {
+	if tx.To() == nil || !w.isTransmitter(*tx.To()) {
+		return "", false
+	}
+
+	data := tx.Data()
+	if len(data) < 4 || !bytes.Equal(data[:4], w.receiveMessage.ID) {
+		return "", false
+	}
+
+	// our own pending broadcasts are not a race
+	if from, err := ethtypes.Sender(w.signer, tx); err != nil || from == w.minter {
+		return "", false
+	}
+
+	args, err := w.receiveMessage.Inputs.Unpack(data[4:])
+	if err != nil || len(args) == 0 {
+		return "", false
+	}
+	message, ok := args[0].([]byte)
+	if !ok {
+		return "", false
+	}
+	parsed, err := new(types.Message).Parse(message)
+	if err != nil {
+		return "", false
+	}
+	return parsed.ReceiveKey(), true
+}
+
+func (w *mempoolWatcher) isTransmitter(address common.Address) bool {
+	for _, transmitter := range w.transmitters {
+		if transmitter == address {
+			return true
+		}
+	}
+	return false
+}
+
+// otherPendingReceive returns the hash of another relayer's pending tx receiving msg, or an empty string
+func (w *mempoolWatcher) otherPendingReceive(msg *types.MessageState) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for key, pending := range w.pending {
+		if time.Since(pending.seen) > pendingReceiveTTL {
+			delete(w.pending, key)
+		}
+	}
+	return w.pending[msg.ReceiveKey()].txHash
+}
+
+// refreshTxpool observes the pending txs returned by txpool_content, unless pending txs are streamed, the txpool
+// was queried recently or the endpoint does not support it
+func (w *mempoolWatcher) refreshTxpool(ctx context.Context, logger log.Logger, client *rpc.Client) {
+	w.mu.Lock()
+	if w.subscribed || w.txpoolUnsupported || time.Since(w.txpoolQueried) < txpoolContentTTL {
+		w.mu.Unlock()
+		return
+	}
+	w.txpoolQueried = time.Now()
+	w.mu.Unlock()
+
+	var content struct {
+		Pending map[string]map[string]*ethtypes.Transaction `json:"pending"`
+	}
+	if err := client.CallContext(ctx, &content, "txpool_content"); err != nil {
+		if isMethodNotFound(err) {
+			logger.Error("The rpc does not support txpool_content, pending txs can not be watched without a websocket", "err", err)
+			w.mu.Lock()
+			w.txpoolUnsupported = true
+			w.mu.Unlock()
+			return
+		}
+		logger.Error("Unable to query txpool_content", "err", err)
+		return
+	}
+
+	for _, txs := range content.Pending {
+		for _, tx := range txs {
+			w.observe(tx)
+		}
+	}
+}
+
+// isMethodNotFound returns true if the rpc does not provide a method
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	return strings.Contains(err.Error(), "does not exist") || strings.Contains(err.Error(), "not supported")
+}
+
+// watchMempool streams pending txs over the websocket until ctx is done. If the endpoint does not support
+// subscribing to full pending txs, txpool_content is queried before each broadcast instead.
+func (e *Ethereum) watchMempool(ctx context.Context, logger log.Logger) {
+	logger = logger.With("routine", "mempool")
+	w := e.mempool
+
+	setSubscribed := func(subscribed bool) {
+		w.mu.Lock()
+		w.subscribed = subscribed
+		w.mu.Unlock()
+	}
+
+	for {
+		client := e.wsClient()
+		pendingTxs := make(chan *ethtypes.Transaction, 1000)
+		sub, err := gethclient.New(client.Client()).SubscribeFullPendingTransactions(ctx, pendingTxs)
+		e.wsEndpoints.Report(client, err)
+		if err != nil {
+			if isMethodNotFound(err) {
+				logger.Info("The websocket does not support streaming pending txs, querying txpool_content instead", "err", err)
+				return
+			}
+			logger.Error(fmt.Sprintf("Unable to subscribe to pending txs. Retrying in %v", mempoolResubscribeInterval), "err", err)
+		} else {
+			setSubscribed(true)
+			err = consumePendingTxs(ctx, w, sub, pendingTxs)
+			setSubscribed(false)
+			if err == nil {
+				return
+			}
+			logger.Error(fmt.Sprintf("Pending tx subscription failed. Resubscribing in %v", mempoolResubscribeInterval), "err", err)
+		}
+
+		timer := time.NewTimer(mempoolResubscribeInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// consumePendingTxs observes streamed pending txs until ctx is done (nil) or the subscription fails
+func consumePendingTxs(ctx context.Context, w *mempoolWatcher, sub *rpc.ClientSubscription, pendingTxs chan *ethtypes.Transaction) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case tx := <-pendingTxs:
+			w.observe(tx)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum/contracts"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+var testTransmitter = common.HexToAddress("0x0a992d191DEeC32aFe36203Ad87D7d289a738F81")
+
+// receiveMessageTx signs a receiveMessage call of the V1 message from sourceDomain with nonce
+func receiveMessageTx(t *testing.T, key *ecdsa.PrivateKey, to common.Address, sourceDomain uint32, nonce uint64) *ethtypes.Transaction {
+	message := make([]byte, 116)
+	binary.BigEndian.PutUint32(message[4:], sourceDomain)
+	binary.BigEndian.PutUint64(message[12:], nonce)
+
+	transmitterABI, err := contracts.MessageTransmitterMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := transmitterABI.Pack("receiveMessage", message, []byte("attestation"))
+	require.NoError(t, err)
+
+	tx, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(big.NewInt(1)), &ethtypes.DynamicFeeTx{
+		ChainID: big.NewInt(1),
+		To:      &to,
+		Gas:     200_000,
+		Data:    data,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestMempoolWatcherObserve(t *testing.T) {
+	minterKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	w, err := newMempoolWatcher(1, crypto.PubkeyToAddress(minterKey.PublicKey).Hex(), []common.Address{testTransmitter})
+	require.NoError(t, err)
+
+	msg := &types.MessageState{SourceDomain: 4, Nonce: 612}
+
+	// our own broadcasts and calls to other contracts are ignored
+	w.observe(receiveMessageTx(t, minterKey, testTransmitter, 4, 612))
+	w.observe(receiveMessageTx(t, otherKey, common.Address{0x1}, 4, 612))
+	require.Empty(t, w.otherPendingReceive(msg))
+
+	tx := receiveMessageTx(t, otherKey, testTransmitter, 4, 612)
+	w.observe(tx)
+	require.Equal(t, tx.Hash().Hex(), w.otherPendingReceive(msg))
+	require.Empty(t, w.otherPendingReceive(&types.MessageState{SourceDomain: 4, Nonce: 613}))
+	require.Empty(t, w.otherPendingReceive(&types.MessageState{SourceDomain: 3, Nonce: 612}))
+}
+
+// fakeTxpoolService serves txpool_content
+type fakeTxpoolService struct {
+	pending map[string]map[string]*ethtypes.Transaction
+}
+
+func (s *fakeTxpoolService) Content() map[string]map[string]map[string]*ethtypes.Transaction {
+	return map[string]map[string]map[string]*ethtypes.Transaction{"pending": s.pending}
+}
+
+func TestMempoolWatcherRefreshTxpool(t *testing.T) {
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := receiveMessageTx(t, otherKey, testTransmitter, 0, 42)
+
+	service := &fakeTxpoolService{pending: map[string]map[string]*ethtypes.Transaction{
+		crypto.PubkeyToAddress(otherKey.PublicKey).Hex(): {"0": tx},
+	}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("txpool", service))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client, err := rpc.Dial(httpServer.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	w, err := newMempoolWatcher(1, "0x26413e8157CD32011E726065a5462e97dD4d03D9", []common.Address{testTransmitter})
+	require.NoError(t, err)
+
+	w.refreshTxpool(context.Background(), log.NewNopLogger(), client)
+	require.Equal(t, tx.Hash().Hex(), w.otherPendingReceive(&types.MessageState{SourceDomain: 0, Nonce: 42}))
+	require.False(t, w.txpoolUnsupported)
+
+	// rpcs without the txpool namespace are only queried once
+	unsupported := httptest.NewServer(rpc.NewServer())
+	defer unsupported.Close()
+	unsupportedClient, err := rpc.Dial(unsupported.URL)
+	require.NoError(t, err)
+	defer unsupportedClient.Close()
+
+	w, err = newMempoolWatcher(1, "0x26413e8157CD32011E726065a5462e97dD4d03D9", []common.Address{testTransmitter})
+	require.NoError(t, err)
+	w.refreshTxpool(context.Background(), log.NewNopLogger(), unsupportedClient)
+	require.True(t, w.txpoolUnsupported)
+}
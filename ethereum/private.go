@@ -0,0 +1,205 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// PrivateTxMethodFlashbots submits private txs with eth_sendPrivateTransaction, e.g. to Flashbots Protect
+	PrivateTxMethodFlashbots = "eth_sendPrivateTransaction"
+	// PrivateTxMethodRaw submits private txs with eth_sendRawTransaction, to private rpcs that do not gossip txs to the
+	// public mempool
+	PrivateTxMethodRaw = "eth_sendRawTransaction"
+
+	defaultPrivateTxTimeoutSeconds = 60
+)
+
+// privateSubmitter sends signed mints to a private rpc, so they can not be copied from the public mempool
+type privateSubmitter struct {
+	url     string
+	method  string
+	timeout time.Duration
+
+	client *rpc.Client
+
+	mu sync.Mutex
+	// nextNonce is the account nonce after the latest private tx. The public rpc's pending nonce does not include
+	// private txs until they are mined or broadcast publicly.
+	nextNonce uint64
+}
+
+func newPrivateSubmitter(url, method string, timeoutSeconds int) (*privateSubmitter, error) {
+	if method == "" {
+		method = PrivateTxMethodFlashbots
+	}
+	if method != PrivateTxMethodFlashbots && method != PrivateTxMethodRaw {
+		return nil, fmt.Errorf("unsupported private-rpc-method %q, must be %s or %s", method, PrivateTxMethodFlashbots, PrivateTxMethodRaw)
+	}
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultPrivateTxTimeoutSeconds
+	}
+
+	return &privateSubmitter{
+		url:     url,
+		method:  method,
+		timeout: time.Duration(timeoutSeconds) * time.Second,
+	}, nil
+}
+
+// send submits a signed tx to the private rpc
+func (p *privateSubmitter) send(ctx context.Context, tx *ethtypes.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("unable to encode tx: %w", err)
+	}
+
+	// the result is the tx hash, but not every private rpc returns it in the same format
+	var result json.RawMessage
+	if p.method == PrivateTxMethodFlashbots {
+		err = p.client.CallContext(ctx, &result, p.method, map[string]string{"tx": hexutil.Encode(raw)})
+	} else {
+		err = p.client.CallContext(ctx, &result, p.method, hexutil.Encode(raw))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to submit private tx: %w", err)
+	}
+
+	p.mu.Lock()
+	p.nextNonce = max(p.nextNonce, tx.Nonce()+1)
+	p.mu.Unlock()
+	return nil
+}
+
+// nonce returns the account nonce to sign the next tx with, given the public rpc's pending nonce
+func (p *privateSubmitter) nonce(pendingNonce uint64) uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return max(pendingNonce, p.nextNonce)
+}
+
+// resetNonce forgets the nonces of private txs, after one was neither mined nor broadcast publicly. The next tx
+// reuses its nonce, otherwise txs after the gap would never be mined.
+func (p *privateSubmitter) resetNonce() {
+	p.mu.Lock()
+	p.nextNonce = 0
+	p.mu.Unlock()
+}
+
+// sendPrivateTx submits a signed tx to the private rpc. It is broadcast publicly right away if the private
+// submission fails, it returns whether the tx is pending privately. Private txs are watched until they land, msg stays
+// attested until then.
+func (e *Ethereum) sendPrivateTx(
+	ctx context.Context,
+	logger log.Logger,
+	msg *types.MessageState,
+	tx *ethtypes.Transaction,
+	attempt int,
+) (bool, error) {
+	if err := e.private.send(ctx, tx); err != nil {
+		logger.Error("Private tx submission failed, broadcasting publicly", "tx", tx.Hash().Hex(), "err", err)
+		return false, e.rpcClient().SendTransaction(ctx, tx)
+	}
+
+	logger.Info(fmt.Sprintf("Submitted tx %s privately, broadcasting publicly if it is not mined within %v", tx.Hash().Hex(), e.private.timeout))
+	go e.fallbackToPublic(ctx, logger, msg, tx, attempt)
+	return true, nil
+}
+
+// fallbackToPublic broadcasts a private tx publicly if it is not mined when the private tx timeout expires. msg is
+// complete once the tx is mined successfully and failed if it reverts or can not be broadcast publicly. It stays
+// attested if ctx is done first.
+func (e *Ethereum) fallbackToPublic(
+	ctx context.Context,
+	logger log.Logger,
+	msg *types.MessageState,
+	tx *ethtypes.Transaction,
+	attempt int,
+) {
+	timer := time.NewTimer(e.private.timeout)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+		return
+	}
+
+	client := e.rpcClient()
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err == nil && receipt != nil {
+		e.settlePrivateTx(logger, msg, tx, receipt, attempt)
+		return
+	}
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		logger.Error("Unable to query private tx receipt, broadcasting it publicly", "tx", tx.Hash().Hex(), "err", err)
+	} else {
+		logger.Info(fmt.Sprintf("Private tx %s was not mined within %v, broadcasting it publicly", tx.Hash().Hex(), e.private.timeout))
+	}
+
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "already known"):
+			// the public mempool has the tx already
+		case strings.Contains(err.Error(), "nonce too low"):
+			// the nonce was used, by the private tx if it was mined in the meantime
+			receipt, rerr := client.TransactionReceipt(ctx, tx.Hash())
+			if rerr != nil || receipt == nil {
+				msg.SetBroadcastStatus(logger, types.Failed, attempt, fmt.Errorf("nonce of private tx %s was used by another tx: %w", tx.Hash().Hex(), err))
+				return
+			}
+			e.settlePrivateTx(logger, msg, tx, receipt, attempt)
+			return
+		default:
+			logger.Error("Unable to broadcast private tx publicly", "tx", tx.Hash().Hex(), "err", err)
+			e.private.resetNonce()
+			msg.SetBroadcastStatus(logger, types.Failed, attempt, fmt.Errorf("unable to broadcast private tx %s publicly: %w", tx.Hash().Hex(), err))
+			return
+		}
+	}
+
+	// the public tx is given as long as the private one to be mined
+	waitCtx, cancel := context.WithTimeout(ctx, e.private.timeout)
+	defer cancel()
+	receipt, err = bind.WaitMined(waitCtx, client, tx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		msg.SetBroadcastStatus(logger, types.Failed, attempt, fmt.Errorf("tx %s was not mined within %v of its public broadcast", tx.Hash().Hex(), e.private.timeout))
+		return
+	}
+	e.settlePrivateTx(logger, msg, tx, receipt, attempt)
+}
+
+// settlePrivateTx moves msg to complete if tx was mined successfully, or to failed if it reverted
+func (e *Ethereum) settlePrivateTx(
+	logger log.Logger,
+	msg *types.MessageState,
+	tx *ethtypes.Transaction,
+	receipt *ethtypes.Receipt,
+	attempt int,
+) {
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		logger.Error(fmt.Sprintf("Private tx %s of msg from %d with tx hash %s reverted", tx.Hash().Hex(), msg.SourceDomain, msg.SourceTxHash))
+		msg.SetBroadcastStatus(logger, types.Failed, attempt, fmt.Errorf("tx %s reverted in block %d", tx.Hash().Hex(), receipt.BlockNumber))
+		return
+	}
+
+	logger.Info(fmt.Sprintf("Private tx %s of msg from %d with tx hash %s was mined", tx.Hash().Hex(), msg.SourceDomain, msg.SourceTxHash))
+	msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
+}
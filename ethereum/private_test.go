@@ -0,0 +1,272 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// fakeTxService stands in for a private or public rpc, recording the raw txs it receives
+type fakeTxService struct {
+	mu      sync.Mutex
+	private []string
+	public  []string
+	mined   map[common.Hash]bool
+	// reverted txs are mined with a failed receipt
+	reverted map[common.Hash]bool
+	// mineOnSend mines txs once they are received publicly
+	mineOnSend bool
+	// sendErr fails public sends
+	sendErr error
+}
+
+func (s *fakeTxService) SendPrivateTransaction(args map[string]string) common.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.private = append(s.private, args["tx"])
+	return common.Hash{}
+}
+
+func (s *fakeTxService) SendRawTransaction(raw hexutil.Bytes) (common.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return common.Hash{}, s.sendErr
+	}
+	s.public = append(s.public, hexutil.Encode(raw))
+
+	if s.mineOnSend {
+		tx := new(ethtypes.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return common.Hash{}, err
+		}
+		if s.mined == nil {
+			s.mined = make(map[common.Hash]bool)
+		}
+		s.mined[tx.Hash()] = true
+	}
+	return common.Hash{}, nil
+}
+
+func (s *fakeTxService) GetTransactionReceipt(hash common.Hash) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.mined[hash] && !s.reverted[hash] {
+		return nil
+	}
+	status := "0x1"
+	if s.reverted[hash] {
+		status = "0x0"
+	}
+	return map[string]any{
+		"transactionHash":   hash,
+		"blockHash":         common.Hash{0x1},
+		"blockNumber":       "0x1",
+		"status":            status,
+		"cumulativeGasUsed": "0x1",
+		"gasUsed":           "0x1",
+		"logs":              []any{},
+		"logsBloom":         hexutil.Bytes(make([]byte, 256)),
+	}
+}
+
+func (s *fakeTxService) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+func (s *fakeTxService) received() (private, public []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.private...), append([]string(nil), s.public...)
+}
+
+func fakeTxServer(t *testing.T, service *fakeTxService) string {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL
+}
+
+func signedTestTx(t *testing.T, nonce uint64) *ethtypes.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(big.NewInt(1)), &ethtypes.DynamicFeeTx{
+		ChainID: big.NewInt(1),
+		Nonce:   nonce,
+		To:      &testTransmitter,
+		Gas:     200_000,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+// attestedTestMsg returns a message ready to be broadcast
+func attestedTestMsg(t *testing.T) *types.MessageState {
+	msg := &types.MessageState{SourceTxHash: "0x1"}
+	require.NoError(t, msg.SetStatus(types.Created))
+	require.NoError(t, msg.SetStatus(types.Attested))
+	return msg
+}
+
+// privateTestChain returns a chain using the stand-in rpcs, with the given private tx timeout
+func privateTestChain(t *testing.T, publicURL, privateURL, method string, timeout time.Duration) *Ethereum {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	e, err := NewChain("ethereum", &ChainConfig{
+		RPC:                    publicURL,
+		ChainID:                1,
+		MessageTransmitter:     testTransmitter.Hex(),
+		Confirmations:          1,
+		Polling:                true,
+		PollInterval:           1,
+		PollRange:              2,
+		BroadcastRetries:       1,
+		BroadcastRetryInterval: 1,
+		MinMintAmount:          1,
+		PrivateRPC:             privateURL,
+		PrivateRPCMethod:       method,
+		PrivateTxTimeout:       1,
+		MinterPrivateKey:       hex.EncodeToString(crypto.FromECDSA(key)),
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.InitializeClients(context.Background(), log.NewNopLogger()))
+	t.Cleanup(func() { _ = e.CloseClients() })
+
+	e.private.timeout = timeout
+	return e
+}
+
+func TestPrivateSubmitterMethod(t *testing.T) {
+	_, err := newPrivateSubmitter("http://localhost", "eth_sendBundle", 0)
+	require.Error(t, err)
+
+	for _, method := range []string{PrivateTxMethodFlashbots, PrivateTxMethodRaw} {
+		t.Run(method, func(t *testing.T) {
+			service := &fakeTxService{}
+			url := fakeTxServer(t, service)
+			e := privateTestChain(t, url, url, method, time.Hour)
+
+			tx := signedTestTx(t, 7)
+			pending, err := e.sendPrivateTx(context.Background(), log.NewNopLogger(), attestedTestMsg(t), tx, 1)
+			require.NoError(t, err)
+			require.True(t, pending)
+
+			raw, err := tx.MarshalBinary()
+			require.NoError(t, err)
+			private, public := service.received()
+			if method == PrivateTxMethodFlashbots {
+				require.Equal(t, []string{hexutil.Encode(raw)}, private)
+				require.Empty(t, public)
+			} else {
+				require.Empty(t, private)
+				require.Equal(t, []string{hexutil.Encode(raw)}, public)
+			}
+
+			// the pending private tx is accounted for in the next nonce
+			require.Equal(t, uint64(8), e.private.nonce(5))
+			require.Equal(t, uint64(9), e.private.nonce(9))
+		})
+	}
+}
+
+func TestPrivateTxFallback(t *testing.T) {
+	t.Run("not mined", func(t *testing.T) {
+		publicService := &fakeTxService{mineOnSend: true}
+		privateService := &fakeTxService{}
+		e := privateTestChain(t, fakeTxServer(t, publicService), fakeTxServer(t, privateService), PrivateTxMethodFlashbots, 10*time.Millisecond)
+
+		msg := attestedTestMsg(t)
+		tx := signedTestTx(t, 0)
+		pending, err := e.sendPrivateTx(context.Background(), log.NewNopLogger(), msg, tx, 1)
+		require.NoError(t, err)
+		require.True(t, pending)
+		// the msg is not complete before its tx is mined
+		require.Equal(t, types.Attested, msg.Status())
+
+		raw, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			_, public := publicService.received()
+			return len(public) == 1 && public[0] == hexutil.Encode(raw)
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool { return msg.Status() == types.Complete }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("mined", func(t *testing.T) {
+		tx := signedTestTx(t, 0)
+		publicService := &fakeTxService{mined: map[common.Hash]bool{tx.Hash(): true}}
+		privateService := &fakeTxService{}
+		e := privateTestChain(t, fakeTxServer(t, publicService), fakeTxServer(t, privateService), PrivateTxMethodFlashbots, 10*time.Millisecond)
+
+		msg := attestedTestMsg(t)
+		e.fallbackToPublic(context.Background(), log.NewNopLogger(), msg, tx, 1)
+		_, public := publicService.received()
+		require.Empty(t, public)
+		require.Equal(t, types.Complete, msg.Status())
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		tx := signedTestTx(t, 0)
+		publicService := &fakeTxService{reverted: map[common.Hash]bool{tx.Hash(): true}}
+		privateService := &fakeTxService{}
+		e := privateTestChain(t, fakeTxServer(t, publicService), fakeTxServer(t, privateService), PrivateTxMethodFlashbots, 10*time.Millisecond)
+
+		msg := attestedTestMsg(t)
+		e.fallbackToPublic(context.Background(), log.NewNopLogger(), msg, tx, 1)
+		_, public := publicService.received()
+		require.Empty(t, public)
+		require.Equal(t, types.Failed, msg.Status())
+		require.Contains(t, msg.History[len(msg.History)-1].Error, "reverted")
+	})
+
+	t.Run("public broadcast fails", func(t *testing.T) {
+		publicService := &fakeTxService{sendErr: errors.New("insufficient funds for gas")}
+		privateService := &fakeTxService{}
+		e := privateTestChain(t, fakeTxServer(t, publicService), fakeTxServer(t, privateService), PrivateTxMethodFlashbots, 10*time.Millisecond)
+
+		msg := attestedTestMsg(t)
+		tx := signedTestTx(t, 4)
+		pending, err := e.sendPrivateTx(context.Background(), log.NewNopLogger(), msg, tx, 1)
+		require.NoError(t, err)
+		require.True(t, pending)
+		require.Equal(t, uint64(5), e.private.nonce(0))
+
+		require.Eventually(t, func() bool { return msg.Status() == types.Failed }, 5*time.Second, 10*time.Millisecond)
+		require.Contains(t, msg.History[len(msg.History)-1].Error, "insufficient funds")
+		// the nonce of the lost tx is reused
+		require.Equal(t, uint64(0), e.private.nonce(0))
+	})
+
+	t.Run("private rpc unreachable", func(t *testing.T) {
+		publicService := &fakeTxService{}
+		unreachable := httptest.NewServer(rpc.NewServer())
+		unreachable.Close()
+		e := privateTestChain(t, fakeTxServer(t, publicService), unreachable.URL, PrivateTxMethodFlashbots, time.Hour)
+
+		pending, err := e.sendPrivateTx(context.Background(), log.NewNopLogger(), attestedTestMsg(t), signedTestTx(t, 0), 1)
+		require.NoError(t, err)
+		require.False(t, pending)
+		_, public := publicService.received()
+		require.Len(t, public, 1)
+		// nothing is pending privately
+		require.Equal(t, uint64(3), e.private.nonce(3))
+	})
+}
@@ -0,0 +1,120 @@
+package ethereum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/ethereum/contracts"
+)
+
+// revertNonceAlreadyUsed is the receiveMessage revert reason of a message that was already minted
+const revertNonceAlreadyUsed = "Nonce already used"
+
+// permanentReverts are the receiveMessage revert reasons that fail the same way on every retry. Other reverts,
+// e.g. "Pausable: paused", may succeed later.
+var permanentReverts = []string{
+	"Invalid attestation length",
+	"Invalid signature",
+	"Invalid destination domain",
+	"Invalid caller for message",
+	// also "Invalid message version" and "Invalid message: too short"
+	"Invalid message",
+	"Invalid burn message",
+	"Invalid mint recipient",
+	"Mint token not supported",
+	"Remote TokenMessenger unsupported",
+	"Message expired",
+}
+
+// RevertError is a receiveMessage revert decoded from a simulation
+type RevertError struct {
+	Reason string
+	// Permanent reverts fail the same way on every retry, e.g. an invalid attestation or destination caller
+	Permanent bool
+}
+
+func (e *RevertError) Error() string {
+	if e.Permanent {
+		return fmt.Sprintf("receiveMessage reverts permanently: %s", e.Reason)
+	}
+	return fmt.Sprintf("receiveMessage reverts: %s", e.Reason)
+}
+
+// NonceAlreadyUsed returns true if the message was already minted
+func (e *RevertError) NonceAlreadyUsed() bool {
+	return e.Reason == revertNonceAlreadyUsed
+}
+
+// newRevertError classifies a revert reason
+func newRevertError(reason string) *RevertError {
+	for _, permanent := range permanentReverts {
+		if strings.HasPrefix(reason, permanent) {
+			return &RevertError{Reason: reason, Permanent: true}
+		}
+	}
+	return &RevertError{Reason: reason}
+}
+
+// decodeRevert returns the revert of a failed eth_call as a *RevertError, other errors (e.g. an unreachable rpc)
+// are returned as they are
+func decodeRevert(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// the revert data is the abi encoded Error(string)
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if bz, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(bz); unpackErr == nil {
+					return newRevertError(reason)
+				}
+			}
+		}
+	}
+
+	// some rpcs only return the reason in the message
+	if reason, ok := strings.CutPrefix(err.Error(), "execution reverted: "); ok {
+		return newRevertError(reason)
+	}
+	if err.Error() == "execution reverted" {
+		return newRevertError("unknown reason")
+	}
+	return err
+}
+
+// rawCaller is the eth_call of a generated contract binding
+type rawCaller interface {
+	Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error
+}
+
+// simulateReceiveMessage calls receiveMessage with eth_call, without signing or sending a tx
+func simulateReceiveMessage(caller rawCaller, opts *bind.CallOpts, message, attestation []byte) error {
+	var out []interface{}
+	return decodeRevert(caller.Call(opts, &out, "receiveMessage", message, attestation))
+}
+
+// transmitterV1 adds receiveMessage simulation to the V1 MessageTransmitter binding
+type transmitterV1 struct {
+	*contracts.MessageTransmitter
+}
+
+func (t transmitterV1) SimulateReceiveMessage(opts *bind.CallOpts, message, attestation []byte) error {
+	return simulateReceiveMessage(&contracts.MessageTransmitterCallerRaw{Contract: &t.MessageTransmitterCaller}, opts, message, attestation)
+}
+
+// transmitterV2 adds receiveMessage simulation to the V2 MessageTransmitter binding
+type transmitterV2 struct {
+	*contracts.MessageTransmitterV2
+}
+
+func (t transmitterV2) SimulateReceiveMessage(opts *bind.CallOpts, message, attestation []byte) error {
+	return simulateReceiveMessage(&contracts.MessageTransmitterV2CallerRaw{Contract: &t.MessageTransmitterV2Caller}, opts, message, attestation)
+}
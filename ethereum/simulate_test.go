@@ -0,0 +1,73 @@
+package ethereum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// revertDataError is an eth_call error carrying abi encoded revert data, like the ones returned by the rpc client
+type revertDataError struct {
+	data string
+}
+
+func (e revertDataError) Error() string          { return "execution reverted" }
+func (e revertDataError) ErrorData() interface{} { return e.data }
+
+func revertData(t *testing.T, reason string) string {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	bz, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	// Error(string) selector
+	return hexutil.Encode(append([]byte{0x08, 0xc3, 0x79, 0xa0}, bz...))
+}
+
+// fakeCaller returns a fixed eth_call error
+type fakeCaller struct {
+	method string
+	err    error
+}
+
+func (c *fakeCaller) Call(_ *bind.CallOpts, _ *[]interface{}, method string, _ ...interface{}) error {
+	c.method = method
+	return c.err
+}
+
+func TestSimulateReceiveMessage(t *testing.T) {
+	caller := &fakeCaller{}
+	require.NoError(t, simulateReceiveMessage(caller, &bind.CallOpts{}, nil, nil))
+	require.Equal(t, "receiveMessage", caller.method)
+
+	tests := []struct {
+		name      string
+		err       error
+		reason    string
+		permanent bool
+	}{
+		{"revert data", revertDataError{revertData(t, "Invalid signature: not attester")}, "Invalid signature: not attester", true},
+		{"reason in message", errors.New("execution reverted: Invalid caller for message"), "Invalid caller for message", true},
+		{"paused", revertDataError{revertData(t, "Pausable: paused")}, "Pausable: paused", false},
+		{"no reason", errors.New("execution reverted"), "unknown reason", false},
+		{"already minted", errors.New("execution reverted: Nonce already used"), revertNonceAlreadyUsed, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := simulateReceiveMessage(&fakeCaller{err: tc.err}, &bind.CallOpts{}, nil, nil)
+			var revert *RevertError
+			require.ErrorAs(t, err, &revert)
+			require.Equal(t, tc.reason, revert.Reason)
+			require.Equal(t, tc.permanent, revert.Permanent)
+			require.Equal(t, tc.reason == revertNonceAlreadyUsed, revert.NonceAlreadyUsed())
+		})
+	}
+
+	// errors reaching the rpc are not reverts, the broadcast is retried
+	err := simulateReceiveMessage(&fakeCaller{err: errors.New("connection refused")}, &bind.CallOpts{}, nil, nil)
+	var revert *RevertError
+	require.False(t, errors.As(err, &revert))
+}
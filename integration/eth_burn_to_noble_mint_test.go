@@ -73,8 +73,9 @@ func TestEthBurnToNobleMint(t *testing.T) {
 
 	processingQueue := make(chan *types.TxState, 10)
 
-	go ethChain.StartListener(ctx, a.Logger, processingQueue, false, 0)
-	go cmd.StartProcessor(ctx, a, registeredDomains, processingQueue, sequenceMap, nil)
+	go ethChain.StartListener(ctx, a.Logger, processingQueue, false, 0, nil)
+	go cmd.StartProcessor(ctx, a, registeredDomains, processingQueue, nil)
+	go cmd.StartBroadcaster(ctx, a, nobleChain, processingQueue, sequenceMap, nil)
 
 	_, _, generatedWallet := testdata.KeyTestPubAddr()
 	destAddress, _ := bech32.ConvertAndEncode("noble", generatedWallet)
@@ -79,8 +79,9 @@ func TestNobleBurnToEthMint(t *testing.T) {
 
 	processingQueue := make(chan *types.TxState, 10)
 
-	go nobleChain.StartListener(ctx, a.Logger, processingQueue, false, 0)
-	go cmd.StartProcessor(ctx, a, registeredDomains, processingQueue, sequenceMap, nil)
+	go nobleChain.StartListener(ctx, a.Logger, processingQueue, false, 0, nil)
+	go cmd.StartProcessor(ctx, a, registeredDomains, processingQueue, nil)
+	go cmd.StartBroadcaster(ctx, a, ethChain, processingQueue, sequenceMap, nil)
 
 	ethDestinationAddress, _, err := generateEthWallet()
 	require.NoError(t, err)
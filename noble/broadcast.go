@@ -64,21 +64,32 @@ func (n *Noble) Broadcast(
 	// build txn
 	txBuilder := sdkContext.TxConfig.NewTxBuilder()
 
+	maxRetries, retryIntervalSeconds := n.broadcastRetries()
+
 	// sign and broadcast txn
-	for attempt := 1; attempt <= n.maxRetries; attempt++ {
-		err := n.attemptBroadcast(ctx, logger, msgs, sequenceMap, sdkContext, txBuilder)
+	var lastErr error
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		err := n.attemptBroadcast(ctx, logger, msgs, sequenceMap, sdkContext, txBuilder, attempt)
 		if err == nil {
 			return nil
 		}
+		lastErr = err
 
 		// Log retry information
-		logger.Error(fmt.Sprintf("Broadcasting to noble failed. Attempt %d/%d Retrying...", attempt, n.maxRetries), "error", err, "interval_seconds", n.retryIntervalSeconds, "src-tx", msgs[0].SourceTxHash)
-		time.Sleep(time.Duration(n.retryIntervalSeconds) * time.Second)
+		logger.Error(fmt.Sprintf("Broadcasting to noble failed. Attempt %d/%d Retrying...", attempt, maxRetries), "error", err, "interval_seconds", retryIntervalSeconds, "src-tx", msgs[0].SourceTxHash)
+		timer := time.NewTimer(time.Duration(retryIntervalSeconds) * time.Second)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			// the messages were not broadcast, they stay attested
+			timer.Stop()
+			return fmt.Errorf("stopped retrying after %d attempts: %w", attempt, errors.Join(lastErr, ctx.Err()))
+		}
 	}
 
 	for _, msg := range msgs {
-		if msg.Status != types.Complete {
-			msg.Status = types.Failed
+		if msg.Status() != types.Complete && msg.Status() != types.RelayedByOther {
+			msg.SetBroadcastStatus(logger, types.Failed, maxRetries, lastErr)
 		}
 	}
 	if m != nil {
@@ -94,22 +105,41 @@ func (n *Noble) attemptBroadcast(
 	sequenceMap *types.SequenceMap,
 	sdkContext sdkclient.Context,
 	txBuilder sdkclient.TxBuilder,
+	attempt int,
 ) error {
+	// other relayers' unconfirmed receives, racing them only burns gas
+	var pending map[string]string
+	if n.watchMempool {
+		var err error
+		if pending, err = n.otherPendingReceives(ctx, sdkContext.TxConfig.TxDecoder()); err != nil {
+			logger.Error("Unable to check the mempool for other relayers' txs", "err", err)
+		}
+	}
+
 	var receiveMsgs []sdk.Msg
+	var broadcasting []*types.MessageState
 	for _, msg := range msgs {
-		used, err := n.cc.QueryUsedNonce(ctx, msg.SourceDomain, msg.Nonce)
+		used, err := n.cc().QueryUsedNonce(ctx, msg.SourceDomain, msg.Nonce)
 		if err != nil {
 			return fmt.Errorf("unable to query used nonce: %w", err)
 		}
 
 		if used {
-			msg.Status = types.Complete
+			msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
 			logger.Info(fmt.Sprintf("Noble cctp minter nonce %d already used.", msg.Nonce), "src-tx", msg.SourceTxHash)
 			continue
 		}
 
 		// check if another worker already broadcasted tx due to flush
-		if msg.Status == types.Complete {
+		if msg.Status() == types.Complete || msg.Status() == types.RelayedByOther {
+			continue
+		}
+
+		if txHash, ok := pending[msg.ReceiveKey()]; ok {
+			logger.Info(fmt.Sprintf("Message from %d with tx hash %s is received by unconfirmed tx %s of another relayer, skipping",
+				msg.SourceDomain, msg.SourceTxHash, txHash))
+			msg.DestTxHash = txHash
+			msg.SetBroadcastStatus(logger, types.RelayedByOther, attempt, nil)
 			continue
 		}
 
@@ -123,6 +153,7 @@ func (n *Noble) attemptBroadcast(
 			msg.MsgSentBytes,
 			attestationBytes,
 		))
+		broadcasting = append(broadcasting, msg)
 
 		logger.Info(fmt.Sprintf(
 			"Broadcasting message from %d to %d: with source tx hash %s",
@@ -190,7 +221,7 @@ func (n *Noble) attemptBroadcast(
 		return fmt.Errorf("failed to proto encode tx: %w", err)
 	}
 
-	rpcResponse, err := n.cc.RPCClient.BroadcastTxSync(ctx, txBytes)
+	rpcResponse, err := n.cc().RPCClient.BroadcastTxSync(ctx, txBytes)
 	if err != nil {
 		return err
 	}
@@ -206,12 +237,12 @@ func (n *Noble) attemptBroadcast(
 	}
 
 	// Tx was successfully broadcast
-	for _, msg := range msgs {
+	for _, msg := range broadcasting {
 		msg.DestTxHash = rpcResponse.Hash.String()
-		msg.Status = types.Complete
+		msg.SetBroadcastStatus(logger, types.Complete, attempt, nil)
 	}
 
-	logger.Info(fmt.Sprintf("Successfully broadcast %s to Noble.  Tx hash: %s", msgs[0].SourceTxHash, msgs[0].DestTxHash))
+	logger.Info(fmt.Sprintf("Successfully broadcast %s to Noble.  Tx hash: %s", broadcasting[0].SourceTxHash, broadcasting[0].DestTxHash))
 
 	return nil
 }
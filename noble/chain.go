@@ -16,49 +16,70 @@ import (
 	"cosmossdk.io/log"
 
 	"github.com/strangelove-ventures/noble-cctp-relayer/cosmos"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 var _ types.Chain = (*Noble)(nil)
 
+// DefaultDomain is noble's CCTP domain
+const DefaultDomain types.Domain = 4
+
 type Noble struct {
 	// from config
-	chainID               string
-	rpcURL                string
-	privateKey            *secp256k1.PrivKey
-	minterAddress         string
-	accountNumber         uint64
-	startBlock            uint64
-	lookbackPeriod        uint64
-	workers               uint32
-	gasLimit              uint64
-	txMemo                string
-	maxRetries            int
-	retryIntervalSeconds  int
-	blockQueueChannelSize uint64
-	minAmount             uint64
+	chainID              string
+	domain               types.Domain
+	rpcURLs              []string
+	privateKey           *secp256k1.PrivKey
+	minterAddress        string
+	accountNumber        uint64
+	startBlock           uint64
+	lookbackPeriod       uint64
+	workers              uint32
+	blockQueryRetries    int
+	archiveRPCURL        string
+	gasLimit             uint64
+	txMemo               string
+	maxRetries           int
+	retryIntervalSeconds int
+	minAmount            uint64
+	watchMempool         bool
 
 	mu sync.Mutex
 
-	cc *cosmos.CosmosProvider
+	endpoints *relayer.EndpointPool[*cosmos.CosmosProvider]
+	archive   *cosmos.CosmosProvider
 
 	latestBlock      uint64
 	lastFlushedBlock uint64
+
+	// listenerConnected is set while the websocket stream is subscribed, or the flush only listener is running
+	listenerConnected bool
+
+	// flushRequests holds a flush requested with RequestFlush until the flush mechanism runs it
+	flushRequests chan struct{}
+
+	// heights that could not be scanned after retrying
+	unscannableMu sync.Mutex
+	unscannable   map[uint64]*UnscannableHeight
 }
 
 func NewChain(
-	rpcURL string,
+	rpcURLs []string,
 	chainID string,
+	domain types.Domain,
 	privateKey string,
 	startBlock uint64,
 	lookbackPeriod uint64,
 	workers uint32,
+	blockQueryRetries int,
+	archiveRPCURL string,
 	gasLimit uint64,
 	txMemo string,
 	maxRetries int,
 	retryIntervalSeconds int,
-	blockQueueChannelSize uint64,
 	minAmount uint64,
+	watchMempool bool,
 ) (*Noble, error) {
 	keyBz, err := hex.DecodeString(privateKey)
 	if err != nil {
@@ -70,32 +91,41 @@ func NewChain(
 	address := privKey.PubKey().Address()
 	minterAddress := sdk.MustBech32ifyAddressBytes("noble", address)
 
+	if blockQueryRetries <= 0 {
+		blockQueryRetries = defaultBlockQueryRetries
+	}
+
 	return &Noble{
-		chainID:               chainID,
-		rpcURL:                rpcURL,
-		startBlock:            startBlock,
-		lookbackPeriod:        lookbackPeriod,
-		workers:               workers,
-		privateKey:            &privKey,
-		minterAddress:         minterAddress,
-		gasLimit:              gasLimit,
-		txMemo:                txMemo,
-		maxRetries:            maxRetries,
-		retryIntervalSeconds:  retryIntervalSeconds,
-		blockQueueChannelSize: blockQueueChannelSize,
-		minAmount:             minAmount,
+		chainID:              chainID,
+		domain:               domain,
+		rpcURLs:              rpcURLs,
+		startBlock:           startBlock,
+		lookbackPeriod:       lookbackPeriod,
+		workers:              workers,
+		blockQueryRetries:    blockQueryRetries,
+		archiveRPCURL:        archiveRPCURL,
+		unscannable:          make(map[uint64]*UnscannableHeight),
+		privateKey:           &privKey,
+		minterAddress:        minterAddress,
+		gasLimit:             gasLimit,
+		txMemo:               txMemo,
+		maxRetries:           maxRetries,
+		retryIntervalSeconds: retryIntervalSeconds,
+		minAmount:            minAmount,
+		watchMempool:         watchMempool,
+		flushRequests:        make(chan struct{}, 1),
 	}, nil
 }
 
 func (n *Noble) AccountInfo(ctx context.Context) (uint64, uint64, error) {
-	res, err := authtypes.NewQueryClient(n.cc).Account(ctx, &authtypes.QueryAccountRequest{
+	res, err := authtypes.NewQueryClient(n.cc()).Account(ctx, &authtypes.QueryAccountRequest{
 		Address: n.minterAddress,
 	})
 	if err != nil {
 		return 0, 0, fmt.Errorf("unable to query account for noble: %w", err)
 	}
 	var acc authtypes.AccountI
-	if err := n.cc.Cdc.InterfaceRegistry.UnpackAny(res.Account, &acc); err != nil {
+	if err := n.cc().Cdc.InterfaceRegistry.UnpackAny(res.Account, &acc); err != nil {
 		return 0, 0, fmt.Errorf("unable to unpack account for noble: %w", err)
 	}
 
@@ -107,7 +137,7 @@ func (n *Noble) Name() string {
 }
 
 func (n *Noble) Domain() types.Domain {
-	return 4
+	return n.domain
 }
 
 func (n *Noble) LatestBlock() uint64 {
@@ -123,6 +153,47 @@ func (n *Noble) SetLatestBlock(block uint64) {
 	n.mu.Unlock()
 }
 
+func (n *Noble) SetBroadcastRetries(maxRetries, retryIntervalSeconds int) {
+	n.mu.Lock()
+	n.maxRetries = maxRetries
+	n.retryIntervalSeconds = retryIntervalSeconds
+	n.mu.Unlock()
+}
+
+// RequestFlush makes the flush mechanism flush right away instead of waiting for the flush interval
+func (n *Noble) RequestFlush() error {
+	select {
+	case n.flushRequests <- struct{}{}:
+		return nil
+	default:
+		return errors.New("a flush is already pending")
+	}
+}
+
+func (n *Noble) broadcastRetries() (maxRetries, retryIntervalSeconds int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.maxRetries, n.retryIntervalSeconds
+}
+
+func (n *Noble) setListenerConnected(connected bool) {
+	n.mu.Lock()
+	n.listenerConnected = connected
+	n.mu.Unlock()
+}
+
+// Status reports no wallet balance, relaying to Noble is free
+func (n *Noble) Status() types.ChainStatus {
+	rpcReachable := n.endpoints != nil && n.endpoints.Healthy()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return types.ChainStatus{
+		RPCReachable:      rpcReachable,
+		ListenerConnected: n.listenerConnected,
+	}
+}
+
 func (n *Noble) LastFlushedBlock() uint64 {
 	return n.lastFlushedBlock
 }
@@ -157,19 +228,45 @@ func decodeDestinationCaller(input []byte) (string, error) {
 
 func (n *Noble) InitializeClients(ctx context.Context, logger log.Logger) error {
 	var err error
-	n.cc, err = cosmos.NewProvider(n.rpcURL)
+	n.endpoints, err = relayer.NewEndpointPool(logger, n.Name(), n.rpcURLs, cosmos.NewProvider, latestHeight)
 	if err != nil {
 		return fmt.Errorf("unable to build cosmos provider for noble: %w", err)
 	}
+
+	if n.archiveRPCURL != "" {
+		n.archive, err = cosmos.NewProvider(n.archiveRPCURL)
+		if err != nil {
+			return fmt.Errorf("unable to build cosmos provider for noble archive rpc: %w", err)
+		}
+	}
 	return nil
 }
 
+// latestHeight queries the latest height of a noble rpc, used to health check the rpc endpoints
+func latestHeight(ctx context.Context, cc *cosmos.CosmosProvider) (uint64, error) {
+	res, err := cc.RPCClient.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(res.SyncInfo.LatestBlockHeight), nil
+}
+
+// cc returns the cosmos provider of the healthiest rpc endpoint
+func (n *Noble) cc() *cosmos.CosmosProvider {
+	return n.endpoints.Client()
+}
+
 func (n *Noble) CloseClients() error {
-	if n.cc != nil && n.cc.RPCClient.IsRunning() {
-		err := n.cc.RPCClient.Stop()
-		if err != nil {
-			return fmt.Errorf("error stopping noble rpc client: %w", err)
+	if n.endpoints == nil {
+		return nil
+	}
+	var errs error
+	for _, cc := range n.endpoints.Clients() {
+		if cc.RPCClient.IsRunning() {
+			if err := cc.RPCClient.Stop(); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("error stopping noble rpc client: %w", err))
+			}
 		}
 	}
-	return nil
+	return errs
 }
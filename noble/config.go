@@ -10,28 +10,47 @@ import (
 
 var _ types.ChainConfig = (*ChainConfig)(nil)
 
-const defaultBlockQueueChannelSize = 1000000
-
 type ChainConfig struct {
 	RPC     string `yaml:"rpc"`
 	ChainID string `yaml:"chain-id"`
+	// Domain is noble's CCTP domain, DefaultDomain if unset
+	Domain types.Domain `yaml:"domain"`
+	// FallbackRPCs are used alongside RPC, the healthiest endpoint is used
+	FallbackRPCs []string `yaml:"fallback-rpcs"`
+	// ArchiveRPC is optional, heights that could not be scanned are retried against it
+	ArchiveRPC string `yaml:"archive-rpc"`
 
 	StartBlock     uint64 `yaml:"start-block"`
 	LookbackPeriod uint64 `yaml:"lookback-period"`
 	Workers        uint32 `yaml:"workers"`
 
+	// BlockQueryRetries is the number of times a failed block query is retried before the height is marked unscannable
+	BlockQueryRetries int `yaml:"block-query-retries"`
+
 	TxMemo                 string `yaml:"tx-memo"`
 	GasLimit               uint64 `yaml:"gas-limit"`
 	BroadcastRetries       int    `yaml:"broadcast-retries"`
 	BroadcastRetryInterval int    `yaml:"broadcast-retry-interval"`
 
+	// Deprecated: history is scanned in ranges of heights, the block queue no longer exists
 	BlockQueueChannelSize uint64 `yaml:"block-queue-channel-size"`
 
 	MinMintAmount uint64 `yaml:"min-mint-amount"`
 
+	// WatchMempool skips messages another relayer's unconfirmed tx is already receiving
+	WatchMempool bool `yaml:"watch-mempool"`
+
 	MinterPrivateKey string `yaml:"minter-private-key"`
 }
 
+// CCTPDomain returns the configured domain, or DefaultDomain if unset. Noble is never domain 0, which is Ethereum.
+func (c *ChainConfig) CCTPDomain() types.Domain {
+	if c.Domain == 0 {
+		return DefaultDomain
+	}
+	return c.Domain
+}
+
 func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 	envKey := strings.ToUpper(name) + "_PRIV_KEY"
 	privKey := os.Getenv(envKey)
@@ -45,17 +64,20 @@ func (c *ChainConfig) Chain(name string) (types.Chain, error) {
 	}
 
 	return NewChain(
-		c.RPC,
+		types.Endpoints(c.RPC, c.FallbackRPCs),
 		c.ChainID,
+		c.CCTPDomain(),
 		c.MinterPrivateKey,
 		c.StartBlock,
 		c.LookbackPeriod,
 		c.Workers,
+		c.BlockQueryRetries,
+		c.ArchiveRPC,
 		c.GasLimit,
 		c.TxMemo,
 		c.BroadcastRetries,
 		c.BroadcastRetryInterval,
-		c.BlockQueueChannelSize,
 		c.MinMintAmount,
+		c.WatchMempool,
 	)
 }
@@ -0,0 +1,56 @@
+package noble
+
+import (
+	"context"
+	"fmt"
+
+	cctptypes "github.com/circlefin/noble-cctp/x/cctp/types"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// ContractStates queries the state of the x/cctp module. It is paused if either sending and receiving messages, or
+// burning and minting is paused.
+func (n *Noble) ContractStates(ctx context.Context) ([]types.ContractState, error) {
+	cc := n.cc()
+	state, err := cctpState(ctx, cctptypes.NewQueryClient(cc))
+	n.endpoints.Report(cc, err)
+	if err != nil {
+		return nil, err
+	}
+	return []types.ContractState{state}, nil
+}
+
+func cctpState(ctx context.Context, qc cctptypes.QueryClient) (types.ContractState, error) {
+	state := types.ContractState{Contract: "x/cctp", ExpectedVersion: types.MessageVersionV1}
+
+	messagesPaused, err := qc.SendingAndReceivingMessagesPaused(ctx, &cctptypes.QueryGetSendingAndReceivingMessagesPausedRequest{})
+	if err != nil {
+		return state, fmt.Errorf("unable to query whether x/cctp messages are paused: %w", err)
+	}
+	mintingPaused, err := qc.BurningAndMintingPaused(ctx, &cctptypes.QueryGetBurningAndMintingPausedRequest{})
+	if err != nil {
+		return state, fmt.Errorf("unable to query whether x/cctp minting is paused: %w", err)
+	}
+	state.Paused = messagesPaused.Paused.Paused || mintingPaused.Paused.Paused
+
+	localDomain, err := qc.LocalDomain(ctx, &cctptypes.QueryLocalDomainRequest{})
+	if err != nil {
+		return state, fmt.Errorf("unable to query the x/cctp local domain: %w", err)
+	}
+	state.LocalDomain = types.Domain(localDomain.DomainId)
+
+	version, err := qc.LocalMessageVersion(ctx, &cctptypes.QueryLocalMessageVersionRequest{})
+	if err != nil {
+		return state, fmt.Errorf("unable to query the x/cctp message version: %w", err)
+	}
+	state.Version = version.Version
+
+	maxMessageBodySize, err := qc.MaxMessageBodySize(ctx, &cctptypes.QueryGetMaxMessageBodySizeRequest{})
+	if err != nil {
+		return state, fmt.Errorf("unable to query the x/cctp max message body size: %w", err)
+	}
+	state.MaxMessageBodySize = maxMessageBodySize.Amount.Amount
+
+	return state, nil
+}
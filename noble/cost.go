@@ -0,0 +1,13 @@
+package noble
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// EstimateMintCost returns a zero fee, MsgReceiveMessage txs are broadcast to noble without fees
+func (n *Noble) EstimateMintCost(context.Context, *types.MessageState) (types.MintCost, error) {
+	return types.MintCost{Fee: big.NewInt(0)}, nil
+}
@@ -0,0 +1,271 @@
+package noble
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/cosmos"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+const (
+	// history is scanned with TxSearch over ranges of heights, filtered by the MessageSent event
+	historyRangeInitial = uint64(1000)
+	historyRangeMin     = uint64(1)
+	historyRangeMax     = uint64(20000)
+
+	// txSearchPerPage is the max page size of the TxSearch rpc
+	txSearchPerPage = 100
+
+	defaultBlockQueryRetries = 5
+	// failed block queries are retried with exponential backoff, starting at 1 second
+	maxBlockQueryBackoff = 1 * time.Minute
+	// archiveRetryInterval is how often unscannable heights are retried against the archive rpc
+	archiveRetryInterval = 5 * time.Minute
+)
+
+// UnscannableHeight is a height that could not be queried after retrying, e.g. because the rpc pruned it
+type UnscannableHeight struct {
+	Height   uint64    `json:"height"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	Updated  time.Time `json:"updated"`
+}
+
+// scanHistory scans the heights from start up to and including end for MessageSent txs and passes them to the
+// processingQueue. The heights are split into one segment per worker, the segments are scanned concurrently.
+func (n *Noble) scanHistory(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	start, end uint64,
+) {
+	if start > end {
+		return
+	}
+
+	workers := max(uint64(n.workers), 1)
+	segment := (end - start + workers) / workers
+
+	var wg sync.WaitGroup
+	for from := start; from <= end; from += segment {
+		to := min(from+segment-1, end)
+		wg.Add(1)
+		go func(from, to uint64) {
+			defer wg.Done()
+			n.scanRange(ctx, logger, processingQueue, from, to)
+		}(from, to)
+	}
+	wg.Wait()
+}
+
+// scanRange scans the heights from start up to and including end with as few TxSearch calls as possible.
+// The number of heights per query adapts to the rpc: it grows while queries succeed and halves when they fail with a
+// range error. Other errors are retried over the same range with backoff. Ranges that keep failing are split into
+// single heights, each retried on its own before it is marked unscannable.
+func (n *Noble) scanRange(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	start, end uint64,
+) {
+	rangeSize := relayer.NewRangeSize(historyRangeInitial, historyRangeMin, historyRangeMax)
+
+	// attempt counts the failed queries of the current range, reset once the scan moves past it or splits it
+	attempt := 0
+	for start <= end {
+		if ctx.Err() != nil {
+			return
+		}
+
+		to := min(start+rangeSize.Get()-1, end)
+		cc := n.cc()
+		txs, err := searchMessageSentTxs(ctx, cc, start, to)
+		n.endpoints.Report(cc, err)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if isRangeError(err) && rangeSize.Shrink() {
+				logger.Debug(fmt.Sprintf("Unable to query Noble blocks %d to %d. Retrying with ranges of %d blocks", start, to, rangeSize.Get()), "err", err)
+				continue
+			}
+
+			attempt++
+			if attempt > n.blockQueryRetries && to > start {
+				// isolate the heights that fail, a transient error must not mark a whole range unscannable
+				logger.Error(fmt.Sprintf("Unable to query Noble blocks %d to %d after %d attempts. Retrying each block", start, to, attempt), "err", err)
+				rangeSize = relayer.NewRangeSize(historyRangeMin, historyRangeMin, historyRangeMax)
+				attempt = 0
+				continue
+			}
+			if attempt > n.blockQueryRetries {
+				logger.Error(fmt.Sprintf("Unable to query Noble block %d after %d attempts. Marking unscannable", start, attempt), "err", err)
+				n.markUnscannable(start, attempt, err)
+				attempt = 0
+				start++
+				continue
+			}
+
+			backoff := min(time.Second<<(attempt-1), maxBlockQueryBackoff)
+			logger.Error(fmt.Sprintf("Unable to query Noble blocks %d to %d. Retrying in %v (attempt %d)", start, to, backoff, attempt), "err", err)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+			continue
+		}
+		attempt = 0
+
+		logger.Debug(fmt.Sprintf("Scanned Noble blocks %d to %d: found %d tx(s)", start, to, len(txs)))
+		for _, tx := range txs {
+			enqueueTx(logger, processingQueue, tx)
+		}
+
+		start = to + 1
+		rangeSize.Grow()
+	}
+}
+
+// rangeErrors are substrings of the errors rpcs return when a TxSearch spans too many heights or results, or
+// includes pruned heights. Smaller ranges either succeed or isolate the heights that can not be queried.
+var rangeErrors = []string{
+	"timed out",
+	"timeout",
+	"deadline exceeded",
+	"response size exceeded",
+	"too large",
+	"too many",
+	"limit exceeded",
+	"is not available",
+	"lowest height",
+}
+
+// isRangeError returns whether err means the TxSearch should be retried over a smaller range
+func isRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, rangeErr := range rangeErrors {
+		if strings.Contains(msg, rangeErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchMessageSentTxs returns all txs from start up to and including end that emitted a MessageSent event,
+// following TxSearch's pagination
+func searchMessageSentTxs(ctx context.Context, cc *cosmos.CosmosProvider, start, end uint64) ([]*ctypes.ResultTx, error) {
+	query := fmt.Sprintf("%s EXISTS AND tx.height>=%d AND tx.height<=%d", messageSentEventKey, start, end)
+
+	perPage := txSearchPerPage
+
+	var txs []*ctypes.ResultTx
+	for page := 1; ; page++ {
+		res, err := cc.RPCClient.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, res.Txs...)
+		if len(txs) >= res.TotalCount || len(res.Txs) == 0 {
+			return txs, nil
+		}
+	}
+}
+
+// markUnscannable records a height that could not be queried after retrying
+func (n *Noble) markUnscannable(height uint64, attempts int, err error) {
+	n.unscannableMu.Lock()
+	defer n.unscannableMu.Unlock()
+
+	if u, ok := n.unscannable[height]; ok {
+		u.Attempts += attempts
+		u.Error = err.Error()
+		u.Updated = time.Now()
+		return
+	}
+	n.unscannable[height] = &UnscannableHeight{
+		Height:   height,
+		Attempts: attempts,
+		Error:    err.Error(),
+		Updated:  time.Now(),
+	}
+}
+
+// UnscannableHeights returns the heights that could not be queried after retrying, lowest first
+func (n *Noble) UnscannableHeights() []UnscannableHeight {
+	n.unscannableMu.Lock()
+	defer n.unscannableMu.Unlock()
+
+	heights := make([]UnscannableHeight, 0, len(n.unscannable))
+	for _, u := range n.unscannable {
+		heights = append(heights, *u)
+	}
+	slices.SortFunc(heights, func(a, b UnscannableHeight) int {
+		return cmp.Compare(a.Height, b.Height)
+	})
+	return heights
+}
+
+// retryUnscannableHeights queries the unscannable heights against the archive rpc every archiveRetryInterval.
+// Heights that can be queried are no longer unscannable.
+func (n *Noble) retryUnscannableHeights(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+) {
+	if n.archive == nil {
+		return
+	}
+
+	for {
+		timer := time.NewTimer(archiveRetryInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		n.rescanUnscannableHeights(ctx, logger, processingQueue, n.archive)
+	}
+}
+
+// rescanUnscannableHeights queries every unscannable height once against cc
+func (n *Noble) rescanUnscannableHeights(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	cc *cosmos.CosmosProvider,
+) {
+	for _, u := range n.UnscannableHeights() {
+		txs, err := searchMessageSentTxs(ctx, cc, u.Height, u.Height)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Unable to rescan unscannable Noble block %d", u.Height), "err", err)
+			n.markUnscannable(u.Height, 1, err)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Rescanned unscannable Noble block %d: found %d tx(s)", u.Height, len(txs)))
+		for _, tx := range txs {
+			enqueueTx(logger, processingQueue, tx)
+		}
+
+		n.unscannableMu.Lock()
+		delete(n.unscannable, u.Height)
+		n.unscannableMu.Unlock()
+	}
+}
@@ -0,0 +1,196 @@
+package noble
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/cosmos"
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// fakeRPC serves TxSearch for MessageSent txs at the given heights, failing queries over more than maxRange heights
+// and queries that include a pruned height
+type fakeRPC struct {
+	rpcclient.Client
+
+	mu       sync.Mutex
+	maxRange uint64
+	pruned   map[int64]bool
+	txs      []*ctypes.ResultTx
+	calls    int
+	// unreachable fails the next queries as if the rpc could not be reached
+	unreachable int
+	// ranges are the sizes of the queried ranges
+	ranges []uint64
+}
+
+func (f *fakeRPC) TxSearch(_ context.Context, query string, _ bool, page, perPage *int, _ string) (*ctypes.ResultTxSearch, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+
+	var start, end int64
+	if _, err := fmt.Sscanf(query, messageSentEventKey+" EXISTS AND tx.height>=%d AND tx.height<=%d", &start, &end); err != nil {
+		return nil, err
+	}
+	f.ranges = append(f.ranges, uint64(end-start+1))
+	if f.unreachable > 0 {
+		f.unreachable--
+		return nil, errors.New("dial tcp 127.0.0.1:26657: connect: connection refused")
+	}
+	if uint64(end-start+1) > f.maxRange {
+		return nil, errors.New("timed out")
+	}
+	for height := start; height <= end; height++ {
+		if f.pruned[height] {
+			return nil, fmt.Errorf("height %d is not available", height)
+		}
+	}
+
+	var matching []*ctypes.ResultTx
+	for _, tx := range f.txs {
+		if tx.Height >= start && tx.Height <= end {
+			matching = append(matching, tx)
+		}
+	}
+
+	from := min((*page-1)**perPage, len(matching))
+	to := min(from+*perPage, len(matching))
+	return &ctypes.ResultTxSearch{Txs: matching[from:to], TotalCount: len(matching)}, nil
+}
+
+func messageSentTx(height int64) *ctypes.ResultTx {
+	msg := make([]byte, 116+132)
+	binary.BigEndian.PutUint32(msg[4:], 4)
+	binary.BigEndian.PutUint64(msg[12:], uint64(height))
+
+	return &ctypes.ResultTx{
+		Hash:   binary.BigEndian.AppendUint64(nil, uint64(height)),
+		Height: height,
+		TxResult: abci.ExecTxResult{
+			Events: []abci.Event{{
+				Type:       "circle.cctp.v1.MessageSent",
+				Attributes: []abci.EventAttribute{{Key: "message", Value: "\"" + base64.StdEncoding.EncodeToString(msg) + "\""}},
+			}},
+		},
+	}
+}
+
+func newFakePool(t *testing.T, rpc rpcclient.Client) *relayer.EndpointPool[*cosmos.CosmosProvider] {
+	pool, err := relayer.NewEndpointPool(
+		log.NewNopLogger(),
+		"noble",
+		[]string{"http://localhost:26657"},
+		func(string) (*cosmos.CosmosProvider, error) { return &cosmos.CosmosProvider{RPCClient: rpc}, nil },
+		latestHeight,
+	)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestScanHistory(t *testing.T) {
+	rpc := &fakeRPC{maxRange: 300}
+	for height := int64(1); height <= 5000; height += 20 {
+		rpc.txs = append(rpc.txs, messageSentTx(height))
+	}
+
+	n := &Noble{workers: 2, endpoints: newFakePool(t, rpc)}
+	processingQueue := make(chan *types.TxState, len(rpc.txs))
+
+	n.scanHistory(context.Background(), log.NewNopLogger(), processingQueue, 1, 5000)
+
+	require.Len(t, processingQueue, len(rpc.txs))
+	seen := make(map[uint64]bool)
+	for len(processingQueue) > 0 {
+		tx := <-processingQueue
+		require.Len(t, tx.Msgs, 1)
+		seen[tx.Msgs[0].Nonce] = true
+	}
+	require.Len(t, seen, len(rpc.txs))
+
+	// ranges shrink to what the rpc can serve instead of querying every height
+	require.Less(t, rpc.calls, 100)
+}
+
+func TestScanHistoryUnscannableHeights(t *testing.T) {
+	rpc := &fakeRPC{maxRange: 100, pruned: map[int64]bool{50: true}}
+	for _, height := range []int64{10, 50, 90} {
+		rpc.txs = append(rpc.txs, messageSentTx(height))
+	}
+
+	n := &Noble{
+		workers:           1,
+		endpoints:         newFakePool(t, rpc),
+		blockQueryRetries: 1,
+		unscannable:       make(map[uint64]*UnscannableHeight),
+	}
+	processingQueue := make(chan *types.TxState, len(rpc.txs))
+
+	// the pruned height is retried once, then skipped so the scan can continue
+	n.scanHistory(context.Background(), log.NewNopLogger(), processingQueue, 1, 100)
+
+	require.Len(t, processingQueue, 2)
+	unscannable := n.UnscannableHeights()
+	require.Len(t, unscannable, 1)
+	require.Equal(t, uint64(50), unscannable[0].Height)
+	require.Equal(t, 2, unscannable[0].Attempts)
+
+	// an archive rpc that still has the height clears it
+	archive := &fakeRPC{maxRange: 100, txs: rpc.txs}
+	n.rescanUnscannableHeights(context.Background(), log.NewNopLogger(), processingQueue, &cosmos.CosmosProvider{RPCClient: archive})
+
+	require.Len(t, processingQueue, 3)
+	require.Empty(t, n.UnscannableHeights())
+}
+
+func TestScanHistoryRetriesUnreachableRPC(t *testing.T) {
+	rpc := &fakeRPC{maxRange: historyRangeInitial, unreachable: 1}
+	for _, height := range []int64{10, 500} {
+		rpc.txs = append(rpc.txs, messageSentTx(height))
+	}
+
+	n := &Noble{workers: 1, endpoints: newFakePool(t, rpc), blockQueryRetries: 1}
+	processingQueue := make(chan *types.TxState, len(rpc.txs))
+
+	n.scanHistory(context.Background(), log.NewNopLogger(), processingQueue, 1, 1000)
+
+	// the failed query is retried over the same range instead of shrinking it
+	require.Len(t, processingQueue, 2)
+	require.Equal(t, []uint64{1000, 1000}, rpc.ranges)
+}
+
+func TestScanHistorySplitsFailingRanges(t *testing.T) {
+	rpc := &fakeRPC{maxRange: historyRangeInitial, unreachable: 2}
+	for _, height := range []int64{1, 4} {
+		rpc.txs = append(rpc.txs, messageSentTx(height))
+	}
+
+	n := &Noble{
+		workers:           1,
+		endpoints:         newFakePool(t, rpc),
+		blockQueryRetries: 1,
+		unscannable:       make(map[uint64]*UnscannableHeight),
+	}
+	processingQueue := make(chan *types.TxState, len(rpc.txs))
+
+	n.scanHistory(context.Background(), log.NewNopLogger(), processingQueue, 1, 4)
+
+	// the range is split into single heights once its retries are used up, none of them is marked unscannable
+	require.Len(t, processingQueue, 2)
+	require.Empty(t, n.UnscannableHeights())
+	require.Equal(t, []uint64{4, 4, 1, 2, 1}, rpc.ranges)
+}
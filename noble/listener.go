@@ -5,21 +5,42 @@ import (
 	"fmt"
 	"time"
 
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+
 	"cosmossdk.io/log"
 
+	"github.com/strangelove-ventures/noble-cctp-relayer/cosmos"
 	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
 	"github.com/strangelove-ventures/noble-cctp-relayer/types"
 )
 
 var flushInterval time.Duration
 
+const (
+	// subscriber identifies the relayer's websocket subscriptions on the rpc
+	subscriber = "noble-cctp-relayer"
+	// messageSentQuery matches txs that emitted a cctp MessageSent event
+	messageSentEventKey = "circle.cctp.v1.MessageSent.message"
+	messageSentQuery    = "tm.event='Tx' AND " + messageSentEventKey + " EXISTS"
+	newBlockHeaderQuery = "tm.event='NewBlockHeader'"
+	// subscriptionCapacity is the buffer of the subscription channels, the rpc client drops events when they are full
+	subscriptionCapacity = 1000
+
+	resubscribeInterval = 5 * time.Second
+	// staleStreamBlocks is how far the latest height may get ahead of the last streamed block header before the
+	// stream is considered stalled
+	staleStreamBlocks = 5
+)
+
 func (n *Noble) StartListener(
 	ctx context.Context,
 	logger log.Logger,
 	processingQueue chan *types.TxState,
 	flushOnlyMode bool,
 	flushInterval_ time.Duration,
-) {
+	m *relayer.PromMetrics,
+) error {
 	logger = logger.With("chain", n.Name(), "chain_id", n.chainID, "domain", n.Domain())
 
 	flushInterval = flushInterval_
@@ -34,94 +55,180 @@ func (n *Noble) StartListener(
 
 	accountNumber, _, err := n.AccountInfo(ctx)
 	if err != nil {
-		panic(fmt.Errorf("unable to get account info for noble: %w", err))
+		return fmt.Errorf("unable to get account info for noble: %w", err)
 	}
 
 	n.accountNumber = accountNumber
 
-	// enqueue block heights
-	currentBlock := n.startBlock
-	lookback := n.lookbackPeriod
 	chainTip := n.LatestBlock()
 
-	if n.blockQueueChannelSize == 0 {
-		n.blockQueueChannelSize = defaultBlockQueueChannelSize
-	}
-	blockQueue := make(chan uint64, n.blockQueueChannelSize)
-
 	if !flushOnlyMode {
+		// stream new txs, only heights missed by the stream are scanned
+		go n.streamTxs(ctx, logger, processingQueue, chainTip+1, m)
+
 		// history
-		currentBlock -= lookback
-		for currentBlock <= chainTip {
-			blockQueue <- currentBlock
-			currentBlock++
+		historyStart := n.startBlock - n.lookbackPeriod
+		go func() {
+			logger.Info(fmt.Sprintf("Scanning Noble history from %d to %d", historyStart, chainTip))
+			n.scanHistory(ctx, logger, processingQueue, historyStart, chainTip)
+			logger.Info("Finished scanning Noble history")
+		}()
+	}
+
+	go n.flushMechanism(ctx, logger, processingQueue, flushOnlyMode)
+	if flushOnlyMode {
+		n.setListenerConnected(true)
+		defer n.setListenerConnected(false)
+	}
+
+	go n.retryUnscannableHeights(ctx, logger, processingQueue)
+
+	<-ctx.Done()
+	return nil
+}
+
+// streamTxs subscribes to txs emitting a MessageSent event over the rpc websocket and passes them to the
+// processingQueue. Block headers are subscribed to as well to detect heights the stream missed, e.g. while the
+// websocket was reconnecting or stalled. Missed heights are scanned in the background.
+func (n *Noble) streamTxs(
+	ctx context.Context,
+	logger log.Logger,
+	processingQueue chan *types.TxState,
+	nextHeight uint64,
+	m *relayer.PromMetrics,
+) {
+	d := fmt.Sprint(n.Domain())
+	defer n.setListenerConnected(false)
+
+	// queueMissed scans the heights from nextHeight up to and including height
+	queueMissed := func(height uint64, reason string) {
+		if height < nextHeight {
+			return
 		}
+		missed := height - nextHeight + 1
+		logger.Info(fmt.Sprintf("Scanning %d block(s) from %d to %d missed by the websocket stream: %s", missed, nextHeight, height, reason))
+		if m != nil {
+			m.IncMissedHeights(n.Name(), d, missed)
+		}
+		go n.scanHistory(ctx, logger, processingQueue, nextHeight, height)
+		nextHeight = height + 1
+	}
 
-		// listen for new blocks
-		go func() {
-			// inner function to queue blocks
-			queueBlocks := func() {
-				chainTip = n.LatestBlock()
-				if chainTip >= currentBlock {
-					for i := currentBlock; i <= chainTip; i++ {
-						blockQueue <- i
-					}
-					currentBlock = chainTip + 1
-				}
+	for {
+		cc := n.cc()
+		txs, headers, err := subscribe(ctx, cc)
+		n.endpoints.Report(cc, err)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Unable to subscribe to Noble websocket. Retrying in %v", resubscribeInterval), "endpoint", n.endpoints.Active(), "err", err)
+
+			timer := time.NewTimer(resubscribeInterval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
 			}
 
-			// initial queue
-			queueBlocks()
-
-			for {
-				timer := time.NewTimer(6 * time.Second)
-				select {
-				case <-timer.C:
-					queueBlocks()
-				case <-ctx.Done():
-					timer.Stop()
-					return
+			// keep up with the chain while the websocket is unavailable
+			queueMissed(n.LatestBlock(), "websocket unavailable")
+			continue
+		}
+		logger.Info("Streaming Noble txs over websocket", "endpoint", n.endpoints.Active(), "height", nextHeight)
+		n.setListenerConnected(true)
+
+		ticker := time.NewTicker(6 * time.Second)
+	Stream:
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				_ = cc.RPCClient.UnsubscribeAll(context.Background(), subscriber)
+				return
+			case event, ok := <-txs:
+				if !ok {
+					logger.Error("Noble tx subscription closed. Resubscribing...")
+					break Stream
+				}
+				data, ok := event.Data.(tmtypes.EventDataTx)
+				if !ok {
+					continue
+				}
+				enqueueTx(logger, processingQueue, &ctypes.ResultTx{
+					Hash:     tmtypes.Tx(data.Tx).Hash(),
+					Height:   data.Height,
+					Index:    data.Index,
+					TxResult: data.Result,
+					Tx:       data.Tx,
+				})
+			case event, ok := <-headers:
+				if !ok {
+					logger.Error("Noble block header subscription closed. Resubscribing...")
+					break Stream
+				}
+				data, ok := event.Data.(tmtypes.EventDataNewBlockHeader)
+				if !ok {
+					continue
+				}
+				height := uint64(data.Header.Height)
+				if height > nextHeight {
+					queueMissed(height-1, "missed block headers")
+				}
+				if height >= nextHeight {
+					nextHeight = height + 1
+				}
+			case <-ticker.C:
+				// block headers stopped arriving while the chain moved on
+				if latest := n.LatestBlock(); latest >= nextHeight+staleStreamBlocks {
+					logger.Error("Noble websocket stream stalled. Resubscribing...", "streamed height", nextHeight-1, "latest height", latest)
+					break Stream
 				}
 			}
-		}()
+		}
+		ticker.Stop()
+		n.setListenerConnected(false)
+		_ = cc.RPCClient.UnsubscribeAll(ctx, subscriber)
+
+		// txs of the last streamed height may have been lost with the subscription
+		if nextHeight > 0 {
+			nextHeight--
+		}
 	}
+}
 
-	// constantly query for blocks
-	for i := 0; i < int(n.workers); i++ {
-		go func() {
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case block := <-blockQueue:
-					res, err := n.cc.RPCClient.TxSearch(ctx, fmt.Sprintf("tx.height=%d", block), false, nil, nil, "")
-					if err != nil || res == nil {
-						logger.Debug(fmt.Sprintf("Unable to query Noble block %d. Will retry.", block), "error:", err)
-						blockQueue <- block
-						continue
-					}
-
-					for _, tx := range res.Txs {
-						parsedMsgs, err := txToMessageState(tx)
-						if err != nil {
-							logger.Error("Unable to parse Noble log to message state", "err", err.Error())
-							continue
-						}
-						for _, parsedMsg := range parsedMsgs {
-							logger.Info(fmt.Sprintf("New stream msg with nonce %d from %d with tx hash %s", parsedMsg.Nonce, parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
-						}
-						processingQueue <- &types.TxState{TxHash: tx.Hash.String(), Msgs: parsedMsgs}
-					}
-				}
-			}
-		}()
+// subscribe starts the rpc client's websocket and subscribes to MessageSent txs and block headers
+func subscribe(ctx context.Context, cc *cosmos.CosmosProvider) (txs, headers <-chan ctypes.ResultEvent, err error) {
+	if !cc.RPCClient.IsRunning() {
+		if err := cc.RPCClient.Start(); err != nil {
+			return nil, nil, fmt.Errorf("unable to start websocket: %w", err)
+		}
 	}
 
-	if flushInterval > 0 {
-		go n.flushMechanism(ctx, logger, blockQueue, flushOnlyMode)
+	txs, err = cc.RPCClient.Subscribe(ctx, subscriber, messageSentQuery, subscriptionCapacity)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to subscribe to txs: %w", err)
 	}
+	headers, err = cc.RPCClient.Subscribe(ctx, subscriber, newBlockHeaderQuery, subscriptionCapacity)
+	if err != nil {
+		_ = cc.RPCClient.UnsubscribeAll(ctx, subscriber)
+		return nil, nil, fmt.Errorf("unable to subscribe to block headers: %w", err)
+	}
+	return txs, headers, nil
+}
 
-	<-ctx.Done()
+// enqueueTx parses the cctp messages of a tx and passes them to the processingQueue
+func enqueueTx(logger log.Logger, processingQueue chan *types.TxState, tx *ctypes.ResultTx) {
+	parsedMsgs, err := txToMessageState(tx)
+	if err != nil {
+		logger.Error("Unable to parse Noble log to message state", "err", err.Error())
+		return
+	}
+	if len(parsedMsgs) == 0 {
+		return
+	}
+	for _, parsedMsg := range parsedMsgs {
+		logger.Info(fmt.Sprintf("New stream msg with nonce %d from %d with tx hash %s", parsedMsg.Nonce, parsedMsg.SourceDomain, parsedMsg.SourceTxHash))
+	}
+	processingQueue <- &types.TxState{TxHash: tx.Hash.String(), Msgs: parsedMsgs}
 }
 
 // flushMechanism looks back over the chain history every specified flushInterval.
@@ -134,13 +241,19 @@ func (n *Noble) StartListener(
 //
 // Note: The first time the flush mechanism is run, it will set the lastFlushedBlock to the latest block
 // minus twice the lookback period.
+//
+// Flushes requested with RequestFlush run right away. Without a flushInterval, flushes only run on request.
 func (n *Noble) flushMechanism(
 	ctx context.Context,
 	logger log.Logger,
-	blockQueue chan uint64,
+	processingQueue chan *types.TxState,
 	flushOnlyMode bool,
 ) {
-	logger.Info(fmt.Sprintf("Starting flush mechanism. Will flush every %v", flushInterval))
+	if flushInterval > 0 {
+		logger.Info(fmt.Sprintf("Starting flush mechanism. Will flush every %v", flushInterval))
+	} else {
+		logger.Info("Starting flush mechanism. Will only flush on request")
+	}
 
 	// extraFlushBlocks is used to add an extra space between latest height and last flushed block
 	// this setting should only be used for the secondary, flush only relayer
@@ -150,66 +263,79 @@ func (n *Noble) flushMechanism(
 	}
 
 	for {
-		timer := time.NewTimer(flushInterval)
+		interval, stop := relayer.FlushTimer(flushInterval)
 		select {
-		case <-timer.C:
-			latestBlock := n.LatestBlock()
+		case <-n.flushRequests:
+			stop()
+			logger.Info("Flush requested")
+			n.flush(ctx, logger, processingQueue, extraFlushBlocks)
+		case <-interval:
+			n.flush(ctx, logger, processingQueue, extraFlushBlocks)
+		case <-ctx.Done():
+			stop()
+			return
+		}
+	}
+}
 
-			// test to see that the rpc is available before attempting flush
-			res, err := n.cc.RPCClient.Status(ctx)
-			if err != nil {
-				logger.Error(fmt.Sprintf("Skipping flush... error reaching out to rpc, will retry flush in %v", flushInterval))
-				continue
-			}
-			if res.SyncInfo.CatchingUp {
-				logger.Error(fmt.Sprintf("Skipping flush... rpc still catching, will retry flush in %v", flushInterval))
-				continue
-			}
+// flush scans the history from the last flushed block up to the lookback period behind the latest block
+func (n *Noble) flush(ctx context.Context, logger log.Logger, processingQueue chan *types.TxState, extraFlushBlocks uint64) {
+	latestBlock := n.LatestBlock()
 
-			// initialize first lastFlushedBlock if not set
-			if n.lastFlushedBlock == 0 {
-				n.lastFlushedBlock = latestBlock - (2*n.lookbackPeriod + extraFlushBlocks)
+	// test to see that the rpc is available before attempting flush
+	cc := n.cc()
+	res, err := cc.RPCClient.Status(ctx)
+	n.endpoints.Report(cc, err)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Skipping flush... error reaching out to rpc, will retry flush in %v", flushInterval))
+		return
+	}
+	if res.SyncInfo.CatchingUp {
+		logger.Error(fmt.Sprintf("Skipping flush... rpc still catching, will retry flush in %v", flushInterval))
+		return
+	}
 
-				if latestBlock < n.lookbackPeriod {
-					n.lastFlushedBlock = 0
-				}
-			}
+	// initialize first lastFlushedBlock if not set
+	if n.lastFlushedBlock == 0 {
+		n.lastFlushedBlock = latestBlock - (2*n.lookbackPeriod + extraFlushBlocks)
 
-			// start from the last block it flushed
-			startBlock := n.lastFlushedBlock
+		if latestBlock < n.lookbackPeriod {
+			n.lastFlushedBlock = 0
+		}
+	}
 
-			// set finish block to be latestBlock - lookbackPeriod
-			finishBlock := latestBlock - (n.lookbackPeriod + extraFlushBlocks)
+	// start from the last block it flushed
+	startBlock := n.lastFlushedBlock
 
-			if startBlock >= finishBlock {
-				logger.Debug("No new blocks to flush")
-				continue
-			}
+	// set finish block to be latestBlock - lookbackPeriod
+	finishBlock := latestBlock - (n.lookbackPeriod + extraFlushBlocks)
 
-			logger.Info(fmt.Sprintf("Flush started from %d to %d (current height: %d, lookback period: %d)", startBlock, finishBlock, latestBlock, n.lookbackPeriod))
+	if startBlock >= finishBlock {
+		logger.Debug("No new blocks to flush")
+		return
+	}
 
-			for i := startBlock; i <= finishBlock; i++ {
-				blockQueue <- i
-			}
-			n.lastFlushedBlock = finishBlock
+	logger.Info(fmt.Sprintf("Flush started from %d to %d (current height: %d, lookback period: %d)", startBlock, finishBlock, latestBlock, n.lookbackPeriod))
 
-			logger.Info("Flush complete")
+	n.scanHistory(ctx, logger, processingQueue, startBlock, finishBlock)
+	n.lastFlushedBlock = finishBlock
 
-		case <-ctx.Done():
-			timer.Stop()
-			return
-		}
-	}
+	logger.Info("Flush complete")
 }
 
-func (n *Noble) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
+func (n *Noble) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) error {
 	logger.With("routine", "TrackLatestBlockHeight", "chain", n.Name(), "domain", n.Domain())
 
 	d := fmt.Sprint(n.Domain())
 
+	// keep scoring the rpc endpoints so queries, the listener and broadcasts use the healthiest one
+	go n.endpoints.Monitor(ctx, m)
+
 	// inner function to update block height
 	updateBlockHeight := func() {
-		res, err := n.cc.RPCClient.Status(ctx)
+		cc := n.cc()
+		res, err := cc.RPCClient.Status(ctx)
+		n.endpoints.Report(cc, err)
 		if err != nil {
 			logger.Error("Unable to query Nobles latest height", "err", err)
 		} else {
@@ -218,6 +344,9 @@ func (n *Noble) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m
 				m.SetLatestHeight(n.Name(), d, res.SyncInfo.LatestBlockHeight)
 			}
 		}
+		if m != nil {
+			m.SetUnscannableHeights(n.Name(), d, len(n.UnscannableHeights()))
+		}
 	}
 
 	// initial call
@@ -231,11 +360,12 @@ func (n *Noble) TrackLatestBlockHeight(ctx context.Context, logger log.Logger, m
 			updateBlockHeight()
 		case <-ctx.Done():
 			timer.Stop()
-			return
+			return nil
 		}
 	}
 }
 
-func (n *Noble) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) {
+func (n *Noble) WalletBalanceMetric(ctx context.Context, logger log.Logger, m *relayer.PromMetrics) error {
 	// Relaying is free. No need to track noble balance.
+	return nil
 }
@@ -27,7 +27,7 @@ func TestStartListener(t *testing.T) {
 
 	processingQueue := make(chan *types.TxState, 10000)
 
-	go n.StartListener(ctx, a.Logger, processingQueue, false, 0)
+	go n.StartListener(ctx, a.Logger, processingQueue, false, 0, nil)
 
 	time.Sleep(20 * time.Second)
 
@@ -35,13 +35,12 @@ func TestStartListener(t *testing.T) {
 
 	expectedMsg := &types.MessageState{
 		IrisLookupID: "efe7cea3fd4785c3beab7f37876bdd48c5d4689c84d85a250813a2a7f01fe765",
-		Status:       "created",
 		SourceDomain: 4,
 		DestDomain:   0,
 		SourceTxHash: "5002A249B1353FA59C1660EBAE5FA7FC652AC1E77F69CEF3A4533B0DF2864012",
 	}
 	require.Equal(t, expectedMsg.IrisLookupID, tx.Msgs[0].IrisLookupID)
-	require.Equal(t, expectedMsg.Status, tx.Msgs[0].Status)
+	require.Equal(t, types.Created, tx.Msgs[0].Status())
 	require.Equal(t, expectedMsg.SourceDomain, tx.Msgs[0].SourceDomain)
 	require.Equal(t, expectedMsg.DestDomain, tx.Msgs[0].DestDomain)
 	require.Equal(t, expectedMsg.SourceTxHash, tx.Msgs[0].SourceTxHash)
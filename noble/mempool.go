@@ -0,0 +1,54 @@
+package noble
+
+import (
+	"context"
+	"fmt"
+
+	nobletypes "github.com/circlefin/noble-cctp/x/cctp/types"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// unconfirmedTxsLimit is the max number of mempool txs inspected before each broadcast
+const unconfirmedTxsLimit = 100
+
+// otherPendingReceives returns the hashes of other relayers' unconfirmed MsgReceiveMessage txs, by the receive key of
+// the message they receive
+func (n *Noble) otherPendingReceives(ctx context.Context, txDecoder sdk.TxDecoder) (map[string]string, error) {
+	limit := unconfirmedTxsLimit
+	res, err := n.cc().RPCClient.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query unconfirmed txs: %w", err)
+	}
+
+	return pendingReceives(res.Txs, txDecoder, n.minterAddress), nil
+}
+
+// pendingReceives decodes the MsgReceiveMessages of txs not sent by minter. Txs that can not be decoded, e.g.
+// because they contain messages of other modules, are ignored.
+func pendingReceives(txs []tmtypes.Tx, txDecoder sdk.TxDecoder, minter string) map[string]string {
+	pending := make(map[string]string)
+	for _, txBz := range txs {
+		tx, err := txDecoder(txBz)
+		if err != nil {
+			continue
+		}
+
+		for _, msg := range tx.GetMsgs() {
+			receive, ok := msg.(*nobletypes.MsgReceiveMessage)
+			if !ok || receive.From == minter {
+				continue
+			}
+			parsed, err := new(types.Message).Parse(receive.Message)
+			if err != nil {
+				continue
+			}
+			pending[parsed.ReceiveKey()] = fmt.Sprintf("%X", txBz.Hash())
+		}
+	}
+	return pending
+}
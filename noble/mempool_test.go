@@ -0,0 +1,50 @@
+package noble
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	nobletypes "github.com/circlefin/noble-cctp/x/cctp/types"
+	"github.com/stretchr/testify/require"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	xauthtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestPendingReceives(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	nobletypes.RegisterInterfaces(interfaceRegistry)
+	txConfig := xauthtx.NewTxConfig(codec.NewProtoCodec(interfaceRegistry), xauthtx.DefaultSignModes)
+
+	receiveTx := func(from string, sourceDomain uint32, nonce uint64) tmtypes.Tx {
+		message := make([]byte, 116)
+		binary.BigEndian.PutUint32(message[4:], sourceDomain)
+		binary.BigEndian.PutUint64(message[12:], nonce)
+
+		txBuilder := txConfig.NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(nobletypes.NewMsgReceiveMessage(from, message, []byte("attestation"))))
+		bz, err := txConfig.TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		return bz
+	}
+
+	minter := "noble1minter"
+	other := receiveTx("noble1other", 0, 42)
+	txs := []tmtypes.Tx{
+		receiveTx(minter, 0, 41),
+		other,
+		// not a tx
+		tmtypes.Tx("garbage"),
+	}
+
+	pending := pendingReceives(txs, txConfig.TxDecoder(), minter)
+	require.Equal(t, map[string]string{
+		(&types.MessageState{SourceDomain: 0, Nonce: 42}).ReceiveKey(): fmt.Sprintf("%X", other.Hash()),
+	}, pending)
+}
@@ -51,7 +51,6 @@ func txToMessageState(tx *ctypes.ResultTx) ([]*types.MessageState, error) {
 
 					messageState := &types.MessageState{
 						IrisLookupID:      hashedHexStr,
-						Status:            types.Created,
 						SourceDomain:      types.Domain(msg.SourceDomain),
 						DestDomain:        types.Domain(msg.DestinationDomain),
 						Nonce:             msg.Nonce,
@@ -63,6 +62,15 @@ func txToMessageState(tx *ctypes.ResultTx) ([]*types.MessageState, error) {
 						Updated:           now,
 					}
 
+					if err := messageState.SetStatus(types.Created); err != nil {
+						parseErrs = errors.Join(parseErrs, err)
+						continue
+					}
+
+					if _, err := new(types.BurnMessage).Parse(msg.MessageBody); err == nil {
+						messageState.Type = types.Mint
+					}
+
 					messageStates = append(messageStates, messageState)
 				}
 			}
@@ -0,0 +1,138 @@
+package noble
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+// streamSession is one websocket subscription of the stream, closing a channel drops the subscription
+type streamSession struct {
+	txs     chan ctypes.ResultEvent
+	headers chan ctypes.ResultEvent
+}
+
+// streamRPC serves the websocket subscriptions of the stream, and TxSearch for the heights it missed
+type streamRPC struct {
+	*fakeRPC
+
+	mu       sync.Mutex
+	current  *streamSession
+	sessions chan *streamSession
+}
+
+func newStreamRPC(txs ...*ctypes.ResultTx) *streamRPC {
+	return &streamRPC{fakeRPC: &fakeRPC{maxRange: historyRangeMax, txs: txs}, sessions: make(chan *streamSession, 10)}
+}
+
+func (s *streamRPC) IsRunning() bool { return true }
+
+func (s *streamRPC) Subscribe(_ context.Context, _, query string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// txs are subscribed to first, the session is handed to the test once headers are subscribed to as well
+	if query == messageSentQuery {
+		s.current = &streamSession{txs: make(chan ctypes.ResultEvent, 10), headers: make(chan ctypes.ResultEvent, 10)}
+		return s.current.txs, nil
+	}
+	s.sessions <- s.current
+	return s.current.headers, nil
+}
+
+func (s *streamRPC) UnsubscribeAll(context.Context, string) error { return nil }
+
+func (s *streamRPC) nextSession(t *testing.T) *streamSession {
+	select {
+	case session := <-s.sessions:
+		return session
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not subscribe")
+		return nil
+	}
+}
+
+func headerEvent(height int64) ctypes.ResultEvent {
+	return ctypes.ResultEvent{Data: tmtypes.EventDataNewBlockHeader{Header: tmtypes.Header{Height: height}}}
+}
+
+func txEvent(tx *ctypes.ResultTx) ctypes.ResultEvent {
+	return ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+		Height: tx.Height,
+		Tx:     tx.Tx,
+		Result: tx.TxResult,
+	}}}
+}
+
+// receivedHeights returns the heights of the next n txs passed to the processingQueue
+func receivedHeights(t *testing.T, processingQueue chan *types.TxState, n int) []uint64 {
+	var heights []uint64
+	for i := 0; i < n; i++ {
+		select {
+		case tx := <-processingQueue:
+			// messageSentTx uses the height as the nonce
+			heights = append(heights, tx.Msgs[0].Nonce)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d of %d txs", i, n)
+		}
+	}
+	return heights
+}
+
+func TestStreamTxsFillsGaps(t *testing.T) {
+	streamed, missed, lastStreamed := messageSentTx(10), messageSentTx(12), messageSentTx(15)
+	rpc := newStreamRPC(streamed, missed, lastStreamed)
+
+	n := &Noble{workers: 1, endpoints: newFakePool(t, rpc), blockQueryRetries: 1, unscannable: make(map[uint64]*UnscannableHeight)}
+	processingQueue := make(chan *types.TxState, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		n.streamTxs(ctx, log.NewNopLogger(), processingQueue, 10, nil)
+		close(done)
+	}()
+
+	// streamed txs are queued as they arrive
+	session := rpc.nextSession(t)
+	session.headers <- headerEvent(10)
+	session.txs <- txEvent(streamed)
+	require.Equal(t, []uint64{10}, receivedHeights(t, processingQueue, 1))
+	require.Eventually(t, func() bool { return n.Status().ListenerConnected }, time.Second, time.Millisecond)
+
+	// headers skipping heights make the stream scan the heights in between
+	session.headers <- headerEvent(14)
+	require.Equal(t, []uint64{12}, receivedHeights(t, processingQueue, 1))
+
+	// the subscription is dropped after height 15 was streamed
+	session.headers <- headerEvent(15)
+	session.txs <- txEvent(lastStreamed)
+	require.Equal(t, []uint64{15}, receivedHeights(t, processingQueue, 1))
+	close(session.txs)
+
+	// after resubscribing, the heights since the last streamed one are scanned, including it as its txs may have
+	// been lost with the subscription
+	session = rpc.nextSession(t)
+	session.headers <- headerEvent(18)
+	require.Equal(t, []uint64{15}, receivedHeights(t, processingQueue, 1))
+
+	// only the missed heights are scanned
+	rpc.fakeRPC.mu.Lock()
+	require.Equal(t, []uint64{3, 3}, rpc.ranges)
+	rpc.fakeRPC.mu.Unlock()
+
+	cancel()
+	<-done
+	require.False(t, n.Status().ListenerConnected)
+}
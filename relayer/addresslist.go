@@ -0,0 +1,105 @@
+package relayer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+const (
+	// AddressListFormatPlain is a file with one address per line, lines starting with # are ignored
+	AddressListFormatPlain = "plain"
+	// AddressListFormatOFAC is the OFAC SDN list csv (sdn.csv), its "Digital Currency Address" remarks are loaded
+	AddressListFormatOFAC = "ofac-sdn"
+)
+
+// regexDigitalCurrencyAddress matches the addresses in the remarks of the OFAC SDN list, e.g.
+// "Digital Currency Address - ETH 0x8589427373D6D84E98730D7795D8f6f8731FDA16;"
+var regexDigitalCurrencyAddress = regexp.MustCompile(`Digital Currency Address - ([A-Za-z0-9]+)\s+([^\s;"]+)`)
+
+// Address is an address in the 32 byte form used by CCTP messages
+type Address [32]byte
+
+func (a Address) String() string {
+	return "0x" + hex.EncodeToString(a[:])
+}
+
+// BytesToAddress left pads an address of up to 32 bytes, like CCTP messages do
+func BytesToAddress(bz []byte) (Address, error) {
+	var address Address
+	if len(bz) == 0 || len(bz) > len(address) {
+		return address, fmt.Errorf("addresses must be 1 to 32 bytes, got %d", len(bz))
+	}
+	copy(address[len(address)-len(bz):], bz)
+	return address, nil
+}
+
+// NormalizeAddress parses a hex address of 20 or 32 bytes, with or without 0x, or a bech32 address (e.g. noble1...)
+// into its 32 byte form
+func NormalizeAddress(address string) (Address, error) {
+	address = strings.TrimSpace(address)
+
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+	if len(trimmed) == 40 || len(trimmed) == 64 {
+		if bz, err := hex.DecodeString(trimmed); err == nil {
+			return BytesToAddress(bz)
+		}
+	}
+
+	if _, bz, err := bech32.DecodeAndConvert(address); err == nil {
+		return BytesToAddress(bz)
+	}
+
+	return Address{}, fmt.Errorf("unsupported address %q, must be 20 or 32 bytes of hex or bech32", address)
+}
+
+// LoadAddressList reads the addresses in the file. Invalid addresses in plain files are errors, addresses of the
+// OFAC SDN list that are not hex or bech32 (e.g. bitcoin addresses) are skipped.
+func LoadAddressList(file, format string) (map[Address]struct{}, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read address list: %w", err)
+	}
+
+	addresses := make(map[Address]struct{})
+	switch format {
+	case "", AddressListFormatPlain:
+		scanner := bufio.NewScanner(bytes.NewReader(bz))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			// anything after the address, e.g. a csv column or comment, is ignored
+			if fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }); len(fields) > 0 {
+				text = fields[0]
+			}
+			address, err := NormalizeAddress(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s line %d: %w", file, line, err)
+			}
+			addresses[address] = struct{}{}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("unable to read address list: %w", err)
+		}
+	case AddressListFormatOFAC:
+		for _, match := range regexDigitalCurrencyAddress.FindAllSubmatch(bz, -1) {
+			// the last remark ends with a period
+			address, err := NormalizeAddress(strings.TrimRight(string(match[2]), ".,"))
+			if err != nil {
+				continue
+			}
+			addresses[address] = struct{}{}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported address list format %q, must be %s or %s", format, AddressListFormatPlain, AddressListFormatOFAC)
+	}
+	return addresses, nil
+}
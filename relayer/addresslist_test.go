@@ -0,0 +1,71 @@
+package relayer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	evm := "0x8589427373D6D84E98730D7795D8f6f8731FDA16"
+	padded := "0x0000000000000000000000008589427373d6d84e98730d7795d8f6f8731fda16"
+
+	for _, address := range []string{evm, "8589427373d6d84e98730d7795d8f6f8731fda16", padded} {
+		normalized, err := relayer.NormalizeAddress(address)
+		require.NoError(t, err, address)
+		require.Equal(t, padded, normalized.String())
+	}
+
+	// noble addresses are the same 20 bytes as the padded recipient of a mint to noble
+	nobleAddress, err := bech32.ConvertAndEncode("noble", make([]byte, 20))
+	require.NoError(t, err)
+	normalized, err := relayer.NormalizeAddress(nobleAddress)
+	require.NoError(t, err)
+	require.Equal(t, relayer.Address{}, normalized)
+
+	for _, invalid := range []string{"", "0x1234", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "noble1invalid"} {
+		_, err := relayer.NormalizeAddress(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestLoadAddressList(t *testing.T) {
+	dir := t.TempDir()
+	evm, err := relayer.NormalizeAddress("0x8589427373D6D84E98730D7795D8f6f8731FDA16")
+	require.NoError(t, err)
+	evm2, err := relayer.NormalizeAddress("0x722122dF12D4e14e13Ac3b6895a86e84145b6967")
+	require.NoError(t, err)
+
+	plain := filepath.Join(dir, "deny.txt")
+	require.NoError(t, os.WriteFile(plain, []byte(`# sanctioned
+0x8589427373D6D84E98730D7795D8f6f8731FDA16
+
+0x722122dF12D4e14e13Ac3b6895a86e84145b6967, tornado cash
+`), 0o600))
+	addresses, err := relayer.LoadAddressList(plain, relayer.AddressListFormatPlain)
+	require.NoError(t, err)
+	require.Len(t, addresses, 2)
+	require.Contains(t, addresses, evm)
+	require.Contains(t, addresses, evm2)
+
+	invalid := filepath.Join(dir, "invalid.txt")
+	require.NoError(t, os.WriteFile(invalid, []byte("0x8589427373D6D84E98730D7795D8f6f8731FDA16\nnot-an-address\n"), 0o600))
+	_, err = relayer.LoadAddressList(invalid, relayer.AddressListFormatPlain)
+	require.ErrorContains(t, err, "line 2")
+
+	sdn := filepath.Join(dir, "sdn.csv")
+	require.NoError(t, os.WriteFile(sdn, []byte(
+		`36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+12345,"EXAMPLE",-0- ,"CYBER2",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"Digital Currency Address - XBT 1BoatSLRHtKNngkdXEeobR76b53LETtpyT; Digital Currency Address - ETH 0x8589427373D6D84E98730D7795D8f6f8731FDA16; Digital Currency Address - USDC 0x722122dF12D4e14e13Ac3b6895a86e84145b6967."
+`), 0o600))
+	addresses, err = relayer.LoadAddressList(sdn, relayer.AddressListFormatOFAC)
+	require.NoError(t, err)
+	require.Len(t, addresses, 2)
+	require.Contains(t, addresses, evm)
+}
@@ -0,0 +1,304 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+)
+
+const (
+	// EndpointCheckInterval is how often every endpoint of a pool is health checked
+	EndpointCheckInterval = 15 * time.Second
+	endpointCheckTimeout  = 5 * time.Second
+
+	// endpointMaxErrors is the number of errors in a row after which the active endpoint is failed over
+	endpointMaxErrors = 3
+
+	// an endpoint's score is its latency in milliseconds plus penalties for head lag and error rate, lower is better
+	lagPenaltyMs       = 100
+	errorRatePenaltyMs = 1000
+	// switchMarginMs keeps the active endpoint unless another one scores better by at least this much
+	switchMarginMs = 100
+	// errorRateWeight is the weight of the latest result in the error rate moving average
+	errorRateWeight = 0.2
+)
+
+// EndpointCheck queries the latest height of an endpoint, it is used to score the endpoint's health
+type EndpointCheck[C comparable] func(ctx context.Context, client C) (height uint64, err error)
+
+// EndpointPool holds the clients for all configured endpoints of a chain (e.g. its rpcs) and picks the healthiest one.
+// Endpoints are scored on latency, how far their head lags behind the other endpoints and their error rate.
+type EndpointPool[C comparable] struct {
+	logger log.Logger
+	chain  string
+	check  EndpointCheck[C]
+
+	mu        sync.Mutex
+	metrics   *PromMetrics
+	endpoints []*endpoint[C]
+	active    int
+}
+
+type endpoint[C comparable] struct {
+	// label is the endpoint url without path or query, which often hold api keys
+	label  string
+	client C
+
+	healthy           bool
+	consecutiveErrors int
+	errorRate         float64
+	latency           time.Duration
+	height            uint64
+	lag               uint64
+}
+
+// NewEndpointPool dials every url. Endpoints that cannot be dialed are skipped, an error is only returned if none
+// can be dialed. The first endpoint is active until the first health check.
+func NewEndpointPool[C comparable](
+	logger log.Logger,
+	chain string,
+	urls []string,
+	dial func(url string) (C, error),
+	check EndpointCheck[C],
+) (*EndpointPool[C], error) {
+	p := &EndpointPool[C]{
+		logger: logger,
+		chain:  chain,
+		check:  check,
+	}
+
+	var dialErrors error
+	for _, u := range urls {
+		client, err := dial(u)
+		if err != nil {
+			logger.Error("Unable to dial endpoint, skipping", "chain", chain, "endpoint", EndpointLabel(u), "err", err)
+			dialErrors = errors.Join(dialErrors, fmt.Errorf("%s: %w", EndpointLabel(u), err))
+			continue
+		}
+		p.endpoints = append(p.endpoints, &endpoint[C]{label: EndpointLabel(u), client: client, healthy: true})
+	}
+	if len(p.endpoints) == 0 {
+		return nil, fmt.Errorf("unable to dial any endpoint for %s: %w", chain, dialErrors)
+	}
+	return p, nil
+}
+
+// EndpointLabel strips the path and query from an endpoint url so it can be logged and used as a metric label
+func EndpointLabel(endpointURL string) string {
+	u, err := url.Parse(endpointURL)
+	if err != nil || u.Host == "" {
+		return "invalid endpoint"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// Client returns the client of the active endpoint
+func (p *EndpointPool[C]) Client() C {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.endpoints[p.active].client
+}
+
+// Active returns the label of the active endpoint
+func (p *EndpointPool[C]) Active() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.endpoints[p.active].label
+}
+
+// Healthy returns whether any endpoint passed its last health check
+func (p *EndpointPool[C]) Healthy() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range p.endpoints {
+		if e.healthy {
+			return true
+		}
+	}
+	return false
+}
+
+// Clients returns the clients of all endpoints
+func (p *EndpointPool[C]) Clients() []C {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clients := make([]C, len(p.endpoints))
+	for i, e := range p.endpoints {
+		clients[i] = e.client
+	}
+	return clients
+}
+
+// Report records the result of a request made with client. After endpointMaxErrors errors in a row the endpoint is
+// marked unhealthy and, if it is active, the pool fails over to the best remaining endpoint.
+func (p *EndpointPool[C]) Report(client C, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.endpoints {
+		if e.client != client {
+			continue
+		}
+		e.record(err)
+		if err == nil {
+			return
+		}
+		if p.metrics != nil {
+			p.metrics.IncEndpointErrors(p.chain, e.label)
+		}
+		if e.consecutiveErrors >= endpointMaxErrors && e.healthy {
+			e.healthy = false
+			p.logger.Error("Endpoint failed too many requests in a row, marking unhealthy", "chain", p.chain, "endpoint", e.label, "err", err)
+			if i == p.active {
+				p.failover()
+			}
+		}
+		p.setMetrics()
+		return
+	}
+}
+
+// Monitor health checks every endpoint each EndpointCheckInterval until ctx is done. m may be nil.
+func (p *EndpointPool[C]) Monitor(ctx context.Context, m *PromMetrics) {
+	p.mu.Lock()
+	p.metrics = m
+	p.mu.Unlock()
+
+	for {
+		p.CheckHealth(ctx)
+
+		timer := time.NewTimer(EndpointCheckInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// CheckHealth checks every endpoint once, rescores them and fails over if a better endpoint is available
+func (p *EndpointPool[C]) CheckHealth(ctx context.Context) {
+	type result struct {
+		height  uint64
+		latency time.Duration
+		err     error
+	}
+
+	// query all endpoints without holding the lock
+	clients := p.Clients()
+	results := make([]result, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client C) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, endpointCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			height, err := p.check(checkCtx, client)
+			results[i] = result{height: height, latency: time.Since(start), err: err}
+		}(i, client)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var highest uint64
+	for i, e := range p.endpoints {
+		r := results[i]
+		e.record(r.err)
+		if r.err != nil {
+			if e.healthy {
+				p.logger.Error("Endpoint health check failed, marking unhealthy", "chain", p.chain, "endpoint", e.label, "err", r.err)
+			}
+			e.healthy = false
+			if p.metrics != nil {
+				p.metrics.IncEndpointErrors(p.chain, e.label)
+			}
+			continue
+		}
+		if !e.healthy {
+			p.logger.Info("Endpoint recovered", "chain", p.chain, "endpoint", e.label)
+		}
+		e.healthy = true
+		e.latency = r.latency
+		e.height = r.height
+		if r.height > highest {
+			highest = r.height
+		}
+	}
+	for _, e := range p.endpoints {
+		e.lag = 0
+		if e.healthy {
+			e.lag = highest - e.height
+		}
+	}
+
+	if best := p.best(); best != p.active {
+		active, candidate := p.endpoints[p.active], p.endpoints[best]
+		if !active.healthy || candidate.score()+switchMarginMs < active.score() {
+			p.switchTo(best)
+		}
+	}
+	p.setMetrics()
+}
+
+// failover switches to the best healthy endpoint, if there is one. p.mu must be held.
+func (p *EndpointPool[C]) failover() {
+	if best := p.best(); best != p.active && p.endpoints[best].healthy {
+		p.switchTo(best)
+		return
+	}
+	p.logger.Error("No healthy endpoint to fail over to", "chain", p.chain, "endpoint", p.endpoints[p.active].label)
+}
+
+func (p *EndpointPool[C]) switchTo(i int) {
+	p.logger.Info("Switching active endpoint", "chain", p.chain, "from", p.endpoints[p.active].label, "to", p.endpoints[i].label)
+	p.active = i
+}
+
+// best returns the index of the healthy endpoint with the lowest score, or the active endpoint if none are healthy.
+// p.mu must be held.
+func (p *EndpointPool[C]) best() int {
+	best := p.active
+	for i, e := range p.endpoints {
+		if !e.healthy {
+			continue
+		}
+		if b := p.endpoints[best]; !b.healthy || e.score() < b.score() {
+			best = i
+		}
+	}
+	return best
+}
+
+func (p *EndpointPool[C]) setMetrics() {
+	if p.metrics == nil {
+		return
+	}
+	for i, e := range p.endpoints {
+		p.metrics.SetEndpointHealth(p.chain, e.label, e.healthy, i == p.active, e.latency, e.lag)
+	}
+}
+
+func (e *endpoint[C]) record(err error) {
+	failed := 0.0
+	if err != nil {
+		failed = 1
+		e.consecutiveErrors++
+	} else {
+		e.consecutiveErrors = 0
+	}
+	e.errorRate = (1-errorRateWeight)*e.errorRate + errorRateWeight*failed
+}
+
+func (e *endpoint[C]) score() float64 {
+	return float64(e.latency.Milliseconds()) + lagPenaltyMs*float64(e.lag) + errorRatePenaltyMs*e.errorRate
+}
@@ -0,0 +1,93 @@
+package relayer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+type fakeClient struct {
+	height uint64
+	err    error
+}
+
+func newFakePool(t *testing.T, clients map[string]*fakeClient, urls ...string) *relayer.EndpointPool[*fakeClient] {
+	pool, err := relayer.NewEndpointPool(
+		log.NewNopLogger(),
+		"ethereum",
+		urls,
+		func(url string) (*fakeClient, error) {
+			client, ok := clients[url]
+			if !ok {
+				return nil, errors.New("unable to dial")
+			}
+			return client, nil
+		},
+		func(_ context.Context, client *fakeClient) (uint64, error) {
+			return client.height, client.err
+		},
+	)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestEndpointLabel(t *testing.T) {
+	require.Equal(t, "https://mainnet.infura.io", relayer.EndpointLabel("https://mainnet.infura.io/v3/secret-key"))
+	require.Equal(t, "wss://rpc.example.com:8546", relayer.EndpointLabel("wss://rpc.example.com:8546?key=secret"))
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	primary := &fakeClient{height: 100}
+	fallback := &fakeClient{height: 100}
+	clients := map[string]*fakeClient{
+		"https://primary.example.com":  primary,
+		"https://fallback.example.com": fallback,
+	}
+
+	// endpoints that cannot be dialed are skipped
+	pool := newFakePool(t, clients, "https://primary.example.com", "https://unreachable.example.com", "https://fallback.example.com")
+	require.Len(t, pool.Clients(), 2)
+	require.Equal(t, primary, pool.Client())
+
+	// a few errors do not fail over
+	pool.Report(primary, errors.New("timeout"))
+	pool.Report(primary, errors.New("timeout"))
+	require.Equal(t, primary, pool.Client())
+
+	// errors in a row fail over to the healthy endpoint
+	pool.Report(primary, errors.New("timeout"))
+	require.Equal(t, fallback, pool.Client())
+	require.Equal(t, "https://fallback.example.com", pool.Active())
+
+	// the primary recovers, but the active endpoint is kept while it scores about the same
+	pool.CheckHealth(context.Background())
+	require.Equal(t, fallback, pool.Client())
+
+	// an endpoint lagging behind its peers is failed over
+	fallback.height = 90
+	primary.height = 110
+	pool.CheckHealth(context.Background())
+	require.Equal(t, primary, pool.Client())
+
+	// a failed health check fails over
+	primary.err = errors.New("connection refused")
+	pool.CheckHealth(context.Background())
+	require.Equal(t, fallback, pool.Client())
+}
+
+func TestEndpointPoolNoEndpoints(t *testing.T) {
+	_, err := relayer.NewEndpointPool(
+		log.NewNopLogger(),
+		"noble",
+		[]string{"https://unreachable.example.com"},
+		func(string) (*fakeClient, error) { return nil, errors.New("unable to dial") },
+		func(context.Context, *fakeClient) (uint64, error) { return 0, nil },
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package relayer
+
+import "time"
+
+// FlushTimer returns a channel that fires once after flushInterval and a func to stop it. Without a flushInterval
+// the channel is nil and never fires, flushes then only run on request.
+func FlushTimer(flushInterval time.Duration) (<-chan time.Time, func() bool) {
+	if flushInterval <= 0 {
+		return nil, func() bool { return false }
+	}
+	timer := time.NewTimer(flushInterval)
+	return timer.C, timer.Stop
+}
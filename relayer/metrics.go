@@ -11,9 +11,28 @@ import (
 )
 
 type PromMetrics struct {
-	WalletBalance   *prometheus.GaugeVec
-	LatestHeight    *prometheus.GaugeVec
-	BroadcastErrors *prometheus.CounterVec
+	WalletBalance     *prometheus.GaugeVec
+	LatestHeight      *prometheus.GaugeVec
+	BroadcastErrors   *prometheus.CounterVec
+	BroadcastQueue    *prometheus.GaugeVec
+	ReorgDepth        *prometheus.HistogramVec
+	RetractedMessages *prometheus.CounterVec
+	MissedHeights     *prometheus.CounterVec
+	Unscannable       *prometheus.GaugeVec
+
+	EndpointHealthy *prometheus.GaugeVec
+	EndpointActive  *prometheus.GaugeVec
+	EndpointLatency *prometheus.GaugeVec
+	EndpointHeadLag *prometheus.GaugeVec
+	EndpointErrors  *prometheus.CounterVec
+
+	RoutineHealthy  *prometheus.GaugeVec
+	RoutineRestarts *prometheus.CounterVec
+
+	ContractHalted *prometheus.GaugeVec
+
+	FilteredMessages *prometheus.CounterVec
+	DeferredMessages *prometheus.CounterVec
 }
 
 func InitPromMetrics(address string, port int16) *PromMetrics {
@@ -24,6 +43,10 @@ func InitPromMetrics(address string, port int16) *PromMetrics {
 		walletLabels         = []string{"chain", "address", "denom"}
 		heightLabels         = []string{"chain", "domain"}
 		broadcastErrorLabels = []string{"chain", "domain"}
+		reorgLabels          = []string{"chain", "domain"}
+		endpointLabels       = []string{"chain", "endpoint"}
+		routineLabels        = []string{"chain", "routine"}
+		filterLabels         = []string{"reason", "source_domain", "dest_domain"}
 	)
 
 	m := &PromMetrics{
@@ -39,11 +62,87 @@ func InitPromMetrics(address string, port int16) *PromMetrics {
 			Name: "cctp_relayer_broadcast_errors_total",
 			Help: "The total number of failed broadcasts. Note: this is AFTER is retires `broadcast-retries` number of times (config setting).",
 		}, broadcastErrorLabels),
+		BroadcastQueue: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_broadcast_queue_size",
+			Help: "The number of attested batches waiting for a broadcaster worker of the destination chain.",
+		}, broadcastErrorLabels),
+		ReorgDepth: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cctp_relayer_chain_reorg_depth",
+			Help:    "The depth in blocks of chain reorgs observed by the listener.",
+			Buckets: []float64{1, 2, 3, 5, 8, 13, 21, 34, 64},
+		}, reorgLabels),
+		RetractedMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_retracted_messages_total",
+			Help: "The total number of messages retracted because their logs were removed in a reorg after they were released to the processor.",
+		}, reorgLabels),
+		MissedHeights: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_missed_heights_total",
+			Help: "The total number of heights missed by a chain's websocket stream and scanned individually.",
+		}, heightLabels),
+		Unscannable: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_unscannable_heights",
+			Help: "The number of heights that could not be queried after retrying, e.g. because the rpc pruned them.",
+		}, heightLabels),
+		EndpointHealthy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_endpoint_healthy",
+			Help: "Whether an rpc or websocket endpoint passed its last health check (1) or not (0).",
+		}, endpointLabels),
+		EndpointActive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_endpoint_active",
+			Help: "Whether an rpc or websocket endpoint is the one currently in use (1) or not (0).",
+		}, endpointLabels),
+		EndpointLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_endpoint_latency_seconds",
+			Help: "The latency of an endpoint's last health check.",
+		}, endpointLabels),
+		EndpointHeadLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_endpoint_head_lag",
+			Help: "How many blocks an endpoint's latest height is behind the highest height of the chain's other endpoints.",
+		}, endpointLabels),
+		EndpointErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_endpoint_errors_total",
+			Help: "The total number of failed requests and health checks for an endpoint.",
+		}, endpointLabels),
+		RoutineHealthy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_routine_healthy",
+			Help: "Whether a chain routine (listener, height tracker, ...) is running (1) or waiting to be restarted after failing (0).",
+		}, routineLabels),
+		RoutineRestarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_routine_restarts_total",
+			Help: "The total number of times a chain routine failed and was restarted.",
+		}, routineLabels),
+		ContractHalted: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cctp_relayer_contract_halted",
+			Help: "Whether broadcasting to a chain is halted (1) because its CCTP contracts are paused, report another domain or an unexpected version, or not (0).",
+		}, heightLabels),
+		FilteredMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_filtered_messages_total",
+			Help: "The total number of messages that were not relayed, by the filter that filtered them.",
+		}, filterLabels),
+		DeferredMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cctp_relayer_deferred_messages_total",
+			Help: "The total number of times attested messages were deferred by their route's policy or relay cost, by reason.",
+		}, filterLabels),
 	}
 
 	reg.MustRegister(m.WalletBalance)
 	reg.MustRegister(m.LatestHeight)
 	reg.MustRegister(m.BroadcastErrors)
+	reg.MustRegister(m.BroadcastQueue)
+	reg.MustRegister(m.ReorgDepth)
+	reg.MustRegister(m.RetractedMessages)
+	reg.MustRegister(m.MissedHeights)
+	reg.MustRegister(m.Unscannable)
+	reg.MustRegister(m.EndpointHealthy)
+	reg.MustRegister(m.EndpointActive)
+	reg.MustRegister(m.EndpointLatency)
+	reg.MustRegister(m.EndpointHeadLag)
+	reg.MustRegister(m.EndpointErrors)
+	reg.MustRegister(m.RoutineHealthy)
+	reg.MustRegister(m.RoutineRestarts)
+	reg.MustRegister(m.ContractHalted)
+	reg.MustRegister(m.FilteredMessages)
+	reg.MustRegister(m.DeferredMessages)
 
 	// Expose /metrics HTTP endpoint
 	go func() {
@@ -69,3 +168,61 @@ func (m *PromMetrics) SetLatestHeight(chain, domain string, height int64) {
 func (m *PromMetrics) IncBroadcastErrors(chain, domain string) {
 	m.BroadcastErrors.WithLabelValues(chain, domain).Inc()
 }
+
+func (m *PromMetrics) SetBroadcastQueueSize(chain, domain string, size int) {
+	m.BroadcastQueue.WithLabelValues(chain, domain).Set(float64(size))
+}
+
+func (m *PromMetrics) ObserveReorgDepth(chain, domain string, depth uint64) {
+	m.ReorgDepth.WithLabelValues(chain, domain).Observe(float64(depth))
+}
+
+func (m *PromMetrics) IncRetractedMessages(chain, domain string, count int) {
+	m.RetractedMessages.WithLabelValues(chain, domain).Add(float64(count))
+}
+
+func (m *PromMetrics) IncMissedHeights(chain, domain string, count uint64) {
+	m.MissedHeights.WithLabelValues(chain, domain).Add(float64(count))
+}
+
+func (m *PromMetrics) SetUnscannableHeights(chain, domain string, count int) {
+	m.Unscannable.WithLabelValues(chain, domain).Set(float64(count))
+}
+
+func (m *PromMetrics) SetEndpointHealth(chain, endpoint string, healthy, active bool, latency time.Duration, lag uint64) {
+	m.EndpointHealthy.WithLabelValues(chain, endpoint).Set(boolToFloat(healthy))
+	m.EndpointActive.WithLabelValues(chain, endpoint).Set(boolToFloat(active))
+	m.EndpointLatency.WithLabelValues(chain, endpoint).Set(latency.Seconds())
+	m.EndpointHeadLag.WithLabelValues(chain, endpoint).Set(float64(lag))
+}
+
+func (m *PromMetrics) IncEndpointErrors(chain, endpoint string) {
+	m.EndpointErrors.WithLabelValues(chain, endpoint).Inc()
+}
+
+func (m *PromMetrics) SetRoutineHealthy(chain, routine string, healthy bool) {
+	m.RoutineHealthy.WithLabelValues(chain, routine).Set(boolToFloat(healthy))
+}
+
+func (m *PromMetrics) IncRoutineRestarts(chain, routine string) {
+	m.RoutineRestarts.WithLabelValues(chain, routine).Inc()
+}
+
+func (m *PromMetrics) SetContractHalted(chain, domain string, halted bool) {
+	m.ContractHalted.WithLabelValues(chain, domain).Set(boolToFloat(halted))
+}
+
+func (m *PromMetrics) IncFilteredMessages(reason, sourceDomain, destDomain string) {
+	m.FilteredMessages.WithLabelValues(reason, sourceDomain, destDomain).Inc()
+}
+
+func (m *PromMetrics) IncDeferredMessages(reason, sourceDomain, destDomain string) {
+	m.DeferredMessages.WithLabelValues(reason, sourceDomain, destDomain).Inc()
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
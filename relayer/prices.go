@@ -0,0 +1,44 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FetchPrices queries a price feed for USD prices. The feed must return a json object of prices by denom, e.g.
+// {"ETH": 3120.5, "AVAX": 35.2}. Denoms are upper cased.
+func FetchPrices(ctx context.Context, url string) (map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create price feed request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query price feed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price feed returned status %d", resp.StatusCode)
+	}
+
+	var feed map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("unable to decode price feed: %w", err)
+	}
+
+	prices := make(map[string]float64, len(feed))
+	for denom, price := range feed {
+		if price < 0 {
+			return nil, fmt.Errorf("price feed returned negative price %v for %s", price, denom)
+		}
+		prices[strings.ToUpper(denom)] = price
+	}
+	return prices, nil
+}
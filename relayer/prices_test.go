@@ -0,0 +1,32 @@
+package relayer_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+func TestFetchPrices(t *testing.T) {
+	body := `{"eth": 3120.5, "AVAX": 35.2}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	prices, err := relayer.FetchPrices(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"ETH": 3120.5, "AVAX": 35.2}, prices)
+
+	body = `{"ETH": -1}`
+	_, err = relayer.FetchPrices(context.Background(), server.URL)
+	require.ErrorContains(t, err, "negative price")
+
+	body = `not json`
+	_, err = relayer.FetchPrices(context.Background(), server.URL)
+	require.ErrorContains(t, err, "unable to decode")
+}
@@ -0,0 +1,53 @@
+package relayer
+
+import "sync"
+
+// RangeSize adapts how many blocks are queried at once when scanning history. It doubles after successful queries
+// and halves after failed ones (e.g. timeouts or "range too large" errors), within [min, max].
+type RangeSize struct {
+	mu   sync.Mutex
+	size uint64
+	min  uint64
+	max  uint64
+}
+
+func NewRangeSize(initial, min, max uint64) *RangeSize {
+	if min == 0 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+	if initial < min {
+		initial = min
+	}
+	if initial > max {
+		initial = max
+	}
+	return &RangeSize{size: initial, min: min, max: max}
+}
+
+// Get returns the current number of blocks to query at once
+func (r *RangeSize) Get() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.size
+}
+
+// Grow doubles the range size, up to max
+func (r *RangeSize) Grow() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.size = min(2*r.size, r.max)
+}
+
+// Shrink halves the range size, down to min. It returns false if the range size was already at min.
+func (r *RangeSize) Shrink() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size == r.min {
+		return false
+	}
+	r.size = max(r.size/2, r.min)
+	return true
+}
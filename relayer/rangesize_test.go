@@ -0,0 +1,31 @@
+package relayer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+func TestRangeSize(t *testing.T) {
+	r := relayer.NewRangeSize(100, 10, 300)
+	require.Equal(t, uint64(100), r.Get())
+
+	r.Grow()
+	require.Equal(t, uint64(200), r.Get())
+	r.Grow()
+	require.Equal(t, uint64(300), r.Get())
+
+	require.True(t, r.Shrink())
+	require.Equal(t, uint64(150), r.Get())
+	for _, expected := range []uint64{75, 37, 18, 10} {
+		require.True(t, r.Shrink())
+		require.Equal(t, expected, r.Get())
+	}
+	require.False(t, r.Shrink())
+
+	// the initial size is clamped
+	require.Equal(t, uint64(1), relayer.NewRangeSize(0, 0, 0).Get())
+	require.Equal(t, uint64(50), relayer.NewRangeSize(100, 1, 50).Get())
+}
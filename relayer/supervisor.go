@@ -0,0 +1,175 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+)
+
+const (
+	// failed routines are restarted with exponential backoff, starting at minRestartBackoff
+	minRestartBackoff = 1 * time.Second
+	maxRestartBackoff = 1 * time.Minute
+	// a routine that ran for at least stableRunTime before failing is restarted without backing off further
+	stableRunTime = 5 * time.Minute
+)
+
+// RoutineState is the health state of a supervised routine
+type RoutineState string
+
+const (
+	// Running routines are expected to keep running until the relayer shuts down
+	Running RoutineState = "running"
+	// Restarting routines failed and wait for their backoff to pass before they are restarted
+	Restarting RoutineState = "restarting"
+	// Finished routines returned without error, they are not restarted
+	Finished RoutineState = "finished"
+)
+
+// RoutineHealth is the health of a supervised routine
+type RoutineHealth struct {
+	Chain     string       `json:"chain"`
+	Routine   string       `json:"routine"`
+	State     RoutineState `json:"state"`
+	Restarts  int          `json:"restarts"`
+	LastError string       `json:"last_error,omitempty"`
+	Since     time.Time    `json:"since"`
+}
+
+// Supervisor runs the long lived routines of every chain (listener, height tracker, ...). Routines that fail or panic
+// are restarted with backoff, so a single unhealthy chain does not take down the rest of the relayer.
+type Supervisor struct {
+	logger  log.Logger
+	metrics *PromMetrics
+
+	mu       sync.Mutex
+	routines map[string]*RoutineHealth
+}
+
+// NewSupervisor creates a supervisor, m may be nil
+func NewSupervisor(logger log.Logger, m *PromMetrics) *Supervisor {
+	return &Supervisor{
+		logger:   logger,
+		metrics:  m,
+		routines: make(map[string]*RoutineHealth),
+	}
+}
+
+// Go runs routine in a goroutine until ctx is done. The routine's context is cancelled whenever it returns, which
+// stops any goroutines it started, before it is restarted.
+func (s *Supervisor) Go(ctx context.Context, chain, routine string, run func(ctx context.Context) error) {
+	s.setState(chain, routine, Running, nil)
+
+	go func() {
+		logger := s.logger.With("chain", chain, "routine", routine)
+		backoff := minRestartBackoff
+
+		for {
+			started := time.Now()
+			err := s.run(ctx, run)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				logger.Debug("Routine finished")
+				s.setState(chain, routine, Finished, nil)
+				return
+			}
+
+			if time.Since(started) >= stableRunTime {
+				backoff = minRestartBackoff
+			}
+			logger.Error(fmt.Sprintf("Routine failed. Restarting in %v", backoff), "err", err)
+			s.setState(chain, routine, Restarting, err)
+			if s.metrics != nil {
+				s.metrics.IncRoutineRestarts(chain, routine)
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+			backoff = min(2*backoff, maxRestartBackoff)
+
+			logger.Info("Restarting routine")
+			s.setState(chain, routine, Running, nil)
+		}
+	}()
+}
+
+// run calls the routine with its own context, turning panics into errors
+func (s *Supervisor) run(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	routineCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return run(routineCtx)
+}
+
+func (s *Supervisor) setState(chain, routine string, state RoutineState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := chain + "/" + routine
+	r, ok := s.routines[key]
+	if !ok {
+		r = &RoutineHealth{Chain: chain, Routine: routine}
+		s.routines[key] = r
+	}
+	if state == Restarting {
+		r.Restarts++
+	}
+	if err != nil {
+		r.LastError = err.Error()
+	}
+	r.State = state
+	r.Since = time.Now()
+
+	if s.metrics != nil {
+		s.metrics.SetRoutineHealthy(chain, routine, state != Restarting)
+	}
+}
+
+// Health returns the health of every supervised routine, sorted by chain and routine
+func (s *Supervisor) Health() []RoutineHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := make([]RoutineHealth, 0, len(s.routines))
+	for _, r := range s.routines {
+		health = append(health, *r)
+	}
+	sort.Slice(health, func(i, j int) bool {
+		if health[i].Chain != health[j].Chain {
+			return health[i].Chain < health[j].Chain
+		}
+		return health[i].Routine < health[j].Routine
+	})
+	return health
+}
+
+// Healthy returns whether none of the chain's routines are restarting
+func (s *Supervisor) Healthy(chain string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.routines {
+		if r.Chain == chain && r.State == Restarting {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package relayer_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/relayer"
+)
+
+func TestSupervisorRestartsFailedRoutines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := relayer.NewSupervisor(log.NewNopLogger(), nil)
+
+	// the listener panics on its first run and keeps running after it is restarted
+	var runs atomic.Int32
+	s.Go(ctx, "ethereum", "listener", func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			panic("unable to parse abi")
+		}
+		<-ctx.Done()
+		return nil
+	})
+	s.Go(ctx, "noble", "wallet-balance", func(context.Context) error {
+		return nil
+	})
+
+	require.Eventually(t, func() bool {
+		return !s.Healthy("ethereum")
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, s.Healthy("noble"))
+
+	require.Eventually(t, func() bool {
+		return runs.Load() == 2 && s.Healthy("ethereum")
+	}, 3*time.Second, 10*time.Millisecond)
+
+	health := s.Health()
+	require.Len(t, health, 2)
+	require.Equal(t, "ethereum", health[0].Chain)
+	require.Equal(t, relayer.Running, health[0].State)
+	require.Equal(t, 1, health[0].Restarts)
+	require.Contains(t, health[0].LastError, "unable to parse abi")
+	require.Equal(t, relayer.Finished, health[1].State)
+}
@@ -1,8 +1,41 @@
 package types
 
+import (
+	"encoding/hex"
+	"strings"
+)
+
 // AttestationResponse is the response received from Circle's iris api
 // Example: https://iris-api-sandbox.circle.com/attestations/0x85bbf7e65a5992e6317a61f005e06d9972a033d71b514be183b179e1b47723fe
 type AttestationResponse struct {
 	Attestation string `json:"attestation"`
 	Status      string `json:"status"`
 }
+
+// AttestationV2Response is the response received from Circle's iris v2 messages api
+// Example: https://iris-api-sandbox.circle.com/v2/messages/0?transactionHash=0x...
+type AttestationV2Response struct {
+	Messages []AttestationV2Message `json:"messages"`
+}
+
+type AttestationV2Message struct {
+	Attestation string `json:"attestation"`
+	Message     string `json:"message"`
+	EventNonce  string `json:"eventNonce"`
+	CctpVersion int    `json:"cctpVersion"`
+	Status      string `json:"status"`
+}
+
+// Find returns the attested message matching the message bytes emitted on the source chain.
+func (r *AttestationV2Response) Find(msgSentBytes []byte) (*AttestationV2Message, []byte) {
+	for i := range r.Messages {
+		attested, err := hex.DecodeString(strings.TrimPrefix(r.Messages[i].Message, "0x"))
+		if err != nil {
+			continue
+		}
+		if SameV2Message(msgSentBytes, attested) {
+			return &r.Messages[i], attested
+		}
+	}
+	return nil, nil
+}
@@ -48,16 +48,26 @@ type Chain interface {
 		sequenceMap *SequenceMap,
 	) error
 
-	// StartListener starts a listener for observing new CCTP burn messages.
+	// StartListener starts a listener for observing new CCTP burn messages. It runs until ctx is done and returns an
+	// error if the listener fails and needs to be restarted.
 	StartListener(
 		ctx context.Context,
 		logger log.Logger,
 		processingQueue chan *TxState,
 		flushOnlyMode bool,
 		flushInterval time.Duration,
-	)
+		metrics *relayer.PromMetrics,
+	) error
+
+	// RequestFlush makes the chain flush right away, also when no flush interval is set. It returns an error if a
+	// flush is already pending.
+	RequestFlush() error
 
-	// Broadcast broadcasts CCTP mint messages to the chain.
+	// SetBroadcastRetries changes how often and how far apart failed broadcasts are retried, e.g. on a config reload.
+	SetBroadcastRetries(maxRetries, retryIntervalSeconds int)
+
+	// Broadcast broadcasts CCTP mint messages to the chain and moves them to their new status. Messages whose mint
+	// is submitted but not yet known to succeed stay attested.
 	Broadcast(
 		ctx context.Context,
 		logger log.Logger,
@@ -66,15 +76,37 @@ type Chain interface {
 		metrics *relayer.PromMetrics,
 	) error
 
+	// TrackLatestBlockHeight keeps LatestBlock up to date until ctx is done.
 	TrackLatestBlockHeight(
 		ctx context.Context,
 		logger log.Logger,
 		metrics *relayer.PromMetrics,
-	)
+	) error
+
+	// Status returns the chain's own view of its health, reported by the health endpoints.
+	Status() ChainStatus
 
+	// ContractStates queries the state of the CCTP contracts mints are broadcast to.
+	ContractStates(ctx context.Context) ([]ContractState, error)
+
+	// EstimateMintCost estimates the fee of broadcasting the attested msg to the chain at current gas prices.
+	EstimateMintCost(ctx context.Context, msg *MessageState) (MintCost, error)
+
+	// WalletBalanceMetric exports the minter's balance until ctx is done.
 	WalletBalanceMetric(
 		ctx context.Context,
 		logger log.Logger,
 		metrics *relayer.PromMetrics,
-	)
+	) error
+}
+
+// ChainStatus is a chain's own view of its health
+type ChainStatus struct {
+	// RPCReachable is true if at least one rpc endpoint passed its last health check
+	RPCReachable bool `json:"rpc_reachable"`
+	// ListenerConnected is true while the listener is subscribed to, or polling for, new messages
+	ListenerConnected bool `json:"listener_connected"`
+	// WalletBalance is nil for chains that do not track their minter's balance
+	WalletBalance    *float64 `json:"wallet_balance,omitempty"`
+	MinWalletBalance float64  `json:"min_wallet_balance,omitempty"`
 }
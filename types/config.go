@@ -4,30 +4,197 @@ type Config struct {
 	Chains        map[string]ChainConfig `yaml:"chains"`
 	EnabledRoutes map[Domain][]Domain    `yaml:"enabled-routes"`
 	Circle        CircleSettings         `yaml:"circle"`
+	FastTransfer  FastTransferSettings   `yaml:"fast-transfer"`
+	Health        HealthSettings         `yaml:"health"`
+	Shutdown      ShutdownSettings       `yaml:"shutdown"`
+
+	// RoutePolicies are the policies set in enabled-routes, by source and destination domain
+	RoutePolicies map[Domain]map[Domain]RoutePolicy `yaml:"route-policies,omitempty"`
+
+	// Filters are the names of the filters messages go through, in order. The default filters are used if empty.
+	Filters      []string            `yaml:"filters"`
+	AddressLists AddressListSettings `yaml:"address-lists"`
+	RelayCost    RelayCostSettings   `yaml:"relay-cost"`
+
+	BroadcastPriority BroadcastPrioritySettings `yaml:"broadcast-priority"`
+
+	// BroadcasterWorkerCount is the number of broadcaster workers of each destination domain, 1 if zero
+	BroadcasterWorkerCount uint32 `yaml:"broadcaster-worker-count"`
+	// BroadcasterWorkers overrides BroadcasterWorkerCount by destination domain
+	BroadcasterWorkers map[Domain]uint32 `yaml:"broadcaster-workers"`
 
 	ProcessorWorkerCount uint32 `yaml:"processor-worker-count"`
 	API                  struct {
 		TrustedProxies []string `yaml:"trusted-proxies"`
+		AdminToken     string   `yaml:"admin-token"`
 	} `yaml:"api"`
 }
 
 type ConfigWrapper struct {
 	Chains        map[string]map[string]any `yaml:"chains"`
-	EnabledRoutes map[Domain][]Domain       `yaml:"enabled-routes"`
+	EnabledRoutes map[Domain][]EnabledRoute `yaml:"enabled-routes"`
 	Circle        CircleSettings            `yaml:"circle"`
+	FastTransfer  FastTransferSettings      `yaml:"fast-transfer"`
+	Health        HealthSettings            `yaml:"health"`
+	Shutdown      ShutdownSettings          `yaml:"shutdown"`
+
+	Filters      []string            `yaml:"filters"`
+	AddressLists AddressListSettings `yaml:"address-lists"`
+	RelayCost    RelayCostSettings   `yaml:"relay-cost"`
+
+	BroadcastPriority BroadcastPrioritySettings `yaml:"broadcast-priority"`
+
+	BroadcasterWorkerCount uint32            `yaml:"broadcaster-worker-count"`
+	BroadcasterWorkers     map[Domain]uint32 `yaml:"broadcaster-workers"`
 
 	ProcessorWorkerCount uint32 `yaml:"processor-worker-count"`
 	API                  struct {
 		TrustedProxies []string `yaml:"trusted-proxies"`
+		AdminToken     string   `yaml:"admin-token"`
 	} `yaml:"api"`
 }
 
+// EnabledRoute is a destination domain in enabled-routes, either just the domain (e.g. 4) or the domain with a
+// policy (e.g. {domain: 4, max-amount: 1000000000})
+type EnabledRoute struct {
+	Dest        Domain `yaml:"domain"`
+	RoutePolicy `yaml:",inline"`
+}
+
+func (r *EnabledRoute) UnmarshalYAML(unmarshal func(any) error) error {
+	var dest Domain
+	if err := unmarshal(&dest); err == nil {
+		*r = EnabledRoute{Dest: dest}
+		return nil
+	}
+
+	type plain EnabledRoute
+	return unmarshal((*plain)(r))
+}
+
+// RoutePolicy limits what is relayed on a route. Amounts are in burn token units, zero values are not enforced.
+// Transfers below MinAmount are filtered, transfers over the other limits are deferred until they fit.
+type RoutePolicy struct {
+	// MinAmount is the smallest transfer relayed, it replaces the destination chain's min-mint-amount
+	MinAmount uint64 `yaml:"min-amount,omitempty"`
+	// MaxAmount is the largest single transfer relayed
+	MaxAmount uint64 `yaml:"max-amount,omitempty"`
+	// VolumeCap is the most relayed within VolumeWindow
+	VolumeCap uint64 `yaml:"volume-cap,omitempty"`
+	// VolumeWindow is the number of seconds of the rolling volume cap window, 3600 if unset
+	VolumeWindow int `yaml:"volume-window,omitempty"`
+	// MaxTxPerMinute is the most transfers relayed within a rolling minute
+	MaxTxPerMinute int `yaml:"max-tx-per-minute,omitempty"`
+}
+
+// RoutePolicy returns the policy of the route from source to dest, the zero policy if none is set
+func (c *Config) RoutePolicy(source, dest Domain) RoutePolicy {
+	return c.RoutePolicies[source][dest]
+}
+
 type CircleSettings struct {
-	AttestationBaseURL string `yaml:"attestation-base-url"`
-	FetchRetries       int    `yaml:"fetch-retries"`
-	FetchRetryInterval int    `yaml:"fetch-retry-interval"`
+	AttestationBaseURL   string `yaml:"attestation-base-url"`
+	AttestationV2BaseURL string `yaml:"attestation-v2-base-url"`
+	FetchRetries         int    `yaml:"fetch-retries"`
+	FetchRetryInterval   int    `yaml:"fetch-retry-interval"`
+}
+
+// FastTransferSettings decides which CCTP V2 fast transfers (attested below the finalized
+// threshold) are relayed. Standard transfers are not affected by these settings.
+type FastTransferSettings struct {
+	Enabled              bool   `yaml:"enabled"`
+	MinFinalityThreshold uint32 `yaml:"min-finality-threshold"`
+	MinFee               uint64 `yaml:"min-fee"`
+}
+
+// RelayCostSettings skip or defer permissionless transfers (without a destination caller) whose estimated mint
+// cost is too high for their amount. Transfers we are the destination caller of are always relayed.
+type RelayCostSettings struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxCostRatio is the highest estimated mint cost relayed, as a fraction of the transfer amount, e.g. 0.01
+	MaxCostRatio float64 `yaml:"max-cost-ratio"`
+	// Action is what happens to transfers over MaxCostRatio: defer (wait for the cost to fall) or skip (filter them),
+	// defer if unset
+	Action string `yaml:"action"`
+	// Prices are static USD prices of the destination chains' fee tokens, by metrics-denom, e.g. ETH: 3000
+	Prices map[string]float64 `yaml:"prices"`
+	// PriceFeed is a url returning a json object of USD prices by denom, e.g. {"ETH": 3000}. Its prices take
+	// precedence over Prices.
+	PriceFeed string `yaml:"price-feed"`
+	// PriceFeedInterval is the number of seconds between price feed queries, 60 if unset
+	PriceFeedInterval int `yaml:"price-feed-interval"`
+	// DeferInterval is the number of seconds between cost estimates of deferred transfers, 60 if unset
+	DeferInterval int `yaml:"defer-interval"`
+}
+
+// BroadcastPrioritySettings order the attested messages waiting to be broadcast to the same destination
+type BroadcastPrioritySettings struct {
+	// Rules are compared in order until one prefers a batch of messages over another: destination-caller (ours
+	// first), route (higher weight first), amount (larger first) and age (longer waiting first). All of them, in
+	// that order, if empty.
+	Rules []string `yaml:"rules"`
+	// Routes are the weights of the route rule, routes that are not listed weigh 0
+	Routes []RouteWeight `yaml:"routes"`
+	// MaxWait is the number of seconds after which a waiting batch goes before all others, oldest first, 300 if unset
+	MaxWait int `yaml:"max-wait"`
+}
+
+// RouteWeight is the priority of a source -> destination route
+type RouteWeight struct {
+	Source Domain `yaml:"source"`
+	Dest   Domain `yaml:"dest"`
+	Weight int    `yaml:"weight"`
+}
+
+// AddressListSettings screen the senders and recipients of transfers. Transfers from or to a denied address are
+// filtered. If allow lists apply to a domain, transfers from or to an address of that domain that is not on one of
+// them are filtered.
+type AddressListSettings struct {
+	// RefreshInterval is the number of seconds between reloads of the list files, 3600 if unset
+	RefreshInterval int           `yaml:"refresh-interval"`
+	Deny            []AddressList `yaml:"deny"`
+	Allow           []AddressList `yaml:"allow"`
+}
+
+// AddressList is a file of addresses
+type AddressList struct {
+	// Name identifies the list in filter reasons and logs, the file name if unset
+	Name string `yaml:"name"`
+	File string `yaml:"file"`
+	// Format is plain (one address per line) or ofac-sdn (the OFAC SDN list csv), plain if unset
+	Format string `yaml:"format"`
+	// Domains the list applies to, all domains if empty. Senders are matched on the source domain and recipients
+	// on the destination domain.
+	Domains []Domain `yaml:"domains"`
+}
+
+// HealthSettings configure the /healthz and /readyz endpoints
+type HealthSettings struct {
+	// CriticalChains are the names of the chains whose degradation fails the health endpoints, all chains if empty
+	CriticalChains []string `yaml:"critical-chains"`
+	// StaleHeightAfter is the number of seconds a chain's latest height may stay the same before it is degraded
+	StaleHeightAfter int `yaml:"stale-height-after"`
+}
+
+// ShutdownSettings configure how in-flight work is drained when the relayer is stopped
+type ShutdownSettings struct {
+	// GracePeriod is the number of seconds processor workers get to finish their current broadcasts
+	GracePeriod int `yaml:"grace-period"`
+	// DumpFile is where unfinished txs are written as json on shutdown, they are logged if empty
+	DumpFile string `yaml:"dump-file"`
 }
 
 type ChainConfig interface {
 	Chain(name string) (Chain, error)
 }
+
+// Endpoints returns the primary endpoint followed by its fallbacks, skipping empty entries
+func Endpoints(primary string, fallbacks []string) []string {
+	var endpoints []string
+	for _, endpoint := range append([]string{primary}, fallbacks...) {
+		if endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
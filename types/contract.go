@@ -0,0 +1,29 @@
+package types
+
+import "fmt"
+
+// ContractState is what a chain's CCTP contract reports about itself, e.g. a MessageTransmitter or noble's x/cctp
+// module
+type ContractState struct {
+	Contract    string `json:"contract"`
+	Paused      bool   `json:"paused"`
+	LocalDomain Domain `json:"local_domain"`
+	Version     uint32 `json:"version"`
+	// ExpectedVersion is the message version the relayer mints with on this contract
+	ExpectedVersion    uint32 `json:"expected_version"`
+	MaxMessageBodySize uint64 `json:"max_message_body_size"`
+}
+
+// HaltReason returns why mints to domain must not be broadcast to the contract, or an empty string if they can be.
+// Broadcasting to a paused, misconfigured or upgraded contract only burns gas on reverts.
+func (s ContractState) HaltReason(domain Domain) string {
+	switch {
+	case s.Paused:
+		return fmt.Sprintf("%s is paused", s.Contract)
+	case s.LocalDomain != domain:
+		return fmt.Sprintf("%s reports domain %d instead of %d", s.Contract, s.LocalDomain, domain)
+	case s.Version != s.ExpectedVersion:
+		return fmt.Sprintf("%s reports version %d instead of %d", s.Contract, s.Version, s.ExpectedVersion)
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractStateHaltReason(t *testing.T) {
+	state := ContractState{Contract: "MessageTransmitterV2", LocalDomain: 3, Version: MessageVersionV2, ExpectedVersion: MessageVersionV2}
+	require.Empty(t, state.HaltReason(3))
+
+	require.Equal(t, "MessageTransmitterV2 reports domain 3 instead of 2", state.HaltReason(2))
+
+	state.Version = MessageVersionV1
+	require.Equal(t, "MessageTransmitterV2 reports version 0 instead of 1", state.HaltReason(3))
+
+	state.Paused = true
+	require.Equal(t, "MessageTransmitterV2 is paused", state.HaltReason(3))
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"math"
+	"math/big"
+)
+
+// MintCost is the estimated fee of broadcasting a mint to a chain
+type MintCost struct {
+	// Fee is in the smallest unit of the chain's fee token, e.g. wei
+	Fee *big.Int `json:"fee"`
+	// Denom is the fee token, e.g. ETH, matched against the configured prices. Empty for chains where mints are free.
+	Denom string `json:"denom,omitempty"`
+	// Exponent converts Fee to Denom, e.g. 18 for wei to ETH
+	Exponent int `json:"exponent,omitempty"`
+}
+
+// Amount returns the fee in Denom
+func (c MintCost) Amount() float64 {
+	if c.Fee == nil {
+		return 0
+	}
+	fee, _ := new(big.Float).SetInt(c.Fee).Float64()
+	return fee / math.Pow10(c.Exponent)
+}
@@ -4,11 +4,25 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/big"
 )
 
+const (
+	// MessageVersionV1 is the message (and burn message) version emitted by CCTP V1 contracts
+	MessageVersionV1 uint32 = 0
+	// MessageVersionV2 is the message (and burn message) version emitted by CCTP V2 contracts
+	MessageVersionV2 uint32 = 1
+
+	// FinalityThresholdConfirmed is the lowest finality threshold Circle will attest to (fast transfer)
+	FinalityThresholdConfirmed uint32 = 1000
+	// FinalityThresholdFinalized is the finality threshold of a standard transfer
+	FinalityThresholdFinalized uint32 = 2000
+)
+
 // Message defines ...
 // https://github.com/circlefin/evm-cctp-contracts/blob/d53f0e1937a0a5c5158d356b6767b77dc32dcc90/src/messages/Message.sol#L29-L37
+// https://github.com/circlefin/evm-cctp-contracts/blob/master/src/messages/v2/MessageV2.sol
 type Message struct {
 	Version           uint32
 	SourceDomain      uint32
@@ -18,16 +32,28 @@ type Message struct {
 	Recipient         []byte
 	DestinationCaller []byte
 	MessageBody       []byte
+
+	// V2 only
+	NonceV2                   []byte
+	MinFinalityThreshold      uint32
+	FinalityThresholdExecuted uint32
 }
 
 // BurnMessage defines ...
 // https://github.com/circlefin/evm-cctp-contracts/blob/d53f0e1937a0a5c5158d356b6767b77dc32dcc90/src/messages/BurnMessage.sol#L24-L29
+// https://github.com/circlefin/evm-cctp-contracts/blob/master/src/messages/v2/BurnMessageV2.sol
 type BurnMessage struct {
 	Version       uint32
 	BurnToken     []byte
 	MintRecipient []byte
 	Amount        *big.Int
 	MessageSender []byte
+
+	// V2 only
+	MaxFee          *big.Int
+	FeeExecuted     *big.Int
+	ExpirationBlock *big.Int
+	HookData        []byte
 }
 
 // MetadataMessage defines ...
@@ -40,59 +66,164 @@ type MetadataMessage struct {
 	Memo      string
 }
 
-//
+// message V1 byte indices
+const (
+	versionIndex           = 0
+	sourceDomainIndex      = 4
+	destinationDomainIndex = 8
+	nonceIndex             = 12
+	senderIndex            = 20
+	recipientIndex         = 52
+	destinationCallerIndex = 84
+	messageBodyIndex       = 116
+)
 
+// message V2 byte indices
+const (
+	nonceV2Index                     = 12
+	senderV2Index                    = 44
+	recipientV2Index                 = 76
+	destinationCallerV2Index         = 108
+	minFinalityThresholdV2Index      = 140
+	finalityThresholdExecutedV2Index = 144
+	messageBodyV2Index               = 148
+)
+
+// burn message byte indices, V2 extends the V1 layout
+const (
+	burnTokenIndex       = 4
+	mintRecipientIndex   = 36
+	amountIndex          = 68
+	msgSenderIndex       = 100
+	burnContentLength    = 132
+	maxFeeIndex          = 132
+	feeExecutedIndex     = 164
+	expirationBlockIndex = 196
+	hookDataIndex        = 228
+)
+
+// Parse decodes a V1 or V2 message depending on the version in the first four bytes.
 func (msg *Message) Parse(bz []byte) (*Message, error) {
-	const (
-		VersionIndex           = 0
-		SourceDomainIndex      = 4
-		DestinationDomainIndex = 8
-		NonceIndex             = 12
-		SenderIndex            = 20
-		RecipientIndex         = 52
-		DestinationCallerIndex = 84
-		MessageBodyIndex       = 116
-	)
+	if len(bz) < sourceDomainIndex {
+		return nil, errors.New("message is too short to contain a version")
+	}
 
-	if len(bz) < MessageBodyIndex {
-		return nil, errors.New("")
+	msg.Version = binary.BigEndian.Uint32(bz[versionIndex:sourceDomainIndex])
+	switch msg.Version {
+	case MessageVersionV1:
+		return msg.parseV1(bz)
+	case MessageVersionV2:
+		return msg.parseV2(bz)
+	default:
+		return nil, fmt.Errorf("unsupported message version: %d", msg.Version)
+	}
+}
+
+func (msg *Message) parseV1(bz []byte) (*Message, error) {
+	if len(bz) < messageBodyIndex {
+		return nil, fmt.Errorf("v1 message must be at least %d bytes, got %d", messageBodyIndex, len(bz))
 	}
 
-	msg.Version = binary.BigEndian.Uint32(bz[VersionIndex:SourceDomainIndex])
-	msg.SourceDomain = binary.BigEndian.Uint32(bz[SourceDomainIndex:DestinationDomainIndex])
-	msg.DestinationDomain = binary.BigEndian.Uint32(bz[DestinationDomainIndex:NonceIndex])
-	msg.Nonce = binary.BigEndian.Uint64(bz[NonceIndex:SenderIndex])
-	msg.Sender = bz[SenderIndex:RecipientIndex]
-	msg.Recipient = bz[RecipientIndex:DestinationCallerIndex]
-	msg.DestinationCaller = bz[DestinationCallerIndex:MessageBodyIndex]
-	msg.MessageBody = bz[MessageBodyIndex:]
+	msg.SourceDomain = binary.BigEndian.Uint32(bz[sourceDomainIndex:destinationDomainIndex])
+	msg.DestinationDomain = binary.BigEndian.Uint32(bz[destinationDomainIndex:nonceIndex])
+	msg.Nonce = binary.BigEndian.Uint64(bz[nonceIndex:senderIndex])
+	msg.Sender = bz[senderIndex:recipientIndex]
+	msg.Recipient = bz[recipientIndex:destinationCallerIndex]
+	msg.DestinationCaller = bz[destinationCallerIndex:messageBodyIndex]
+	msg.MessageBody = bz[messageBodyIndex:]
 
 	return msg, nil
 }
 
+func (msg *Message) parseV2(bz []byte) (*Message, error) {
+	if len(bz) < messageBodyV2Index {
+		return nil, fmt.Errorf("v2 message must be at least %d bytes, got %d", messageBodyV2Index, len(bz))
+	}
+
+	msg.SourceDomain = binary.BigEndian.Uint32(bz[sourceDomainIndex:destinationDomainIndex])
+	msg.DestinationDomain = binary.BigEndian.Uint32(bz[destinationDomainIndex:nonceV2Index])
+	msg.NonceV2 = bz[nonceV2Index:senderV2Index]
+	msg.Sender = bz[senderV2Index:recipientV2Index]
+	msg.Recipient = bz[recipientV2Index:destinationCallerV2Index]
+	msg.DestinationCaller = bz[destinationCallerV2Index:minFinalityThresholdV2Index]
+	msg.MinFinalityThreshold = binary.BigEndian.Uint32(bz[minFinalityThresholdV2Index:finalityThresholdExecutedV2Index])
+	msg.FinalityThresholdExecuted = binary.BigEndian.Uint32(bz[finalityThresholdExecutedV2Index:messageBodyV2Index])
+	msg.MessageBody = bz[messageBodyV2Index:]
+
+	return msg, nil
+}
+
+// ReceiveKey identifies the message on its destination chain, like the MessageTransmitter's used nonces: by source
+// domain and nonce for V1, by the nonce assigned by Circle for V2. Receiving two messages with the same key mints once.
+func (msg *Message) ReceiveKey() string {
+	return receiveKey(msg.Version, Domain(msg.SourceDomain), msg.Nonce, msg.NonceV2)
+}
+
+func receiveKey(version uint32, sourceDomain Domain, nonce uint64, nonceV2 []byte) string {
+	if version == MessageVersionV2 {
+		return fmt.Sprintf("v2/%x", nonceV2)
+	}
+	return fmt.Sprintf("v1/%d/%d", sourceDomain, nonce)
+}
+
+// Parse decodes a V1 or V2 burn message depending on the version in the first four bytes.
 func (c *BurnMessage) Parse(bz []byte) (*BurnMessage, error) {
-	const (
-		VersionIndex       = 0
-		BurnTokenIndex     = 4
-		MintRecipientIndex = 36
-		AmountIndex        = 68
-		MsgSenderIndex     = 100
-		BurnContentLength  = 132
-	)
+	if len(bz) < burnTokenIndex {
+		return nil, errors.New("burn message is too short to contain a version")
+	}
 
-	if len(bz) != BurnContentLength {
-		return nil, errors.New("")
+	c.Version = binary.BigEndian.Uint32(bz[versionIndex:burnTokenIndex])
+	switch c.Version {
+	case MessageVersionV1:
+		if len(bz) != burnContentLength {
+			return nil, fmt.Errorf("v1 burn message must be %d bytes, got %d", burnContentLength, len(bz))
+		}
+	case MessageVersionV2:
+		if len(bz) < hookDataIndex {
+			return nil, fmt.Errorf("v2 burn message must be at least %d bytes, got %d", hookDataIndex, len(bz))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported burn message version: %d", c.Version)
 	}
 
-	c.Version = binary.BigEndian.Uint32(bz[VersionIndex:BurnTokenIndex])
-	c.BurnToken = bz[BurnTokenIndex:MintRecipientIndex]
-	c.MintRecipient = bz[MintRecipientIndex:AmountIndex]
-	c.Amount = new(big.Int).SetBytes(bz[AmountIndex:MsgSenderIndex])
-	c.MessageSender = bz[MsgSenderIndex:]
+	c.BurnToken = bz[burnTokenIndex:mintRecipientIndex]
+	c.MintRecipient = bz[mintRecipientIndex:amountIndex]
+	c.Amount = new(big.Int).SetBytes(bz[amountIndex:msgSenderIndex])
+	c.MessageSender = bz[msgSenderIndex:burnContentLength]
+
+	if c.Version == MessageVersionV2 {
+		c.MaxFee = new(big.Int).SetBytes(bz[maxFeeIndex:feeExecutedIndex])
+		c.FeeExecuted = new(big.Int).SetBytes(bz[feeExecutedIndex:expirationBlockIndex])
+		c.ExpirationBlock = new(big.Int).SetBytes(bz[expirationBlockIndex:hookDataIndex])
+		c.HookData = bz[hookDataIndex:]
+	}
 
 	return c, nil
 }
 
+// SameV2Message returns true if the message emitted on the source chain and the message returned by the
+// attestation service are the same transfer. Circle fills in the nonce, the executed finality threshold and
+// (for burn messages) the executed fee and expiration block before attesting, so those bytes are ignored.
+func SameV2Message(emitted, attested []byte) bool {
+	if len(emitted) != len(attested) || len(emitted) < messageBodyV2Index {
+		return false
+	}
+
+	mask := func(bz []byte) []byte {
+		masked := bytes.Clone(bz)
+		clear(masked[nonceV2Index:senderV2Index])
+		clear(masked[finalityThresholdExecutedV2Index:messageBodyV2Index])
+
+		body := masked[messageBodyV2Index:]
+		if len(body) >= hookDataIndex && binary.BigEndian.Uint32(body[versionIndex:burnTokenIndex]) == MessageVersionV2 {
+			clear(body[feeExecutedIndex:hookDataIndex])
+		}
+		return masked
+	}
+
+	return bytes.Equal(mask(emitted), mask(attested))
+}
+
 func (c *MetadataMessage) Parse(bz []byte) (*MetadataMessage, error) {
 	const (
 		NonceIndex     = 0
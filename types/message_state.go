@@ -3,22 +3,27 @@ package types
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
-	"github.com/circlefin/noble-cctp/x/cctp/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
-	Created  string = "created"
-	Pending  string = "pending"
-	Attested string = "attested"
-	Complete string = "complete"
-	Failed   string = "failed"
-	Filtered string = "filtered"
+	Created   string = "created"
+	Pending   string = "pending"
+	Attested  string = "attested"
+	Complete  string = "complete"
+	Failed    string = "failed"
+	Filtered  string = "filtered"
+	Retracted string = "retracted"
+	// RelayedByOther messages were found in another relayer's pending tx on the destination chain
+	RelayedByOther string = "relayed-by-other"
 
 	Mint    string = "mint"
 	Forward string = "forward"
@@ -30,11 +35,15 @@ type TxState struct {
 	TxHash       string
 	Msgs         []*MessageState
 	RetryAttempt int
+	// Removed is set by listeners when the tx's logs were removed in a reorg after being passed on
+	Removed bool
 }
 
 type MessageState struct {
 	IrisLookupID      string // hex encoded MessageSent bytes
-	Status            string // created, pending, attested, complete, failed, filtered
+	FilterReason      string // name of the filter that filtered the message, empty if not filtered
+	FilterDetail      string // why the filter matched, e.g. the denied address, if the filter provides it
+	DeferReason       string // route policy limit the attested message is waiting for, empty if not deferred
 	Attestation       string // hex encoded attestation
 	SourceDomain      Domain // uint32 source domain id
 	DestDomain        Domain // uint32 destination domain id
@@ -44,9 +53,26 @@ type MessageState struct {
 	MsgBody           []byte // bytes of the MessageBody
 	DestinationCaller []byte // address authorized to call transaction
 	Channel           string // "channel-%d" if a forward, empty if not a forward
+	Memo              string // ibc memo of a forward, set on both the mint and the forward
+	Type              string // mint or forward
 	Created           time.Time
 	Updated           time.Time
 	Nonce             uint64
+	History           []Transition // status changes, oldest first
+
+	// status is created, pending, attested, complete, failed, filtered, retracted or relayed-by-other. It is read
+	// with Status and changed with SetStatus, mu guards it and History.
+	status string
+	mu     sync.Mutex
+
+	// CCTP V2
+	Version                   uint32   // message version, 0 for V1 and 1 for V2
+	NonceV2                   []byte   // bytes32 nonce, assigned by Circle when the message is attested
+	MinFinalityThreshold      uint32   // finality threshold requested by the sender
+	FinalityThresholdExecuted uint32   // finality threshold the message was attested at
+	MaxFee                    *big.Int // max fee the sender is willing to pay for a fast transfer
+	FeeExecuted               *big.Int // fee charged, set when the message is attested
+	HookData                  []byte   // hook data appended to the burn message
 }
 
 // EvmLogToMessageState transforms an evm log into a messageState given an ABI
@@ -57,14 +83,16 @@ func EvmLogToMessageState(abi abi.ABI, messageSent abi.Event, log *ethtypes.Log)
 	}
 
 	rawMessageSentBytes := event["message"].([]byte)
-	message, _ := new(types.Message).Parse(rawMessageSentBytes)
+	message, err := new(Message).Parse(rawMessageSentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse message sent bytes. error: %w", err)
+	}
 
 	hashed := crypto.Keccak256(rawMessageSentBytes)
 	hashedHexStr := hex.EncodeToString(hashed)
 
 	messageState = &MessageState{
 		IrisLookupID:      hashedHexStr,
-		Status:            Created,
 		SourceDomain:      Domain(message.SourceDomain),
 		DestDomain:        Domain(message.DestinationDomain),
 		SourceTxHash:      log.TxHash.Hex(),
@@ -75,18 +103,161 @@ func EvmLogToMessageState(abi abi.ABI, messageSent abi.Event, log *ethtypes.Log)
 		Created:           time.Now(),
 		Updated:           time.Now(),
 	}
+	if err := messageState.SetStatus(Created); err != nil {
+		return nil, err
+	}
+
+	if burnMessage, err := new(BurnMessage).Parse(message.MessageBody); err == nil {
+		messageState.Type = Mint
+		messageState.setV2Fields(message, burnMessage)
+		return messageState, nil
+	}
 
-	if _, err := new(BurnMessage).Parse(message.MessageBody); err == nil {
+	// metadata messages are only kept if they pair with a burn, see TxState.PairForwards
+	if _, err := new(MetadataMessage).Parse(message.MessageBody); err == nil {
+		messageState.Type = Forward
 		return messageState, nil
 	}
 
-	return nil, fmt.Errorf("unable to parse tx into message, err: %w", err)
+	return nil, errors.New("unable to parse tx into message, message body is neither a burn nor a metadata message")
+}
+
+// PairForwards links the metadata messages sent by TokenMessengerWithMetadata to the burn they were emitted alongside.
+// The metadata is sent in the same call as the burn, so it carries the burn's nonce and its own nonce is the next one.
+// Paired messages become forwards and their channel and memo are set on both messages. Metadata messages without a
+// burn in the transaction are dropped.
+func (t *TxState) PairForwards() {
+	msgs := make([]*MessageState, 0, len(t.Msgs))
+	for _, msg := range t.Msgs {
+		mint := t.burnForMetadata(msg)
+		if mint == nil {
+			if msg.Type != Forward {
+				msgs = append(msgs, msg)
+			}
+			continue
+		}
+
+		metadata, _ := new(MetadataMessage).Parse(msg.MsgBody)
+		msg.Type = Forward
+		msg.Channel = fmt.Sprintf("channel-%d", metadata.Channel)
+		msg.Memo = metadata.Memo
+		mint.Channel = msg.Channel
+		mint.Memo = msg.Memo
+
+		msgs = append(msgs, msg)
+	}
+	t.Msgs = msgs
+}
+
+// Pair returns the other half of a forwarded transfer, the forward for a mint or the mint for a forward.
+func (t *TxState) Pair(msg *MessageState) *MessageState {
+	if msg.Channel == "" {
+		return nil
+	}
+	for _, other := range t.Msgs {
+		if other == msg || other.Channel != msg.Channel || other.SourceDomain != msg.SourceDomain {
+			continue
+		}
+		switch {
+		case msg.Type == Mint && other.Type == Forward && other.Nonce == msg.Nonce+1,
+			msg.Type == Forward && other.Type == Mint && msg.Nonce == other.Nonce+1:
+			return other
+		}
+	}
+	return nil
+}
+
+// burnForMetadata returns the burn in the transaction that the metadata message was sent for, if any.
+func (t *TxState) burnForMetadata(msg *MessageState) *MessageState {
+	if msg.IsV2() {
+		return nil
+	}
+
+	metadata, err := new(MetadataMessage).Parse(msg.MsgBody)
+	if err != nil {
+		return nil
+	}
+
+	for _, other := range t.Msgs {
+		if other == msg || other.IsV2() || other.SourceDomain != msg.SourceDomain {
+			continue
+		}
+		if _, err := new(BurnMessage).Parse(other.MsgBody); err != nil {
+			continue
+		}
+		if metadata.Nonce == other.Nonce && msg.Nonce == other.Nonce+1 {
+			return other
+		}
+	}
+	return nil
+}
+
+// IsV2 returns true if the message was emitted by a CCTP V2 MessageTransmitter
+func (m *MessageState) IsV2() bool {
+	return m.Version == MessageVersionV2
+}
+
+// ReceiveKey identifies the message on its destination chain, see Message.ReceiveKey
+func (m *MessageState) ReceiveKey() string {
+	return receiveKey(m.Version, m.SourceDomain, m.Nonce, m.NonceV2)
+}
+
+// IsFastTransfer returns true if the sender requested (or Circle attested at) a finality
+// threshold below finalized. Only V2 messages can be fast transfers.
+func (m *MessageState) IsFastTransfer() bool {
+	if !m.IsV2() {
+		return false
+	}
+	if m.FinalityThresholdExecuted != 0 {
+		return m.FinalityThresholdExecuted < FinalityThresholdFinalized
+	}
+	return m.MinFinalityThreshold < FinalityThresholdFinalized
+}
+
+// ApplyAttestedMessage replaces the emitted V2 message with the message returned by the attestation service.
+// Circle fills in the nonce, executed finality threshold and executed fee, and only the attested bytes
+// can be received on the destination chain.
+func (m *MessageState) ApplyAttestedMessage(attested []byte) error {
+	if !SameV2Message(m.MsgSentBytes, attested) {
+		return errors.New("attested message does not match the emitted message")
+	}
+
+	message, err := new(Message).Parse(attested)
+	if err != nil {
+		return fmt.Errorf("unable to parse attested message: %w", err)
+	}
+
+	m.MsgSentBytes = attested
+	m.MsgBody = message.MessageBody
+
+	// non-burn messages are relayed as is, they just do not carry fee information
+	burnMessage, _ := new(BurnMessage).Parse(message.MessageBody)
+	m.setV2Fields(message, burnMessage)
+
+	return nil
+}
+
+func (m *MessageState) setV2Fields(message *Message, burnMessage *BurnMessage) {
+	m.Version = message.Version
+	if message.Version != MessageVersionV2 {
+		return
+	}
+
+	m.NonceV2 = message.NonceV2
+	m.MinFinalityThreshold = message.MinFinalityThreshold
+	m.FinalityThresholdExecuted = message.FinalityThresholdExecuted
+
+	if burnMessage != nil {
+		m.MaxFee = burnMessage.MaxFee
+		m.FeeExecuted = burnMessage.FeeExecuted
+		m.HookData = burnMessage.HookData
+	}
 }
 
 // Equal checks if two MessageState instances are equal
 func (m *MessageState) Equal(other *MessageState) bool {
 	return (m.IrisLookupID == other.IrisLookupID &&
-		m.Status == other.Status &&
+		m.Status() == other.Status() &&
 		m.Attestation == other.Attestation &&
 		m.SourceDomain == other.SourceDomain &&
 		m.DestDomain == other.DestDomain &&
@@ -95,6 +266,11 @@ func (m *MessageState) Equal(other *MessageState) bool {
 		bytes.Equal(m.MsgSentBytes, other.MsgSentBytes) &&
 		bytes.Equal(m.DestinationCaller, other.DestinationCaller) &&
 		m.Channel == other.Channel &&
+		m.Memo == other.Memo &&
+		m.Type == other.Type &&
 		m.Created == other.Created &&
-		m.Updated == other.Updated)
+		m.Updated == other.Updated &&
+		m.Version == other.Version &&
+		bytes.Equal(m.NonceV2, other.NonceV2) &&
+		m.FinalityThresholdExecuted == other.FinalityThresholdExecuted)
 }
@@ -58,7 +58,7 @@ func TestToMessageStateSuccess(t *testing.T) {
 
 	destCaller := make([]byte, 32)
 	assert.Equal(t, "e40ed0e983675678715972bd50d6abc417735051b0255f3c0916911957eda603", messageState.IrisLookupID)
-	assert.Equal(t, "created", messageState.Status)
+	assert.Equal(t, "created", messageState.Status())
 	assert.Equal(t, "", messageState.Attestation)
 	assert.Equal(t, uint32(0), messageState.SourceDomain)
 	assert.Equal(t, uint32(4), messageState.DestDomain)
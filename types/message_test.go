@@ -0,0 +1,195 @@
+package types
+
+import (
+	"encoding/binary"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func buildBurnMessage(version uint32, amount, maxFee, feeExecuted int64, hookData []byte) []byte {
+	bz := make([]byte, burnContentLength)
+	binary.BigEndian.PutUint32(bz[versionIndex:], version)
+	bz[burnTokenIndex+31] = 0x1
+	bz[mintRecipientIndex+31] = 0x2
+	big.NewInt(amount).FillBytes(bz[amountIndex:msgSenderIndex])
+	bz[msgSenderIndex+31] = 0x3
+
+	if version == MessageVersionV2 {
+		v2 := make([]byte, hookDataIndex-burnContentLength)
+		big.NewInt(maxFee).FillBytes(v2[:32])
+		big.NewInt(feeExecuted).FillBytes(v2[32:64])
+		bz = append(bz, v2...)
+		bz = append(bz, hookData...)
+	}
+	return bz
+}
+
+func buildV2Message(nonce byte, thresholdExecuted uint32, body []byte) []byte {
+	bz := make([]byte, messageBodyV2Index)
+	binary.BigEndian.PutUint32(bz[versionIndex:], MessageVersionV2)
+	binary.BigEndian.PutUint32(bz[sourceDomainIndex:], 0)
+	binary.BigEndian.PutUint32(bz[destinationDomainIndex:], 3)
+	bz[senderV2Index-1] = nonce
+	binary.BigEndian.PutUint32(bz[minFinalityThresholdV2Index:], FinalityThresholdConfirmed)
+	binary.BigEndian.PutUint32(bz[finalityThresholdExecutedV2Index:], thresholdExecuted)
+	return append(bz, body...)
+}
+
+func TestParseV1Message(t *testing.T) {
+	bz := make([]byte, messageBodyIndex)
+	binary.BigEndian.PutUint32(bz[sourceDomainIndex:], 4)
+	binary.BigEndian.PutUint32(bz[destinationDomainIndex:], 0)
+	binary.BigEndian.PutUint64(bz[nonceIndex:], 612)
+	bz = append(bz, buildBurnMessage(MessageVersionV1, 10, 0, 0, nil)...)
+
+	msg, err := new(Message).Parse(bz)
+	require.NoError(t, err)
+	require.Equal(t, MessageVersionV1, msg.Version)
+	require.Equal(t, uint32(4), msg.SourceDomain)
+	require.Equal(t, uint64(612), msg.Nonce)
+	require.Nil(t, msg.NonceV2)
+
+	bm, err := new(BurnMessage).Parse(msg.MessageBody)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), bm.Amount.Int64())
+	require.Nil(t, bm.MaxFee)
+
+	// v1 burn messages have a fixed length
+	_, err = new(BurnMessage).Parse(append(msg.MessageBody, 0x0))
+	require.Error(t, err)
+}
+
+func TestEvmLogToMessageState(t *testing.T) {
+	f, err := os.Open("../ethereum/abi/MessageTransmitter.json")
+	require.NoError(t, err)
+	defer f.Close()
+	messageTransmitterABI, err := abi.JSON(f)
+	require.NoError(t, err)
+	messageSent := messageTransmitterABI.Events["MessageSent"]
+
+	bz := make([]byte, messageBodyIndex)
+	binary.BigEndian.PutUint32(bz[destinationDomainIndex:], 4)
+	bz = append(bz, buildBurnMessage(MessageVersionV1, 10, 0, 0, nil)...)
+	data, err := messageSent.Inputs.NonIndexed().Pack(bz)
+	require.NoError(t, err)
+
+	// evm messages are created with a history, like noble's
+	msg, err := EvmLogToMessageState(messageTransmitterABI, messageSent, &ethtypes.Log{Data: data})
+	require.NoError(t, err)
+	require.Equal(t, Created, msg.Status())
+	require.Len(t, msg.History, 1)
+	require.Equal(t, "", msg.History[0].From)
+	require.Equal(t, Created, msg.History[0].To)
+}
+
+func TestParseV2Message(t *testing.T) {
+	body := buildBurnMessage(MessageVersionV2, 1000, 5, 2, []byte("hook"))
+	bz := buildV2Message(7, FinalityThresholdConfirmed, body)
+
+	msg, err := new(Message).Parse(bz)
+	require.NoError(t, err)
+	require.Equal(t, MessageVersionV2, msg.Version)
+	require.Equal(t, uint32(3), msg.DestinationDomain)
+	require.Len(t, msg.NonceV2, 32)
+	require.Equal(t, byte(7), msg.NonceV2[31])
+	require.Equal(t, FinalityThresholdConfirmed, msg.MinFinalityThreshold)
+	require.Equal(t, FinalityThresholdConfirmed, msg.FinalityThresholdExecuted)
+
+	bm, err := new(BurnMessage).Parse(msg.MessageBody)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), bm.Amount.Int64())
+	require.Equal(t, int64(5), bm.MaxFee.Int64())
+	require.Equal(t, int64(2), bm.FeeExecuted.Int64())
+	require.Equal(t, []byte("hook"), bm.HookData)
+
+	_, err = new(Message).Parse(bz[:messageBodyV2Index-1])
+	require.Error(t, err)
+}
+
+func TestParseUnknownVersion(t *testing.T) {
+	bz := make([]byte, messageBodyV2Index)
+	binary.BigEndian.PutUint32(bz, 7)
+
+	_, err := new(Message).Parse(bz)
+	require.Error(t, err)
+
+	_, err = new(BurnMessage).Parse(bz)
+	require.Error(t, err)
+}
+
+func TestApplyAttestedMessage(t *testing.T) {
+	emitted := buildV2Message(0, 0, buildBurnMessage(MessageVersionV2, 1000, 5, 0, nil))
+	attested := buildV2Message(9, FinalityThresholdConfirmed, buildBurnMessage(MessageVersionV2, 1000, 5, 3, nil))
+	otherAmount := buildV2Message(9, FinalityThresholdConfirmed, buildBurnMessage(MessageVersionV2, 999, 5, 3, nil))
+
+	require.True(t, SameV2Message(emitted, attested))
+	require.False(t, SameV2Message(emitted, otherAmount))
+
+	msg := &MessageState{MsgSentBytes: emitted, Version: MessageVersionV2, MinFinalityThreshold: FinalityThresholdConfirmed}
+	require.True(t, msg.IsFastTransfer())
+
+	require.Error(t, msg.ApplyAttestedMessage(otherAmount))
+	require.NoError(t, msg.ApplyAttestedMessage(attested))
+	require.Equal(t, attested, msg.MsgSentBytes)
+	require.Equal(t, byte(9), msg.NonceV2[31])
+	require.Equal(t, FinalityThresholdConfirmed, msg.FinalityThresholdExecuted)
+	require.Equal(t, int64(3), msg.FeeExecuted.Int64())
+	require.True(t, msg.IsFastTransfer())
+}
+
+func buildMetadataMessage(burnNonce, channel uint64, memo string) []byte {
+	bz := make([]byte, 112)
+	binary.BigEndian.PutUint64(bz[0:], burnNonce)
+	binary.BigEndian.PutUint64(bz[40:], channel)
+	copy(bz[48+28:80], "osmo")
+	return append(bz, memo...)
+}
+
+func TestPairForwards(t *testing.T) {
+	mint := &MessageState{Nonce: 10, Type: Mint, MsgBody: buildBurnMessage(MessageVersionV1, 10, 0, 0, nil)}
+	forward := &MessageState{Nonce: 11, Type: Forward, MsgBody: buildMetadataMessage(10, 1, "{\"wasm\":{}}")}
+	orphan := &MessageState{Nonce: 13, Type: Forward, MsgBody: buildMetadataMessage(12, 1, "")}
+
+	metadata, err := new(MetadataMessage).Parse(forward.MsgBody)
+	require.NoError(t, err)
+	require.Equal(t, "osmo", metadata.Prefix)
+
+	tx := &TxState{Msgs: []*MessageState{mint, forward, orphan}}
+	tx.PairForwards()
+
+	require.Equal(t, []*MessageState{mint, forward}, tx.Msgs)
+	require.Equal(t, Mint, mint.Type)
+	require.Equal(t, Forward, forward.Type)
+	require.Equal(t, "channel-1", mint.Channel)
+	require.Equal(t, "channel-1", forward.Channel)
+	require.Equal(t, "{\"wasm\":{}}", mint.Memo)
+	require.Equal(t, forward, tx.Pair(mint))
+	require.Equal(t, mint, tx.Pair(forward))
+
+	// a plain burn has no pair
+	burn := &MessageState{Nonce: 20, Type: Mint, MsgBody: buildBurnMessage(MessageVersionV1, 10, 0, 0, nil)}
+	tx = &TxState{Msgs: []*MessageState{burn}}
+	tx.PairForwards()
+	require.Len(t, tx.Msgs, 1)
+	require.Nil(t, tx.Pair(burn))
+}
+
+func TestReceiveKey(t *testing.T) {
+	bz := make([]byte, messageBodyIndex)
+	binary.BigEndian.PutUint32(bz[sourceDomainIndex:], 4)
+	binary.BigEndian.PutUint64(bz[nonceIndex:], 612)
+	v1, err := new(Message).Parse(bz)
+	require.NoError(t, err)
+	require.Equal(t, (&MessageState{SourceDomain: 4, Nonce: 612}).ReceiveKey(), v1.ReceiveKey())
+	require.NotEqual(t, (&MessageState{SourceDomain: 0, Nonce: 612}).ReceiveKey(), v1.ReceiveKey())
+
+	// v2 nonces are unique across source domains
+	v2, err := new(Message).Parse(buildV2Message(7, FinalityThresholdConfirmed, nil))
+	require.NoError(t, err)
+	require.Equal(t, (&MessageState{SourceDomain: 3, Version: MessageVersionV2, NonceV2: v2.NonceV2}).ReceiveKey(), v2.ReceiveKey())
+}
@@ -37,6 +37,17 @@ func (sm *StateMap) Delete(key string) {
 	sm.internal.Delete(key)
 }
 
+// Range calls f for every transaction until f returns false. Mu is held during the iteration,
+// so f can read message states but must not lock Mu.
+func (sm *StateMap) Range(f func(key string, value *TxState) bool) {
+	sm.Mu.Lock()
+	defer sm.Mu.Unlock()
+
+	sm.internal.Range(func(key, value any) bool {
+		return f(key.(string), value.(*TxState))
+	})
+}
+
 // store stores the message states tied to a specific transaction hash
 func (sm *StateMap) Store(key string, value *TxState) {
 	sm.Mu.Lock()
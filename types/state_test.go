@@ -13,7 +13,7 @@ func TestStateHandling(t *testing.T) {
 	msg := MessageState{
 		SourceTxHash: txHash,
 		IrisLookupID: "123",
-		Status:       Filtered,
+		status:       Filtered,
 		MsgSentBytes: []byte("i like turtles"),
 	}
 
@@ -27,18 +27,18 @@ func TestStateHandling(t *testing.T) {
 	loadedMsg, _ := stateMap.Load(txHash)
 	require.True(t, msg.Equal(loadedMsg.Msgs[0]))
 
-	loadedMsg.Msgs[0].Status = Complete
+	loadedMsg.Msgs[0].status = Complete
 
 	// Because it is a pointer, no need to re-store to state
 	// message status should be updated with out re-storing.
 	loadedMsg2, _ := stateMap.Load(txHash)
-	require.Equal(t, Complete, loadedMsg2.Msgs[0].Status)
+	require.Equal(t, Complete, loadedMsg2.Msgs[0].Status())
 
 	// even though loadedMsg is a pointer, if we add to the array, we need to re-store in cache.
 	msg2 := MessageState{
 		SourceTxHash: txHash,
 		IrisLookupID: "123",
-		Status:       Filtered,
+		status:       Filtered,
 		MsgSentBytes: []byte("mock bytes 2"),
 	}
 
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"cosmossdk.io/log"
+)
+
+// ErrInvalidTransition is returned when a message is moved to a status it can not reach from its current one
+var ErrInvalidTransition = errors.New("invalid message status transition")
+
+// StatusMachine is the table of the statuses a message may move to from each status. Complete, failed and filtered
+// messages are done with and can not move.
+type StatusMachine map[string][]string
+
+// MessageStatuses are the valid status transitions of messages. Messages start without a status, retracted
+// messages are created again if their tx is seen again and messages relayed by another relayer are complete once
+// its tx lands, or attested again to be rebroadcast if it does not. Attested messages are only retracted if they are
+// fast transfers, attested before their block is final.
+var MessageStatuses = StatusMachine{
+	"":             {Created},
+	Created:        {Pending, Attested, Filtered, Retracted},
+	Pending:        {Attested, Filtered, Retracted},
+	Attested:       {Complete, Failed, Filtered, RelayedByOther, Retracted},
+	RelayedByOther: {Complete, Attested},
+	Retracted:      {Created},
+}
+
+// CanTransition returns whether a message may move from status from to status to
+func (sm StatusMachine) CanTransition(from, to string) bool {
+	return slices.Contains(sm[from], to)
+}
+
+// Transition is a status change of a message
+type Transition struct {
+	From string
+	To   string
+	Time time.Time
+	// Attempt is the broadcast attempt that moved the message, zero for changes outside of broadcasts
+	Attempt int `json:",omitempty"`
+	// Error is why the message failed, if it did
+	Error string `json:",omitempty"`
+}
+
+// SetStatus moves m to status and appends the transition to its history. Moving m to its current status does
+// nothing, moves MessageStatuses does not allow are rejected with ErrInvalidTransition.
+func (m *MessageState) SetStatus(status string) error {
+	return m.setStatus(status, 0, nil)
+}
+
+// SetBroadcastStatus is SetStatus for broadcasters. It records the broadcast attempt that moved m and the error it
+// failed with, if any, and logs rejected transitions.
+func (m *MessageState) SetBroadcastStatus(logger log.Logger, status string, attempt int, cause error) {
+	if err := m.setStatus(status, attempt, cause); err != nil {
+		logger.Error(fmt.Sprintf("Unable to update msg from %d with tx hash %s", m.SourceDomain, m.SourceTxHash), "err", err)
+	}
+}
+
+// Status returns the current status of m
+func (m *MessageState) Status() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+func (m *MessageState) setStatus(status string, attempt int, cause error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.status == status {
+		return nil
+	}
+	if !MessageStatuses.CanTransition(m.status, status) {
+		return fmt.Errorf("%w from %q to %q", ErrInvalidTransition, m.status, status)
+	}
+
+	transition := Transition{From: m.status, To: status, Time: time.Now(), Attempt: attempt}
+	if cause != nil {
+		transition.Error = cause.Error()
+	}
+	m.status = status
+	m.Updated = transition.Time
+	m.History = append(m.History, transition)
+	return nil
+}
+
+// MarshalJSON holds the lock of m while its status and history are marshaled
+func (m *MessageState) MarshalJSON() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type plain MessageState
+	return json.Marshal(struct {
+		*plain
+		Status string
+	}{(*plain)(m), m.status})
+}
@@ -0,0 +1,68 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/strangelove-ventures/noble-cctp-relayer/types"
+)
+
+func TestSetStatus(t *testing.T) {
+	msg := &types.MessageState{}
+
+	require.NoError(t, msg.SetStatus(types.Created))
+	require.NoError(t, msg.SetStatus(types.Pending))
+	// moving to the current status is not a transition
+	require.NoError(t, msg.SetStatus(types.Pending))
+	require.NoError(t, msg.SetStatus(types.Attested))
+
+	// done with messages can not move, illegal transitions leave the message as it is
+	err := msg.SetStatus(types.Created)
+	require.ErrorIs(t, err, types.ErrInvalidTransition)
+	require.Equal(t, types.Attested, msg.Status())
+
+	msg.SetBroadcastStatus(log.NewNopLogger(), types.Failed, 3, errors.New("out of gas"))
+	require.ErrorIs(t, msg.SetStatus(types.Complete), types.ErrInvalidTransition)
+	require.Equal(t, types.Failed, msg.Status())
+
+	require.Len(t, msg.History, 4)
+	for i, to := range []string{types.Created, types.Pending, types.Attested, types.Failed} {
+		require.Equal(t, to, msg.History[i].To)
+		if i > 0 {
+			require.Equal(t, msg.History[i-1].To, msg.History[i].From)
+			require.False(t, msg.History[i].Time.Before(msg.History[i-1].Time))
+		}
+	}
+	require.Equal(t, 3, msg.History[3].Attempt)
+	require.Equal(t, "out of gas", msg.History[3].Error)
+	require.Equal(t, msg.History[3].Time, msg.Updated)
+
+	// the history is part of the message's json
+	bz, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var decoded struct {
+		Status  string
+		History []types.Transition
+	}
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, types.Failed, decoded.Status)
+	require.Len(t, decoded.History, 4)
+	require.Equal(t, "out of gas", decoded.History[3].Error)
+}
+
+func TestStatusMachine(t *testing.T) {
+	require.True(t, types.MessageStatuses.CanTransition(types.Retracted, types.Created))
+	require.True(t, types.MessageStatuses.CanTransition(types.RelayedByOther, types.Complete))
+	// rebroadcast if the other relayer's tx does not land
+	require.True(t, types.MessageStatuses.CanTransition(types.RelayedByOther, types.Attested))
+	// fast transfers are attested before their block is final
+	require.True(t, types.MessageStatuses.CanTransition(types.Attested, types.Retracted))
+	require.False(t, types.MessageStatuses.CanTransition(types.Complete, types.Retracted))
+	require.False(t, types.MessageStatuses.CanTransition(types.Filtered, types.Attested))
+	require.False(t, types.MessageStatuses.CanTransition(types.Complete, types.Failed))
+}